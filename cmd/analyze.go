@@ -9,7 +9,9 @@ import (
 	"github.com/Legit-Labs/legitify/internal/screen"
 
 	"github.com/Legit-Labs/legitify/internal/common/namespace"
+	"github.com/Legit-Labs/legitify/internal/common/repo_filter"
 	"github.com/Legit-Labs/legitify/internal/common/scm_type"
+	"github.com/Legit-Labs/legitify/internal/context_utils"
 	"github.com/spf13/cobra"
 	"github.com/spf13/viper"
 )
@@ -31,6 +33,14 @@ const (
 	argFailedOnly                 = "failed-only"
 	argSimulateSecondaryRateLimit = "simulate-secondary-rate-limit"
 	argIgnorePolicies             = "ignore-policies-file"
+	argCreateIssues               = "create-issues"
+	argIssuesRepository           = "issues-repo"
+	argTrendsDB                   = "trends-db"
+	argBranchPatterns             = "branch-patterns"
+	argIncludeArchived            = "include-archived"
+	argIncludeUserRepos           = "include-user-repos"
+	argRepoFilter                 = "repo-filter"
+	argAuditDays                  = "audit-days"
 )
 
 func toOptionsString(options []string) string {
@@ -66,9 +76,17 @@ func newAnalyzeCommand() *cobra.Command {
 	flags.StringSliceVarP(&analyzeArgs.Repositories, argRepository, "", nil, "specific repositories to collect (--repo owner/repo_name (e.g. ossf/scorecard)")
 	flags.StringSliceVarP(&analyzeArgs.Enterprises, argEnterprises, "", nil, "specific enterprises to collect (--enterprise your_enterprise_slug) this flag must be provided with a value")
 	flags.StringSliceVarP(&analyzeArgs.PoliciesPath, argPoliciesPath, "p", []string{}, "directory containing opa policies")
-	flags.StringSliceVarP(&analyzeArgs.Namespaces, argNamespace, "n", namespace.All, "which namespace to run")
+	flags.StringSliceVarP(&analyzeArgs.Namespaces, argNamespace, "n", namespace.Default, "which namespace to run")
 	flags.StringVarP(&analyzeArgs.IgnoredPolicies, argIgnorePolicies, "", "", "path to a file that contain \n separated list of policies to ignore")
+	flags.StringSliceVarP(&analyzeArgs.BranchPatterns, argBranchPatterns, "", []string{"release/*"}, "branches to analyze in addition to the default branch and the protected branches (--branch-patterns 'release/*,prod')")
+	flags.BoolVarP(&analyzeArgs.IncludeArchived, argIncludeArchived, "", false, "analyze archived repositories as well")
+	flags.BoolVarP(&analyzeArgs.IncludeUserRepos, argIncludeUserRepos, "", false, "analyze the repositories that the members of the organizations own personally as well")
+	flags.StringSliceVarP(&analyzeArgs.RepoFilter, argRepoFilter, "", nil, "analyze only the repositories that match all the filtered fields (--repo-filter 'topic:prod,visibility:private,name:payments-*,property.tier:production')")
+	flags.IntVarP(&analyzeArgs.AuditDays, argAuditDays, "", context_utils.DefaultAuditDays, "the number of days back to analyze the audit log in the audit namespace")
 	flags.StringVarP(&analyzeArgs.ScorecardWhen, argScorecard, "", DefaultScOption, "Whether to run additional scorecard checks "+scorecardWhens)
+	flags.BoolVarP(&analyzeArgs.CreateIssues, argCreateIssues, "", false, "open/update an issue for each failed policy and close the issues of fixed policies")
+	flags.StringVarP(&analyzeArgs.IssuesRepository, argIssuesRepository, "", "", "central repository to open all the issues in (--issues-repo owner/repo_name), defaults to the violating repository")
+	flags.StringVarP(&analyzeArgs.TrendsDB, argTrendsDB, "", "", "path to a sqlite db to record the results in, for tracking the posture over time (see: legitify report trends)")
 	flags.BoolVarP(&analyzeArgs.SimulateSecondaryRateLimit, argSimulateSecondaryRateLimit, "", false, "Simulate secondary rate limits (for testing purposes)")
 	_ = flags.MarkHidden(argSimulateSecondaryRateLimit)
 
@@ -88,6 +106,31 @@ func validateAnalyzeArgs() error {
 		return fmt.Errorf("cannot use --org & --repo options together")
 	}
 
+	if analyzeArgs.IncludeUserRepos && len(analyzeArgs.Repositories) != 0 {
+		return fmt.Errorf("cannot use --%s & --repo options together", argIncludeUserRepos)
+	}
+
+	if analyzeArgs.AuditDays <= 0 {
+		return fmt.Errorf("--%s must be positive", argAuditDays)
+	}
+
+	filter, err := repo_filter.Parse(analyzeArgs.RepoFilter)
+	if err != nil {
+		return err
+	}
+	if filter.NeedsProperties() && analyzeArgs.ScmType != scm_type.GitHub {
+		return fmt.Errorf("--%s by custom properties is only supported for GitHub", argRepoFilter)
+	}
+
+	if analyzeArgs.IssuesRepository != "" {
+		if !analyzeArgs.CreateIssues {
+			return fmt.Errorf("--%s must be used together with --%s", argIssuesRepository, argCreateIssues)
+		}
+		if _, err := validateRepositories([]string{analyzeArgs.IssuesRepository}); err != nil {
+			return err
+		}
+	}
+
 	return nil
 }
 
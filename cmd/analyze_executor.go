@@ -9,27 +9,38 @@ import (
 	"github.com/Legit-Labs/legitify/internal/collectors/collectors_manager"
 	"github.com/Legit-Labs/legitify/internal/enricher"
 	"github.com/Legit-Labs/legitify/internal/errlog"
+	"github.com/Legit-Labs/legitify/internal/issues"
 	"github.com/Legit-Labs/legitify/internal/outputer"
+	"github.com/Legit-Labs/legitify/internal/trends"
 )
 
 type analyzeExecutor struct {
 	manager         collectors_manager.CollectorManager
 	analyzer        analyzers.Analyzer
 	enricherManager enricher.EnricherManager
+	postureScorer   enricher.PostureScorer
 	out             outputer.Outputer
+	issueTracker    issues.Tracker
+	trendsRecorder  trends.Recorder
 	ctx             context.Context
 }
 
 func initializeAnalyzeExecutor(manager collectors_manager.CollectorManager,
 	analyzer analyzers.Analyzer,
 	enricherManager enricher.EnricherManager,
+	postureScorer enricher.PostureScorer,
 	outputer outputer.Outputer,
+	issueTracker issues.Tracker,
+	trendsRecorder trends.Recorder,
 	ctx context.Context) *analyzeExecutor {
 	return &analyzeExecutor{
 		manager:         manager,
 		analyzer:        analyzer,
 		enricherManager: enricherManager,
+		postureScorer:   postureScorer,
 		out:             outputer,
+		issueTracker:    issueTracker,
+		trendsRecorder:  trendsRecorder,
 		ctx:             ctx,
 	}
 }
@@ -44,7 +55,10 @@ func (r *analyzeExecutor) Run() error {
 	collectionChan := r.manager.Collect()
 	analyzedDataChan := r.analyzer.Analyze(collectionChan)
 	enrichedDataChan := r.enricherManager.Enrich(r.ctx, analyzedDataChan)
-	outputWaiter := r.out.Digest(enrichedDataChan)
+	scoredDataChan := r.postureScorer.Score(enrichedDataChan)
+	trackedDataChan := r.issueTracker.Track(scoredDataChan)
+	recordedDataChan := r.trendsRecorder.Record(trackedDataChan)
+	outputWaiter := r.out.Digest(recordedDataChan)
 
 	// wait for progress bars to finish before outputting
 	pWaiter.Wait()
@@ -52,5 +66,13 @@ func (r *analyzeExecutor) Run() error {
 	// wait for output to be digested
 	outputWaiter.Wait()
 
-	return r.out.Output(os.Stdout)
+	if err := r.out.Output(os.Stdout); err != nil {
+		return err
+	}
+
+	if err := r.trendsRecorder.Save(); err != nil {
+		return err
+	}
+
+	return r.issueTracker.Sync()
 }
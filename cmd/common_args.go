@@ -29,6 +29,11 @@ type args struct {
 	PoliciesPath               []string
 	Namespaces                 []string
 	IgnoredPolicies            string
+	BranchPatterns             []string
+	IncludeArchived            bool
+	IncludeUserRepos           bool
+	RepoFilter                 []string
+	AuditDays                  int
 	ColorWhen                  string
 	OutputFile                 string
 	ErrorFile                  string
@@ -40,6 +45,14 @@ type args struct {
 	SimulateSecondaryRateLimit bool
 	IgnoreInvalidCertificate   bool
 	PermissionsOutputFile      string
+	CreateIssues               bool
+	IssuesRepository           string
+	RemediatePolicies          []string
+	DryRun                     bool
+	TrendsDB                   string
+	TrendsPeriod               string
+	TrendsPolicy               string
+	TrendsSince                string
 }
 
 const (
@@ -5,15 +5,21 @@ import (
 	"context"
 	"fmt"
 	"github.com/Legit-Labs/legitify/internal/common/namespace"
+	"github.com/Legit-Labs/legitify/internal/common/repo_filter"
 	"github.com/Legit-Labs/legitify/internal/common/scm_type"
+	"github.com/Legit-Labs/legitify/internal/common/slice_utils"
+	"github.com/Legit-Labs/legitify/internal/common/types"
 	"github.com/Legit-Labs/legitify/internal/context_utils"
 	"github.com/Legit-Labs/legitify/internal/gpt"
 	"github.com/Legit-Labs/legitify/internal/opa"
 	"github.com/Legit-Labs/legitify/internal/opa/opa_engine"
 	"github.com/Legit-Labs/legitify/internal/outputer"
+	"github.com/Legit-Labs/legitify/internal/outputer/formatter"
+	"github.com/Legit-Labs/legitify/internal/trends"
 	"log"
 	"os"
 	"strings"
+	"time"
 )
 
 func provideGenericClient(args *args) (Client, error) {
@@ -78,19 +84,45 @@ func provideContext(client Client, args *args) (context.Context, error) {
 			return nil, err
 		}
 		ctx = context_utils.NewContextWithRepos(validated)
-		args.Namespaces = []namespace.Namespace{namespace.Repository}
+		namespaces := []namespace.Namespace{namespace.Repository}
+		if slice_utils.Contains(args.Namespaces, namespace.Branch) {
+			namespaces = append(namespaces, namespace.Branch)
+		}
+		args.Namespaces = namespaces
 	}
 
 	ctx = context_utils.NewContextWithScorecard(ctx,
 		IsScorecardEnabled(args.ScorecardWhen),
 		IsScorecardVerbose(args.ScorecardWhen))
 
+	ctx = context_utils.NewContextWithTerraform(ctx, args.OutputFormat == formatter.Terraform)
 	ctx = context_utils.NewContextWithIsCloud(ctx, args.Endpoint == "")
 	ctx = context_utils.NewContextWithIgnoredPolicies(ctx, getIgnoredPolicies(args))
+	ctx = context_utils.NewContextWithBranchPatterns(ctx, args.BranchPatterns)
+	ctx = context_utils.NewContextWithIncludedRepositories(ctx, args.IncludeArchived, args.IncludeUserRepos)
+	// already validated by validateAnalyzeArgs
+	filter, _ := repo_filter.Parse(args.RepoFilter)
+	ctx = context_utils.NewContextWithRepositoryFilter(ctx, filter)
+	ctx = context_utils.NewContextWithAuditSince(ctx, time.Now().AddDate(0, 0, -args.AuditDays))
 
 	return context_utils.NewContextWithTokenScopes(ctx, client.Scopes()), nil
 }
 
+// getIssuesRepository returns the central repository for issues, or nil to open the issues in the violating repositories
+func getIssuesRepository(args *args) *types.RepositoryWithOwner {
+	if args.IssuesRepository == "" {
+		return nil
+	}
+
+	// already validated by validateAnalyzeArgs
+	validated, _ := validateRepositories([]string{args.IssuesRepository})
+	return &validated[0]
+}
+
 func provideGPTAnalyzer(context context.Context, args *args) *gpt.Analyzer {
 	return gpt.NewAnalyzer(context, args.OpenAIToken)
 }
+
+func provideTrendsRecorder(analyzeArgs *args) trends.Recorder {
+	return trends.NewRecorder(analyzeArgs.TrendsDB, analyzeArgs.ScmType)
+}
@@ -43,6 +43,10 @@ func validateConvertArgs() error {
 		return fmt.Errorf("please provide an input file")
 	}
 
+	if convertArgs.OutputFormat == formatter.Terraform {
+		return fmt.Errorf("the %s format is not supported by convert since the analysis output does not keep the terraform data (use: legitify analyze -f %s)", formatter.Terraform, formatter.Terraform)
+	}
+
 	return nil
 }
 
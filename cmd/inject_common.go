@@ -17,9 +17,12 @@ var analyzeProviderSet = wire.NewSet(
 	provideContext,
 	analyzers.NewAnalyzer,
 	provideGPTAnalyzer,
+	provideTrendsRecorder,
 	skippers.NewSkipper,
 	enricher.NewEnricherManager,
+	enricher.NewPostureScorer,
 	collectors_manager.NewCollectorsManager,
 	initializeAnalyzeExecutor,
 	initializeAnalyzeGPTExecutor,
+	initializeRemediateExecutor,
 )
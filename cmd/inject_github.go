@@ -10,6 +10,8 @@ import (
 	github2 "github.com/Legit-Labs/legitify/internal/collectors/github"
 	"github.com/Legit-Labs/legitify/internal/common/namespace"
 	"github.com/Legit-Labs/legitify/internal/context_utils"
+	"github.com/Legit-Labs/legitify/internal/issues"
+	"github.com/Legit-Labs/legitify/internal/remediation"
 	"github.com/google/wire"
 )
 
@@ -19,6 +21,7 @@ func setupGitHub(analyzeArgs *args) (*analyzeExecutor, error) {
 		analyzeProviderSet,
 		provideGitHubClient,
 		provideGitHubCollectors,
+		provideGitHubIssueTracker,
 	)
 	return nil, nil
 }
@@ -33,6 +36,17 @@ func setupGitHubGPTExecutor(analyzeArgs *args) (*analyzeGPTExecutor, error) {
 	return nil, nil
 }
 
+func setupGitHubRemediateExecutor(analyzeArgs *args) (*remediateExecutor, error) {
+	wire.Build(
+		wire.Bind(new(Client), new(*github.Client)),
+		analyzeProviderSet,
+		provideGitHubClient,
+		provideGitHubCollectors,
+		provideGitHubRemediator,
+	)
+	return nil, nil
+}
+
 func provideGitHubCollectors(ctx context.Context, client *github.Client, analyzeArgs *args) []collectors.Collector {
 	type newCollectorFunc func(ctx context.Context, client *github.Client) collectors.Collector
 	var collectorsMapping = map[namespace.Namespace]newCollectorFunc{
@@ -42,6 +56,11 @@ func provideGitHubCollectors(ctx context.Context, client *github.Client, analyze
 		namespace.Member:       github2.NewMemberCollector,
 		namespace.Actions:      github2.NewActionCollector,
 		namespace.RunnerGroup:  github2.NewRunnersCollector,
+		namespace.Branch:       github2.NewBranchCollector,
+		namespace.Apps:         github2.NewAppsCollector,
+		namespace.Team:         github2.NewTeamCollector,
+		namespace.Runner:       github2.NewRunnerCollector,
+		namespace.Audit:        github2.NewAuditCollector,
 	}
 
 	var result []collectors.Collector
@@ -52,6 +71,15 @@ func provideGitHubCollectors(ctx context.Context, client *github.Client, analyze
 	return result
 }
 
+func provideGitHubIssueTracker(ctx context.Context, client *github.Client, analyzeArgs *args) issues.Tracker {
+	return issues.NewTracker(issues.NewGitHubClient(ctx, client), analyzeArgs.CreateIssues, getIssuesRepository(analyzeArgs))
+}
+
+func provideGitHubRemediator(ctx context.Context, client *github.Client, analyzeArgs *args) remediation.Remediator {
+	return remediation.NewRemediator(remediation.NewGitHubExecutor(ctx, client), remediation.GitHubRemediations,
+		analyzeArgs.RemediatePolicies, analyzeArgs.DryRun)
+}
+
 func provideGitHubClient(analyzeArgs *args) (*github.Client, error) {
 	ctx := context_utils.NewContextWithSimulatedSecondaryRateLimit(context.Background(), analyzeArgs.SimulateSecondaryRateLimit)
 	return github.NewClient(ctx, analyzeArgs.Token, analyzeArgs.Endpoint,
@@ -9,6 +9,8 @@ import (
 	"github.com/Legit-Labs/legitify/internal/collectors"
 	"github.com/Legit-Labs/legitify/internal/collectors/gitlab"
 	"github.com/Legit-Labs/legitify/internal/common/namespace"
+	"github.com/Legit-Labs/legitify/internal/issues"
+	"github.com/Legit-Labs/legitify/internal/remediation"
 	"github.com/google/wire"
 )
 
@@ -18,6 +20,7 @@ func setupGitLab(analyzeArgs *args) (*analyzeExecutor, error) {
 		analyzeProviderSet,
 		provideGitLabClient,
 		provideGitLabCollectors,
+		provideGitLabIssueTracker,
 	)
 	return nil, nil
 }
@@ -32,12 +35,25 @@ func setupGitLabGPTExecutor(analyzeArgs *args) (*analyzeGPTExecutor, error) {
 	return nil, nil
 }
 
+func setupGitLabRemediateExecutor(analyzeArgs *args) (*remediateExecutor, error) {
+	wire.Build(
+		wire.Bind(new(Client), new(*glclient.Client)),
+		analyzeProviderSet,
+		provideGitLabClient,
+		provideGitLabCollectors,
+		provideGitLabRemediator,
+	)
+	return nil, nil
+}
+
 func provideGitLabCollectors(ctx context.Context, client *glclient.Client, analyzeArgs *args) []collectors.Collector {
 	var collectorsMapping = map[namespace.Namespace]func(ctx context.Context, client *glclient.Client) collectors.Collector{
 		namespace.Organization: gitlab.NewGroupCollector,
 		namespace.Repository:   gitlab.NewRepositoryCollector,
 		namespace.Member:       gitlab.NewUserCollector,
 		namespace.Enterprise:   gitlab.NewServerCollector,
+		namespace.Team:         gitlab.NewTeamCollector,
+		namespace.Audit:        gitlab.NewAuditCollector,
 	}
 
 	var result []collectors.Collector
@@ -50,6 +66,15 @@ func provideGitLabCollectors(ctx context.Context, client *glclient.Client, analy
 	return result
 }
 
+func provideGitLabIssueTracker(client *glclient.Client, analyzeArgs *args) issues.Tracker {
+	return issues.NewTracker(issues.NewGitLabClient(client), analyzeArgs.CreateIssues, getIssuesRepository(analyzeArgs))
+}
+
+func provideGitLabRemediator(client *glclient.Client, analyzeArgs *args) remediation.Remediator {
+	return remediation.NewRemediator(remediation.NewGitLabExecutor(client), remediation.GitLabRemediations,
+		analyzeArgs.RemediatePolicies, analyzeArgs.DryRun)
+}
+
 func provideGitLabClient(analyzeArgs *args) (*glclient.Client, error) {
 	return glclient.NewClient(context.Background(), analyzeArgs.Token, analyzeArgs.Endpoint, analyzeArgs.Organizations)
 }
@@ -0,0 +1,105 @@
+package cmd
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/Legit-Labs/legitify/internal/common/scm_type"
+	"github.com/Legit-Labs/legitify/internal/common/slice_utils"
+	"github.com/Legit-Labs/legitify/internal/remediation"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+func init() {
+	rootCmd.AddCommand(newRemediateCommand())
+}
+
+const (
+	argRemediatePolicy = "policy"
+	argDryRun          = "dry-run"
+)
+
+var remediateArgs args
+
+func newRemediateCommand() *cobra.Command {
+	remediateCmd := &cobra.Command{
+		Use:          "remediate",
+		Short:        `Fix failed policies of safe settings via the GitHub/GitLab API`,
+		RunE:         executeRemediateCommand,
+		SilenceUsage: true,
+	}
+
+	viper.AutomaticEnv()
+	flags := remediateCmd.Flags()
+	remediateArgs.addCommonCollectionOptions(flags)
+	remediateArgs.addOutputOptions(flags)
+
+	flags.StringSliceVarP(&remediateArgs.Organizations, argOrg, "", nil, "specific organizations to remediate")
+	flags.StringSliceVarP(&remediateArgs.Repositories, argRepository, "", nil, "specific repositories to remediate (--repo owner/repo_name (e.g. ossf/scorecard)")
+	flags.StringSliceVarP(&remediateArgs.PoliciesPath, argPoliciesPath, "p", []string{}, "directory containing opa policies")
+	flags.StringSliceVarP(&remediateArgs.RemediatePolicies, argRemediatePolicy, "", nil,
+		"policies to remediate (required), github: "+toOptionsString(remediation.PolicyNames(remediation.GitHubRemediations))+
+			" gitlab: "+toOptionsString(remediation.PolicyNames(remediation.GitLabRemediations)))
+	flags.BoolVarP(&remediateArgs.DryRun, argDryRun, "", false, "only print the API calls that would be made, without applying them")
+
+	return remediateCmd
+}
+
+func validateRemediateArgs() error {
+	if len(remediateArgs.Organizations) != 0 && len(remediateArgs.Repositories) != 0 {
+		return fmt.Errorf("cannot use --org & --repo options together")
+	}
+
+	if len(remediateArgs.RemediatePolicies) == 0 {
+		return fmt.Errorf("must specify at least one policy to remediate using --%s", argRemediatePolicy)
+	}
+
+	supported := remediation.PolicyNames(remediation.Supported(remediateArgs.ScmType))
+	for _, p := range remediateArgs.RemediatePolicies {
+		if !slice_utils.Contains(supported, p) {
+			return fmt.Errorf("policy %s cannot be remediated automatically on %s (supported: %s)",
+				p, remediateArgs.ScmType, strings.Join(supported, ", "))
+		}
+	}
+
+	return nil
+}
+
+func setupRemediateExecutor(remediateArgs *args) (*remediateExecutor, error) {
+	switch remediateArgs.ScmType {
+	case scm_type.GitHub:
+		return setupGitHubRemediateExecutor(remediateArgs)
+	case scm_type.GitLab:
+		return setupGitLabRemediateExecutor(remediateArgs)
+	default:
+		// shouldn't happen since scm type is validated before
+		return nil, fmt.Errorf("invalid scm type %s", remediateArgs.ScmType)
+	}
+}
+
+func executeRemediateCommand(cmd *cobra.Command, _args []string) error {
+	if err := remediateArgs.applyCommonCollectionOptions(); err != nil {
+		return err
+	}
+
+	if err := validateRemediateArgs(); err != nil {
+		return err
+	}
+
+	preExitHook, err := remediateArgs.applyOutputOptions()
+	if err != nil {
+		return err
+	}
+	defer preExitHook()
+
+	// collect only the namespaces of the selected policies
+	remediateArgs.Namespaces = remediation.Namespaces(remediation.Supported(remediateArgs.ScmType), remediateArgs.RemediatePolicies)
+
+	executor, err := setupRemediateExecutor(&remediateArgs)
+	if err != nil {
+		return err
+	}
+
+	return executor.Run()
+}
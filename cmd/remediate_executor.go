@@ -0,0 +1,90 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/Legit-Labs/legitify/cmd/progressbar"
+	"github.com/Legit-Labs/legitify/internal/analyzers"
+	"github.com/Legit-Labs/legitify/internal/collectors/collectors_manager"
+	"github.com/Legit-Labs/legitify/internal/errlog"
+	"github.com/Legit-Labs/legitify/internal/remediation"
+	"github.com/fatih/color"
+)
+
+type remediateExecutor struct {
+	manager    collectors_manager.CollectorManager
+	analyzer   analyzers.Analyzer
+	remediator remediation.Remediator
+}
+
+func initializeRemediateExecutor(manager collectors_manager.CollectorManager,
+	analyzer analyzers.Analyzer,
+	remediator remediation.Remediator) *remediateExecutor {
+	return &remediateExecutor{
+		manager:    manager,
+		analyzer:   analyzer,
+		remediator: remediator,
+	}
+}
+
+func formatRemediationResults(results []remediation.Result, dryRun bool) string {
+	sb := strings.Builder{}
+	if dryRun {
+		sb.WriteString(color.YellowString("Dry run: no changes were made\n"))
+	}
+
+	if len(results) == 0 {
+		sb.WriteString("No failed policies to remediate\n")
+		return sb.String()
+	}
+
+	for _, r := range results {
+		sb.WriteString("\n")
+		sb.WriteString(color.HiCyanString("%s: %s (%s)\n", r.Data.Namespace, r.Data.PolicyName, r.Data.Entity.Name()))
+		sb.WriteString(fmt.Sprintf("Url: %s\n", r.Data.CanonicalLink))
+		sb.WriteString(fmt.Sprintf("Remediation: %s\n", r.Remediation.Description))
+		for _, call := range r.Calls {
+			sb.WriteString(fmt.Sprintf("  %s\n", call.String()))
+		}
+		switch {
+		case r.Err != nil:
+			sb.WriteString(color.RedString("Failed: %v\n", r.Err))
+		case !dryRun:
+			sb.WriteString(color.GreenString("Remediated\n"))
+		}
+	}
+
+	return sb.String()
+}
+
+func (r *remediateExecutor) Run() error {
+	defer errlog.FlushAll()
+
+	pWaiter := progressbar.Run()
+
+	collectionChan := r.manager.Collect()
+	analyzedDataChan := r.analyzer.Analyze(collectionChan)
+
+	var results []remediation.Result
+	failed := 0
+	for res := range r.remediator.Remediate(analyzedDataChan) {
+		if res.Err != nil {
+			failed++
+		}
+		results = append(results, res)
+	}
+
+	pWaiter.Wait()
+
+	if _, err := fmt.Fprint(os.Stdout, formatRemediationResults(results, r.remediator.DryRun())); err != nil {
+		return err
+	}
+
+	if failed > 0 {
+		return fmt.Errorf("failed to remediate %d out of %d violations", failed, len(results))
+	}
+
+	return nil
+}
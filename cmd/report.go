@@ -0,0 +1,135 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/Legit-Labs/legitify/internal/common/scm_type"
+	"github.com/Legit-Labs/legitify/internal/trends"
+	"github.com/spf13/cobra"
+)
+
+func init() {
+	reportCmd := &cobra.Command{
+		Use:   "report",
+		Short: `Generate reports from the data recorded by previous analyze runs`,
+	}
+	reportCmd.AddCommand(newReportTrendsCommand())
+	rootCmd.AddCommand(reportCmd)
+}
+
+const (
+	argPeriod       = "period"
+	argTrendsPolicy = "policy"
+	argSince        = "since"
+	sinceLayout     = "2006-01-02"
+	reportHuman     = "human"
+	reportJson      = "json"
+)
+
+var reportArgs args
+
+func newReportTrendsCommand() *cobra.Command {
+	trendsCmd := &cobra.Command{
+		Use:          "trends",
+		Short:        `Show the posture over time, the time to fix failed policies and regressions`,
+		RunE:         executeReportTrendsCommand,
+		SilenceUsage: true,
+	}
+
+	flags := trendsCmd.Flags()
+	flags.StringVarP(&reportArgs.TrendsDB, argTrendsDB, "", "", "path to the sqlite db the analyze command recorded the results in (required)")
+	flags.StringVarP(&reportArgs.TrendsPeriod, argPeriod, "", trends.PeriodRun, "summarize the results by "+toOptionsString(trends.Periods))
+	flags.StringVarP(&reportArgs.TrendsPolicy, argTrendsPolicy, "", "", "only report the given policy (e.g. missing_default_branch_protection)")
+	flags.StringSliceVarP(&reportArgs.Organizations, argOrg, "", nil, "only report the given organizations (GitLab: groups)")
+	flags.StringVarP(&reportArgs.ScmType, ScmType, "", "", "only report the runs of the given server type (GitHub, GitLab), required when the db has runs of both")
+	flags.StringVarP(&reportArgs.TrendsSince, argSince, "", "", "only report runs since the given date (YYYY-MM-DD)")
+	flags.StringVarP(&reportArgs.OutputFormat, argOutputFormat, "f", reportHuman, "output format "+toOptionsString([]string{reportHuman, reportJson}))
+	flags.StringVarP(&reportArgs.OutputFile, ArgOutputFile, "o", "", "output file, defaults to stdout")
+
+	return trendsCmd
+}
+
+func validateReportTrendsArgs() error {
+	if reportArgs.TrendsDB == "" {
+		return fmt.Errorf("please provide the trends db using --%s", argTrendsDB)
+	}
+	if _, err := os.Stat(reportArgs.TrendsDB); err != nil {
+		return fmt.Errorf("failed to access the trends db: %v", err)
+	}
+
+	if err := trends.ValidatePeriod(reportArgs.TrendsPeriod); err != nil {
+		return err
+	}
+
+	if reportArgs.ScmType != "" {
+		if err := scm_type.Validate(reportArgs.ScmType); err != nil {
+			return err
+		}
+	}
+
+	if reportArgs.OutputFormat != reportHuman && reportArgs.OutputFormat != reportJson {
+		return fmt.Errorf("invalid output format %s", reportArgs.OutputFormat)
+	}
+
+	if reportArgs.TrendsSince != "" {
+		if _, err := time.Parse(sinceLayout, reportArgs.TrendsSince); err != nil {
+			return fmt.Errorf("invalid --%s date %s (expected YYYY-MM-DD)", argSince, reportArgs.TrendsSince)
+		}
+	}
+
+	return nil
+}
+
+func executeReportTrendsCommand(cmd *cobra.Command, _args []string) error {
+	if err := validateReportTrendsArgs(); err != nil {
+		return err
+	}
+
+	if err := setOutputFile(reportArgs.OutputFile); err != nil {
+		return err
+	}
+
+	var since time.Time
+	if reportArgs.TrendsSince != "" {
+		// already validated
+		since, _ = time.Parse(sinceLayout, reportArgs.TrendsSince)
+	}
+
+	store, err := trends.Open(reportArgs.TrendsDB)
+	if err != nil {
+		return err
+	}
+	defer store.Close()
+
+	runs, err := store.Load(since)
+	if err != nil {
+		return fmt.Errorf("failed to load the trends db: %v", err)
+	}
+
+	if scmTypes := trends.ScmTypes(runs); reportArgs.ScmType == "" && len(scmTypes) > 1 {
+		return fmt.Errorf("the trends db has runs of %s, please select one using --%s", strings.Join(scmTypes, " and "), ScmType)
+	}
+
+	report := trends.BuildReport(runs, reportArgs.TrendsPeriod, trends.Filter{
+		Policy:        reportArgs.TrendsPolicy,
+		Organizations: reportArgs.Organizations,
+		ScmType:       reportArgs.ScmType,
+	})
+
+	var output []byte
+	if reportArgs.OutputFormat == reportJson {
+		output, err = json.MarshalIndent(report, "", "  ")
+		if err != nil {
+			return err
+		}
+	} else {
+		output = report.Human()
+	}
+
+	_, err = os.Stdout.Write(output)
+	return err
+}
@@ -19,6 +19,8 @@ import (
 	"github.com/Legit-Labs/legitify/internal/common/namespace"
 	"github.com/Legit-Labs/legitify/internal/context_utils"
 	"github.com/Legit-Labs/legitify/internal/enricher"
+	"github.com/Legit-Labs/legitify/internal/issues"
+	"github.com/Legit-Labs/legitify/internal/remediation"
 )
 
 // Injectors from inject_github.go:
@@ -41,8 +43,11 @@ func setupGitHub(analyzeArgs2 *args) (*analyzeExecutor, error) {
 	skipper := skippers.NewSkipper(context)
 	analyzer := analyzers.NewAnalyzer(context, enginer, skipper)
 	enricherManager := enricher.NewEnricherManager()
+	postureScorer := enricher.NewPostureScorer()
 	outputer := provideOutputer(context, analyzeArgs2)
-	cmdAnalyzeExecutor := initializeAnalyzeExecutor(collectorManager, analyzer, enricherManager, outputer, context)
+	tracker := provideGitHubIssueTracker(context, client, analyzeArgs2)
+	recorder := provideTrendsRecorder(analyzeArgs2)
+	cmdAnalyzeExecutor := initializeAnalyzeExecutor(collectorManager, analyzer, enricherManager, postureScorer, outputer, tracker, recorder, context)
 	return cmdAnalyzeExecutor, nil
 }
 
@@ -62,6 +67,28 @@ func setupGitHubGPTExecutor(analyzeArgs2 *args) (*analyzeGPTExecutor, error) {
 	return cmdAnalyzeGPTExecutor, nil
 }
 
+func setupGitHubRemediateExecutor(analyzeArgs2 *args) (*remediateExecutor, error) {
+	client, err := provideGitHubClient(analyzeArgs2)
+	if err != nil {
+		return nil, err
+	}
+	context, err := provideContext(client, analyzeArgs2)
+	if err != nil {
+		return nil, err
+	}
+	v := provideGitHubCollectors(context, client, analyzeArgs2)
+	collectorManager := collectors_manager.NewCollectorsManager(v)
+	enginer, err := provideOpa(analyzeArgs2)
+	if err != nil {
+		return nil, err
+	}
+	skipper := skippers.NewSkipper(context)
+	analyzer := analyzers.NewAnalyzer(context, enginer, skipper)
+	remediator := provideGitHubRemediator(context, client, analyzeArgs2)
+	cmdRemediateExecutor := initializeRemediateExecutor(collectorManager, analyzer, remediator)
+	return cmdRemediateExecutor, nil
+}
+
 // Injectors from inject_gitlab.go:
 
 func setupGitLab(analyzeArgs2 *args) (*analyzeExecutor, error) {
@@ -82,8 +109,11 @@ func setupGitLab(analyzeArgs2 *args) (*analyzeExecutor, error) {
 	skipper := skippers.NewSkipper(context)
 	analyzer := analyzers.NewAnalyzer(context, enginer, skipper)
 	enricherManager := enricher.NewEnricherManager()
+	postureScorer := enricher.NewPostureScorer()
 	outputer := provideOutputer(context, analyzeArgs2)
-	cmdAnalyzeExecutor := initializeAnalyzeExecutor(collectorManager, analyzer, enricherManager, outputer, context)
+	tracker := provideGitLabIssueTracker(client, analyzeArgs2)
+	recorder := provideTrendsRecorder(analyzeArgs2)
+	cmdAnalyzeExecutor := initializeAnalyzeExecutor(collectorManager, analyzer, enricherManager, postureScorer, outputer, tracker, recorder, context)
 	return cmdAnalyzeExecutor, nil
 }
 
@@ -103,11 +133,33 @@ func setupGitLabGPTExecutor(analyzeArgs2 *args) (*analyzeGPTExecutor, error) {
 	return cmdAnalyzeGPTExecutor, nil
 }
 
+func setupGitLabRemediateExecutor(analyzeArgs2 *args) (*remediateExecutor, error) {
+	client, err := provideGitLabClient(analyzeArgs2)
+	if err != nil {
+		return nil, err
+	}
+	context, err := provideContext(client, analyzeArgs2)
+	if err != nil {
+		return nil, err
+	}
+	v := provideGitLabCollectors(context, client, analyzeArgs2)
+	collectorManager := collectors_manager.NewCollectorsManager(v)
+	enginer, err := provideOpa(analyzeArgs2)
+	if err != nil {
+		return nil, err
+	}
+	skipper := skippers.NewSkipper(context)
+	analyzer := analyzers.NewAnalyzer(context, enginer, skipper)
+	remediator := provideGitLabRemediator(client, analyzeArgs2)
+	cmdRemediateExecutor := initializeRemediateExecutor(collectorManager, analyzer, remediator)
+	return cmdRemediateExecutor, nil
+}
+
 // inject_github.go:
 
 func provideGitHubCollectors(ctx context.Context, client *github.Client, analyzeArgs2 *args) []collectors.Collector {
 	type newCollectorFunc func(ctx context.Context, client *github.Client) collectors.Collector
-	var collectorsMapping = map[namespace.Namespace]newCollectorFunc{namespace.Repository: github2.NewRepositoryCollector, namespace.Organization: github2.NewOrganizationCollector, namespace.Enterprise: github2.NewEnterpriseCollector, namespace.Member: github2.NewMemberCollector, namespace.Actions: github2.NewActionCollector, namespace.RunnerGroup: github2.NewRunnersCollector}
+	var collectorsMapping = map[namespace.Namespace]newCollectorFunc{namespace.Repository: github2.NewRepositoryCollector, namespace.Organization: github2.NewOrganizationCollector, namespace.Enterprise: github2.NewEnterpriseCollector, namespace.Member: github2.NewMemberCollector, namespace.Actions: github2.NewActionCollector, namespace.RunnerGroup: github2.NewRunnersCollector, namespace.Branch: github2.NewBranchCollector, namespace.Apps: github2.NewAppsCollector, namespace.Team: github2.NewTeamCollector, namespace.Runner: github2.NewRunnerCollector, namespace.Audit: github2.NewAuditCollector}
 
 	var result []collectors.Collector
 	for _, ns := range analyzeArgs2.Namespaces {
@@ -117,6 +169,15 @@ func provideGitHubCollectors(ctx context.Context, client *github.Client, analyze
 	return result
 }
 
+func provideGitHubIssueTracker(ctx context.Context, client *github.Client, analyzeArgs2 *args) issues.Tracker {
+	return issues.NewTracker(issues.NewGitHubClient(ctx, client), analyzeArgs2.CreateIssues, getIssuesRepository(analyzeArgs2))
+}
+
+func provideGitHubRemediator(ctx context.Context, client *github.Client, analyzeArgs2 *args) remediation.Remediator {
+	return remediation.NewRemediator(remediation.NewGitHubExecutor(ctx, client), remediation.GitHubRemediations, analyzeArgs2.
+		RemediatePolicies, analyzeArgs2.DryRun)
+}
+
 func provideGitHubClient(analyzeArgs2 *args) (*github.Client, error) {
 	ctx := context_utils.NewContextWithSimulatedSecondaryRateLimit(context.Background(), analyzeArgs2.SimulateSecondaryRateLimit)
 	return github.NewClient(ctx, analyzeArgs2.Token, analyzeArgs2.Endpoint, analyzeArgs2.
@@ -126,7 +187,7 @@ func provideGitHubClient(analyzeArgs2 *args) (*github.Client, error) {
 // inject_gitlab.go:
 
 func provideGitLabCollectors(ctx context.Context, client *gitlab.Client, analyzeArgs2 *args) []collectors.Collector {
-	var collectorsMapping = map[namespace.Namespace]func(ctx context.Context, client *gitlab.Client) collectors.Collector{namespace.Organization: gitlab2.NewGroupCollector, namespace.Repository: gitlab2.NewRepositoryCollector, namespace.Member: gitlab2.NewUserCollector, namespace.Enterprise: gitlab2.NewServerCollector}
+	var collectorsMapping = map[namespace.Namespace]func(ctx context.Context, client *gitlab.Client) collectors.Collector{namespace.Organization: gitlab2.NewGroupCollector, namespace.Repository: gitlab2.NewRepositoryCollector, namespace.Member: gitlab2.NewUserCollector, namespace.Enterprise: gitlab2.NewServerCollector, namespace.Team: gitlab2.NewTeamCollector, namespace.Audit: gitlab2.NewAuditCollector}
 
 	var result []collectors.Collector
 	for _, ns := range analyzeArgs2.Namespaces {
@@ -138,6 +199,15 @@ func provideGitLabCollectors(ctx context.Context, client *gitlab.Client, analyze
 	return result
 }
 
+func provideGitLabIssueTracker(client *gitlab.Client, analyzeArgs2 *args) issues.Tracker {
+	return issues.NewTracker(issues.NewGitLabClient(client), analyzeArgs2.CreateIssues, getIssuesRepository(analyzeArgs2))
+}
+
+func provideGitLabRemediator(client *gitlab.Client, analyzeArgs2 *args) remediation.Remediator {
+	return remediation.NewRemediator(remediation.NewGitLabExecutor(client), remediation.GitLabRemediations, analyzeArgs2.
+		RemediatePolicies, analyzeArgs2.DryRun)
+}
+
 func provideGitLabClient(analyzeArgs2 *args) (*gitlab.Client, error) {
 	return gitlab.NewClient(context.Background(), analyzeArgs2.Token, analyzeArgs2.Endpoint, analyzeArgs2.Organizations)
 }
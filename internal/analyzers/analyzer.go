@@ -21,6 +21,8 @@ const (
 	PolicyPassed  PolicyStatus = "PASSED"
 	PolicyFailed  PolicyStatus = "FAILED"
 	PolicySkipped PolicyStatus = "SKIPPED"
+	// PolicyNotApplicable is the status of a policy that does not apply to the classification of the entity (see the appliesTo annotation)
+	PolicyNotApplicable PolicyStatus = "NOT_APPLICABLE"
 )
 
 type AnalyzedData struct {
@@ -105,6 +107,10 @@ func (a *analyzer) Analyze(dataChannel <-chan collectors.CollectedData) <-chan A
 }
 
 func (a *analyzer) resolvePolicyStatus(data collectors.CollectedData, opaResult opa_engine.QueryResult) PolicyStatus {
+	if !a.skipper.IsApplicable(data, opaResult) {
+		return PolicyNotApplicable
+	}
+
 	if a.skipper.ShouldSkip(data, opaResult) {
 		return PolicySkipped
 	}
@@ -2,9 +2,15 @@ package skippers
 
 import (
 	"context"
+	"fmt"
+	"sync"
+
 	"github.com/Legit-Labs/legitify/internal/analyzers/parsing_utils"
+	"github.com/Legit-Labs/legitify/internal/collected"
+	githubcollected "github.com/Legit-Labs/legitify/internal/collected/github"
 	"github.com/Legit-Labs/legitify/internal/collectors"
 	"github.com/Legit-Labs/legitify/internal/common/permissions"
+	"github.com/Legit-Labs/legitify/internal/common/repo_filter"
 	"github.com/Legit-Labs/legitify/internal/context_utils"
 	"github.com/Legit-Labs/legitify/internal/errlog"
 	"github.com/Legit-Labs/legitify/internal/opa/opa_engine"
@@ -13,6 +19,14 @@ import (
 
 type Skipper interface {
 	ShouldSkip(data collectors.CollectedData, violation opa_engine.QueryResult) bool
+	IsApplicable(data collectors.CollectedData, violation opa_engine.QueryResult) bool
+}
+
+// appliesToFields maps the keys of the appliesTo annotation to the repository filter fields
+var appliesToFields = map[string]string{
+	"names":      repo_filter.FieldName,
+	"topics":     repo_filter.FieldTopic,
+	"visibility": repo_filter.FieldVisibility,
 }
 
 type IsPrerequisitesSatisfied func(data collectors.CollectedData) bool
@@ -39,6 +53,22 @@ func NewSkipper(ctx context.Context) Skipper {
 			"enterprise": func(_ collectors.CollectedData) bool {
 				return !context_utils.GetIsCloud(ctx)
 			},
+			"not_archived": func(data collectors.CollectedData) bool {
+				archivable, ok := data.Entity.(collected.ArchivableEntity)
+				return !ok || !archivable.IsArchived()
+			},
+			"oauth_app_restrictions_known": func(data collectors.CollectedData) bool {
+				apps, ok := data.Entity.(githubcollected.OrganizationApps)
+				return ok && apps.OAuthAppRestrictions != nil
+			},
+			"two_factor_status_known": func(data collectors.CollectedData) bool {
+				members, ok := data.Entity.(githubcollected.OrganizationMembers)
+				return ok && members.HasTwoFactorStatus
+			},
+			"external_identities_known": func(data collectors.CollectedData) bool {
+				members, ok := data.Entity.(githubcollected.OrganizationMembers)
+				return ok && members.HasSamlSso != nil
+			},
 			"advanced_security": func(data collectors.CollectedData) bool {
 				repositoryContext, ok := data.Context.(collectors.CollectedDataRepositoryContext)
 				if !ok {
@@ -55,6 +85,7 @@ type skipper struct {
 	ctx                   context.Context
 	prerequisitesCheckers map[string]IsPrerequisitesSatisfied
 	ignoredPolicies       []string
+	applicability         sync.Map
 }
 
 func (sm *skipper) ShouldSkip(data collectors.CollectedData, violation opa_engine.QueryResult) bool {
@@ -82,6 +113,62 @@ func (sm *skipper) ShouldSkip(data collectors.CollectedData, violation opa_engin
 	return false
 }
 
+// IsApplicable checks the classification of the entity against the appliesTo annotation of the policy
+// (e.g. appliesTo: {visibility: [public], topics: [prod]}).
+// Policies without the annotation, and entities that cannot be classified, are always applicable.
+func (sm *skipper) IsApplicable(data collectors.CollectedData, violation opa_engine.QueryResult) bool {
+	appliesTo, ok := violation.Annotations.Custom["appliesTo"]
+	if !ok {
+		return true
+	}
+
+	classifiable, ok := data.Entity.(collected.ClassifiableEntity)
+	if !ok {
+		return true
+	}
+
+	filter := sm.applicabilityFilter(violation.FullyQualifiedPolicyName, appliesTo)
+
+	return filter.Matches(classifiable.Classification())
+}
+
+// applicabilityFilter parses the appliesTo annotation once per policy.
+// An invalid annotation is reported and treated as if the policy applies to all the entities.
+func (sm *skipper) applicabilityFilter(policyName string, appliesTo interface{}) *repo_filter.Filter {
+	if filter, ok := sm.applicability.Load(policyName); ok {
+		return filter.(*repo_filter.Filter)
+	}
+
+	filter, err := parseAppliesTo(appliesTo)
+	if err != nil {
+		log.Printf("invalid appliesTo annotation for policy %s: %v", policyName, err)
+		filter = &repo_filter.Filter{}
+	}
+	sm.applicability.Store(policyName, filter)
+
+	return filter
+}
+
+func parseAppliesTo(appliesTo interface{}) (*repo_filter.Filter, error) {
+	fields, ok := appliesTo.(map[string]interface{})
+	if !ok {
+		return nil, fmt.Errorf("expected a map, got %T", appliesTo)
+	}
+
+	var conditions []string
+	for key, values := range fields {
+		field, ok := appliesToFields[key]
+		if !ok {
+			return nil, fmt.Errorf("unknown key %s", key)
+		}
+		for _, value := range parsing_utils.ResolveAnnotation(values) {
+			conditions = append(conditions, field+":"+value)
+		}
+	}
+
+	return repo_filter.Parse(conditions)
+}
+
 func (sm *skipper) ignoredPolicy(policy opa_engine.QueryResult) bool {
 	for _, ignored := range sm.ignoredPolicies {
 		if policy.PolicyName == ignored {
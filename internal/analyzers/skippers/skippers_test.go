@@ -0,0 +1,151 @@
+package skippers_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/Legit-Labs/legitify/internal/analyzers/skippers"
+	"github.com/Legit-Labs/legitify/internal/collected"
+	githubcollected "github.com/Legit-Labs/legitify/internal/collected/github"
+	"github.com/Legit-Labs/legitify/internal/collected/gitlab_collected"
+	"github.com/Legit-Labs/legitify/internal/collectors"
+	"github.com/Legit-Labs/legitify/internal/common/permissions"
+	"github.com/Legit-Labs/legitify/internal/context_utils"
+	"github.com/Legit-Labs/legitify/internal/opa/opa_engine"
+	"github.com/google/go-github/v53/github"
+	"github.com/open-policy-agent/opa/ast"
+	"github.com/stretchr/testify/require"
+	"github.com/xanzy/go-gitlab"
+)
+
+type testContext struct{}
+
+func (testContext) Premium() bool {
+	return true
+}
+
+func (testContext) Roles() []permissions.Role {
+	return nil
+}
+
+func TestShouldSkipArchived(t *testing.T) {
+	ctx := context_utils.NewContextWithTokenScopes(context.Background(), permissions.TokenScopes{})
+	skipper := skippers.NewSkipper(ctx)
+	violation := opa_engine.QueryResult{
+		PolicyName:  "policy",
+		Annotations: &ast.Annotations{Custom: map[string]interface{}{"prerequisites": []interface{}{"not_archived"}}},
+	}
+	skip := func(entity collected.Entity) bool {
+		return skipper.ShouldSkip(collectors.CollectedData{Context: testContext{}, Entity: entity}, violation)
+	}
+
+	require.True(t, skip(githubcollected.Repository{Repository: &githubcollected.GitHubQLRepository{IsArchived: true}}))
+	require.False(t, skip(githubcollected.Repository{Repository: &githubcollected.GitHubQLRepository{}}))
+	require.True(t, skip(gitlab_collected.Repository{Project: &gitlab.Project{Archived: true}}))
+	require.False(t, skip(gitlab_collected.Repository{Project: &gitlab.Project{}}))
+	branch := &githubcollected.GitHubQLBranch{Name: github.String("release/1.0")}
+	require.True(t, skip(githubcollected.Branch{Repository: &githubcollected.GitHubQLRepository{IsArchived: true}, Branch: branch}))
+	require.False(t, skip(githubcollected.Branch{Repository: &githubcollected.GitHubQLRepository{}, Branch: branch}))
+}
+
+func TestShouldSkipUnknownOAuthAppRestrictions(t *testing.T) {
+	ctx := context_utils.NewContextWithTokenScopes(context.Background(), permissions.TokenScopes{})
+	skipper := skippers.NewSkipper(ctx)
+	violation := opa_engine.QueryResult{
+		PolicyName:  "oauth_app_restrictions_disabled",
+		Annotations: &ast.Annotations{Custom: map[string]interface{}{"prerequisites": []interface{}{"oauth_app_restrictions_known"}}},
+	}
+	skip := func(restrictions *bool) bool {
+		apps := githubcollected.OrganizationApps{
+			Organization:         githubcollected.ExtendedOrg{Organization: github.Organization{Login: github.String("org")}},
+			OAuthAppRestrictions: restrictions,
+		}
+		return skipper.ShouldSkip(collectors.CollectedData{Context: testContext{}, Entity: apps}, violation)
+	}
+
+	require.True(t, skip(nil))
+	require.False(t, skip(github.Bool(false)))
+	require.False(t, skip(github.Bool(true)))
+}
+
+func TestShouldSkipUnknownTwoFactorStatus(t *testing.T) {
+	ctx := context_utils.NewContextWithTokenScopes(context.Background(), permissions.TokenScopes{})
+	skipper := skippers.NewSkipper(ctx)
+	violation := opa_engine.QueryResult{
+		PolicyName:  "member_without_two_factor_authentication",
+		Annotations: &ast.Annotations{Custom: map[string]interface{}{"prerequisites": []interface{}{"two_factor_status_known"}}},
+	}
+	skip := func(hasTwoFactorStatus bool) bool {
+		members := githubcollected.OrganizationMembers{
+			Organization:       githubcollected.ExtendedOrg{Organization: github.Organization{Login: github.String("org")}},
+			HasTwoFactorStatus: hasTwoFactorStatus,
+		}
+		return skipper.ShouldSkip(collectors.CollectedData{Context: testContext{}, Entity: members}, violation)
+	}
+
+	require.True(t, skip(false))
+	require.False(t, skip(true))
+}
+
+func TestShouldSkipUnknownExternalIdentities(t *testing.T) {
+	ctx := context_utils.NewContextWithTokenScopes(context.Background(), permissions.TokenScopes{})
+	skipper := skippers.NewSkipper(ctx)
+	violation := opa_engine.QueryResult{
+		PolicyName:  "member_without_linked_sso_identity",
+		Annotations: &ast.Annotations{Custom: map[string]interface{}{"prerequisites": []interface{}{"external_identities_known"}}},
+	}
+	skip := func(hasSamlSso *bool) bool {
+		members := githubcollected.OrganizationMembers{
+			Organization: githubcollected.ExtendedOrg{Organization: github.Organization{Login: github.String("org")}},
+			HasSamlSso:   hasSamlSso,
+		}
+		return skipper.ShouldSkip(collectors.CollectedData{Context: testContext{}, Entity: members}, violation)
+	}
+
+	require.True(t, skip(nil))
+	require.False(t, skip(github.Bool(false)))
+	require.False(t, skip(github.Bool(true)))
+}
+
+func TestIsApplicable(t *testing.T) {
+	ctx := context_utils.NewContextWithTokenScopes(context.Background(), permissions.TokenScopes{})
+	skipper := skippers.NewSkipper(ctx)
+	policy := func(name string, appliesTo interface{}) opa_engine.QueryResult {
+		custom := map[string]interface{}{}
+		if appliesTo != nil {
+			custom["appliesTo"] = appliesTo
+		}
+		return opa_engine.QueryResult{
+			PolicyName:               name,
+			FullyQualifiedPolicyName: "data.repository." + name,
+			Annotations:              &ast.Annotations{Custom: custom},
+		}
+	}
+	applicable := func(entity collected.Entity, violation opa_engine.QueryResult) bool {
+		return skipper.IsApplicable(collectors.CollectedData{Context: testContext{}, Entity: entity}, violation)
+	}
+
+	production := githubcollected.Repository{Repository: &githubcollected.GitHubQLRepository{
+		Name:       "payments",
+		Visibility: "PRIVATE",
+		RepositoryTopics: githubcollected.GitHubQLTopics{
+			Nodes: []githubcollected.GitHubQLTopicNode{{Topic: githubcollected.GitHubQLTopic{Name: "prod"}}},
+		},
+	}}
+	prototype := gitlab_collected.Repository{Project: &gitlab.Project{Path: "prototype", Visibility: gitlab.PublicVisibility}}
+
+	prodOnly := policy("prod_only", map[string]interface{}{"topics": []interface{}{"prod"}})
+	require.True(t, applicable(production, prodOnly))
+	require.False(t, applicable(prototype, prodOnly))
+
+	publicOnly := policy("public_only", map[string]interface{}{"visibility": []interface{}{"public", "internal"}})
+	require.False(t, applicable(production, publicOnly))
+	require.True(t, applicable(prototype, publicOnly))
+
+	everywhere := policy("everywhere", nil)
+	require.True(t, applicable(production, everywhere))
+	require.True(t, applicable(prototype, everywhere))
+
+	invalid := policy("invalid", map[string]interface{}{"owners": []interface{}{"legit"}})
+	require.True(t, applicable(prototype, invalid))
+}
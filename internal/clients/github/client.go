@@ -6,6 +6,7 @@ import (
 	"fmt"
 	"log"
 	"net/http"
+	neturl "net/url"
 	"regexp"
 	"strings"
 	"sync"
@@ -137,6 +138,10 @@ func (c *Client) Orgs() []string {
 	return c.orgs
 }
 
+func (c *Client) Enterprises() []string {
+	return c.enterprises
+}
+
 func (c *Client) inRealOrgs(org string, realOrgs []string) bool {
 	for _, realOrg := range realOrgs {
 		if strings.EqualFold(org, realOrg) {
@@ -588,6 +593,15 @@ func (c *Client) collectSpecificEnterprises() ([]githubcollected.Enterprise, err
 			enterpriseQuery.Enterprise.OwnerInfo.NotificationDeliveryRestrictionEnabledSetting,
 			samlEnabled,
 			codeAndSecurityPolicySettings)
+		newEnter.Slug = enterprise
+		newEnter.Organizations, err = c.getEnterpriseOrganizations(enterprise)
+		if err != nil {
+			log.Printf("failed to get organizations for enterprise %v: %v", enterprise, err)
+		}
+		newEnter.Rulesets, err = c.GetEnterpriseRulesets(enterprise)
+		if err != nil {
+			log.Printf("failed to get rulesets for enterprise %v: %v", enterprise, err)
+		}
 		res = append(res, newEnter)
 
 	}
@@ -595,8 +609,43 @@ func (c *Client) collectSpecificEnterprises() ([]githubcollected.Enterprise, err
 	return res, nil
 }
 
+// getEnterpriseOrganizations returns the logins of the organizations that belong to the enterprise
+func (c *Client) getEnterpriseOrganizations(enterprise string) ([]string, error) {
+	var query struct {
+		Enterprise struct {
+			Organizations struct {
+				PageInfo githubcollected.GitHubQLPageInfo
+				Nodes    []struct {
+					Login string
+				}
+			} `graphql:"organizations(first: 100, after: $cursor)"`
+		} `graphql:"enterprise(slug: $slug)"`
+	}
+	variables := map[string]interface{}{
+		"slug":   githubv4.String(enterprise),
+		"cursor": (*githubv4.String)(nil),
+	}
+
+	var organizations []string
+	for {
+		if err := c.GraphQLClient().Query(c.context, &query, variables); err != nil {
+			return nil, err
+		}
+		for _, node := range query.Enterprise.Organizations.Nodes {
+			organizations = append(organizations, node.Login)
+		}
+
+		if !query.Enterprise.Organizations.PageInfo.HasNextPage {
+			break
+		}
+		variables["cursor"] = query.Enterprise.Organizations.PageInfo.EndCursor
+	}
+
+	return organizations, nil
+}
+
 func (c *Client) GetRulesForBranch(organization, repository, branch string) ([]*types.RepositoryRule, error) {
-	url := fmt.Sprintf("repos/%v/%v/rules/branches/%v", organization, repository, branch)
+	url := fmt.Sprintf("repos/%v/%v/rules/branches/%v", organization, repository, neturl.PathEscape(branch))
 	req, err := c.client.NewRequest("GET", url, nil)
 	if err != nil {
 		return nil, err
@@ -621,6 +670,144 @@ func (c *Client) GetRulesForBranch(organization, repository, branch string) ([]*
 
 }
 
+// GetOrganizationRulesets returns the rulesets of the organization, including their conditions and rules
+func (c *Client) GetOrganizationRulesets(organization string) ([]*types.Ruleset, error) {
+	return c.getRulesets(fmt.Sprintf("orgs/%v/rulesets", organization))
+}
+
+// GetEnterpriseRulesets returns the rulesets of the enterprise, including their conditions and rules
+func (c *Client) GetEnterpriseRulesets(enterprise string) ([]*types.Ruleset, error) {
+	return c.getRulesets(fmt.Sprintf("enterprises/%v/rulesets", enterprise))
+}
+
+func (c *Client) getRulesets(url string) ([]*types.Ruleset, error) {
+	var rulesets []*types.Ruleset
+	opts := &gh.ListOptions{PerPage: 100}
+	for {
+		req, err := c.client.NewRequest("GET", fmt.Sprintf("%s?per_page=%d&page=%d", url, opts.PerPage, opts.Page), nil)
+		if err != nil {
+			return nil, err
+		}
+
+		var page []*types.Ruleset
+		resp, err := c.client.Do(c.context, req, &page)
+		if err != nil {
+			return nil, err
+		}
+		rulesets = append(rulesets, page...)
+
+		if resp.NextPage == 0 {
+			break
+		}
+		opts.Page = resp.NextPage
+	}
+
+	// the list only includes the summary of the rulesets
+	for i, ruleset := range rulesets {
+		req, err := c.client.NewRequest("GET", fmt.Sprintf("%s/%d", url, ruleset.ID), nil)
+		if err != nil {
+			return nil, err
+		}
+
+		var specific types.Ruleset
+		if _, err = c.client.Do(c.context, req, &specific); err != nil {
+			return nil, err
+		}
+		rulesets[i] = &specific
+	}
+
+	return rulesets, nil
+}
+
+// GetOrganizationRunners returns the self-hosted runners registered to the organization
+func (c *Client) GetOrganizationRunners(organization string) ([]*types.Runner, error) {
+	return c.getRunners(fmt.Sprintf("orgs/%v/actions/runners", organization))
+}
+
+// GetRepositoryRunners returns the self-hosted runners registered to the repository
+func (c *Client) GetRepositoryRunners(owner, repository string) ([]*types.Runner, error) {
+	return c.getRunners(fmt.Sprintf("repos/%v/%v/actions/runners", owner, repository))
+}
+
+func (c *Client) getRunners(url string) ([]*types.Runner, error) {
+	var runners []*types.Runner
+	opts := &gh.ListOptions{PerPage: 100}
+	for {
+		req, err := c.client.NewRequest("GET", fmt.Sprintf("%s?per_page=%d&page=%d", url, opts.PerPage, opts.Page), nil)
+		if err != nil {
+			return nil, err
+		}
+
+		var page types.Runners
+		resp, err := c.client.Do(c.context, req, &page)
+		if err != nil {
+			return nil, err
+		}
+		runners = append(runners, page.Runners...)
+
+		if resp.NextPage == 0 {
+			break
+		}
+		opts.Page = resp.NextPage
+	}
+
+	return runners, nil
+}
+
+// GetDeploymentBranchPolicies returns the custom deployment branch policies of the environment
+func (c *Client) GetDeploymentBranchPolicies(owner, repository, environment string) ([]*gh.DeploymentBranchPolicy, error) {
+	url := fmt.Sprintf("repos/%v/%v/environments/%v/deployment-branch-policies", owner, repository, neturl.PathEscape(environment))
+	var policies []*gh.DeploymentBranchPolicy
+	opts := &gh.ListOptions{PerPage: 100}
+	for {
+		req, err := c.client.NewRequest("GET", fmt.Sprintf("%s?per_page=%d&page=%d", url, opts.PerPage, opts.Page), nil)
+		if err != nil {
+			return nil, err
+		}
+
+		var page gh.DeploymentBranchPolicyResponse
+		resp, err := c.client.Do(c.context, req, &page)
+		if err != nil {
+			return nil, err
+		}
+		policies = append(policies, page.BranchPolicies...)
+
+		if resp.NextPage == 0 {
+			break
+		}
+		opts.Page = resp.NextPage
+	}
+
+	return policies, nil
+}
+
+// GetOrganizationPropertyValues returns the values of the custom properties of the repositories of the organization
+func (c *Client) GetOrganizationPropertyValues(org string) ([]*types.RepositoryPropertyValues, error) {
+	url := fmt.Sprintf("orgs/%v/properties/values", org)
+	var values []*types.RepositoryPropertyValues
+	opts := &gh.ListOptions{PerPage: 100}
+	for {
+		req, err := c.client.NewRequest("GET", fmt.Sprintf("%s?per_page=%d&page=%d", url, opts.PerPage, opts.Page), nil)
+		if err != nil {
+			return nil, err
+		}
+
+		var page []*types.RepositoryPropertyValues
+		resp, err := c.client.Do(c.context, req, &page)
+		if err != nil {
+			return nil, err
+		}
+		values = append(values, page...)
+
+		if resp.NextPage == 0 {
+			break
+		}
+		opts.Page = resp.NextPage
+	}
+
+	return values, nil
+}
+
 func (c *Client) GetSecurityAndAnalysisForEnterprise(enterprise string) (*types.AnalysisAndSecurityPolicies, error) {
 	url := fmt.Sprintf("/api/v3/enterprises/%v/code_security_and_analysis", enterprise)
 	req, err := c.client.NewRequest("GET", url, nil)
@@ -670,3 +857,71 @@ func (c *Client) GetSecurityAndAnalysisForRepository(repo, owner string) (*gh.Se
 
 	return r.SecurityAndAnalysis, nil
 }
+
+// GetOAuthAppRestrictions tells whether the OAuth app access restrictions of the organization are enabled,
+// according to the last time they were enabled or disabled in the audit log (which requires GitHub Enterprise Cloud).
+// The state is unknown (nil) when the audit log has no such event.
+func (c *Client) GetOAuthAppRestrictions(org string) (*bool, error) {
+	lastEvent := func(action string) (*gh.AuditEntry, error) {
+		opts := &gh.GetAuditLogOptions{
+			Phrase:            gh.String("action:" + action),
+			ListCursorOptions: gh.ListCursorOptions{PerPage: 1},
+		}
+		entries, _, err := c.client.Organizations.GetAuditLog(c.context, org, opts)
+		if err != nil || len(entries) == 0 {
+			return nil, err
+		}
+		return entries[0], nil
+	}
+
+	enabled, err := lastEvent("org.enable_oauth_app_restrictions")
+	if err != nil {
+		return nil, err
+	}
+	disabled, err := lastEvent("org.disable_oauth_app_restrictions")
+	if err != nil {
+		return nil, err
+	}
+
+	switch {
+	case enabled == nil && disabled == nil:
+		return nil, nil
+	case disabled == nil:
+		return gh.Bool(true), nil
+	case enabled == nil:
+		return gh.Bool(false), nil
+	default:
+		return gh.Bool(enabled.GetTimestamp().After(disabled.GetTimestamp().Time)), nil
+	}
+}
+
+// GetAuditLog returns the audit log events of the organization, or of the enterprise, that match the search phrase
+func (c *Client) GetAuditLog(owner string, isEnterprise bool, phrase string) ([]*gh.AuditEntry, error) {
+	opts := &gh.GetAuditLogOptions{
+		Phrase:            gh.String(phrase),
+		ListCursorOptions: gh.ListCursorOptions{PerPage: 100},
+	}
+
+	var entries []*gh.AuditEntry
+	for {
+		var page []*gh.AuditEntry
+		var resp *gh.Response
+		var err error
+		if isEnterprise {
+			page, resp, err = c.client.Enterprise.GetAuditLog(c.context, owner, opts)
+		} else {
+			page, resp, err = c.client.Organizations.GetAuditLog(c.context, owner, opts)
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		entries = append(entries, page...)
+		if resp.After == "" {
+			break
+		}
+		opts.After = resp.After
+	}
+
+	return entries, nil
+}
@@ -15,6 +15,90 @@ type RepositoryRule struct {
 	Parameters *json.RawMessage `json:"parameters,omitempty"`
 	Id         int64            `json:"ruleset_id"`
 	Ruleset    *github.Ruleset  `json:"ruleset"`
+	// Possible values for SourceType are: Repository, Organization, Enterprise
+	SourceType string `json:"ruleset_source_type,omitempty"`
+	Source     string `json:"ruleset_source,omitempty"`
+}
+
+// Ruleset is an organization or enterprise ruleset.
+// Unlike github.Ruleset, its conditions include the repository properties and organization names,
+// its bypass actors include the repository roles and organization admins,
+// and rules of types that go-github doesn't know are kept rather than failing the whole ruleset.
+type Ruleset struct {
+	github.Ruleset
+	Conditions   *RulesetConditions    `json:"conditions,omitempty"`
+	Rules        []*RulesetRule        `json:"rules,omitempty"`
+	BypassActors []*RulesetBypassActor `json:"bypass_actors,omitempty"`
+}
+
+type RulesetBypassActor struct {
+	ActorID int64 `json:"actor_id"`
+	// Possible values for ActorType are: RepositoryRole, Team, Integration, OrganizationAdmin, DeployKey
+	ActorType string `json:"actor_type"`
+	// Possible values for BypassMode are: always, pull_request
+	BypassMode string `json:"bypass_mode,omitempty"`
+}
+
+type RulesetRule struct {
+	Type       string           `json:"type"`
+	Parameters *json.RawMessage `json:"parameters,omitempty"`
+}
+
+type RulesetConditions struct {
+	RefName            *github.RulesetRefConditionParameters        `json:"ref_name,omitempty"`
+	RepositoryName     *github.RulesetRepositoryConditionParameters `json:"repository_name,omitempty"`
+	RepositoryProperty *RulesetPropertyConditionParameters          `json:"repository_property,omitempty"`
+	RepositoryID       *RulesetRepositoryIDConditionParameters      `json:"repository_id,omitempty"`
+	OrganizationName   *github.RulesetRefConditionParameters        `json:"organization_name,omitempty"`
+}
+
+type RulesetRepositoryIDConditionParameters struct {
+	RepositoryIDs []int64 `json:"repository_ids"`
+}
+
+type RulesetPropertyConditionParameters struct {
+	Include []RulesetPropertyTarget `json:"include"`
+	Exclude []RulesetPropertyTarget `json:"exclude"`
+}
+
+type RulesetPropertyTarget struct {
+	Name           string   `json:"name"`
+	PropertyValues []string `json:"property_values"`
+}
+
+// RepositoryPropertyValues are the values of the custom properties of a repository, as listed for its organization
+type RepositoryPropertyValues struct {
+	RepositoryName string                     `json:"repository_name"`
+	Properties     []*RepositoryPropertyValue `json:"properties"`
+}
+
+// RepositoryPropertyValue is the value of a custom property of a repository.
+// Multi-select properties have multiple values.
+type RepositoryPropertyValue struct {
+	PropertyName string
+	Values       []string
+}
+
+func (v *RepositoryPropertyValue) UnmarshalJSON(data []byte) error {
+	var raw struct {
+		PropertyName string          `json:"property_name"`
+		Value        json.RawMessage `json:"value"`
+	}
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return err
+	}
+
+	v.PropertyName = raw.PropertyName
+	v.Values = nil
+	var single *string
+	if err := json.Unmarshal(raw.Value, &single); err == nil {
+		if single != nil {
+			v.Values = []string{*single}
+		}
+		return nil
+	}
+
+	return json.Unmarshal(raw.Value, &v.Values)
 }
 
 type AnalysisAndSecurityPolicies struct {
@@ -24,3 +108,14 @@ type AnalysisAndSecurityPolicies struct {
 	SecretScanningPushProtectionEnabledForNewRepos bool   `json:"secret_scanning_push_protection_enabled_for_new_repositories"`
 	SecretScanningPushProtectionCustomLink         string `json:"secret_scanning_push_protection_custom_link"`
 }
+
+// Runner is a self-hosted runner, including the fields that github.Runner doesn't have
+type Runner struct {
+	github.Runner
+	Ephemeral *bool `json:"ephemeral,omitempty"`
+}
+
+type Runners struct {
+	TotalCount int       `json:"total_count"`
+	Runners    []*Runner `json:"runners"`
+}
@@ -2,8 +2,11 @@ package gitlab
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"log"
+	"net/http"
+	"net/url"
 	"strings"
 
 	"github.com/Legit-Labs/legitify/internal/clients/gitlab/pagination"
@@ -16,6 +19,8 @@ import (
 
 const (
 	allGroupsFilter = ""
+	// graphQLPath is relative to the REST API base URL (e.g. /api/v4/)
+	graphQLPath = "../graphql"
 )
 
 type Client struct {
@@ -144,6 +149,31 @@ func IsAdmin(client *gitlab.Client) bool {
 	return res.IsAdmin
 }
 
+// GraphQL runs query against the GraphQL API and decodes its data into result.
+// It is used for data that isn't available through the REST API.
+func (c *Client) GraphQL(query string, result interface{}) error {
+	req, err := c.Client().NewRequest(http.MethodPost, "", map[string]string{"query": query}, nil)
+	if err != nil {
+		return err
+	}
+	req.URL = c.Client().BaseURL().ResolveReference(&url.URL{Path: graphQLPath})
+
+	var response struct {
+		Data   json.RawMessage `json:"data"`
+		Errors []struct {
+			Message string `json:"message"`
+		} `json:"errors"`
+	}
+	if _, err = c.Client().Do(req, &response); err != nil {
+		return err
+	}
+	if len(response.Errors) > 0 {
+		return fmt.Errorf("graphql query failed: %s", response.Errors[0].Message)
+	}
+
+	return json.Unmarshal(response.Data, result)
+}
+
 func (c *Client) Group(name string) (*gitlab.Group, error) {
 	ownedGroups := !c.IsAdmin() // list all groups as site admin
 	opts := &gitlab.ListGroupsOptions{
@@ -1,8 +1,20 @@
 package collected
 
+import "github.com/Legit-Labs/legitify/internal/common/repo_filter"
+
 type Entity interface {
 	ViolationEntityType() string
 	CanonicalLink() string
 	Name() string
 	ID() int64
 }
+
+// ArchivableEntity is an entity that can be archived, after which most of its settings no longer have an effect
+type ArchivableEntity interface {
+	IsArchived() bool
+}
+
+// ClassifiableEntity is an entity of a repository, that can be classified by the repository metadata (e.g. visibility and topics)
+type ClassifiableEntity interface {
+	Classification() repo_filter.Repository
+}
@@ -0,0 +1,44 @@
+package githubcollected
+
+import (
+	"fmt"
+
+	"github.com/Legit-Labs/legitify/internal/common/namespace"
+	"github.com/google/go-github/v53/github"
+)
+
+// AuditLog is the audit log of an organization or of an enterprise, filtered to the risky events since the start of the analyzed time window
+type AuditLog struct {
+	// Organization is set for the audit log of an organization, and nil for the audit log of an enterprise
+	Organization *ExtendedOrg `json:"organization,omitempty"`
+	// Enterprise is set for the audit log of an enterprise, and nil for the audit log of an organization
+	Enterprise *Enterprise `json:"enterprise,omitempty"`
+	// Since is the start of the analyzed time window, in RFC3339
+	Since  string               `json:"since"`
+	Events []*github.AuditEntry `json:"events"`
+}
+
+func (a AuditLog) ViolationEntityType() string {
+	return namespace.Audit
+}
+
+func (a AuditLog) CanonicalLink() string {
+	if a.Enterprise != nil {
+		return a.Enterprise.Url + "/settings/audit-log"
+	}
+	return fmt.Sprintf("https://github.com/organizations/%s/settings/audit-log", a.Organization.Name())
+}
+
+func (a AuditLog) Name() string {
+	if a.Enterprise != nil {
+		return a.Enterprise.Slug
+	}
+	return a.Organization.Name()
+}
+
+func (a AuditLog) ID() int64 {
+	if a.Enterprise != nil {
+		return a.Enterprise.ID()
+	}
+	return a.Organization.GetID()
+}
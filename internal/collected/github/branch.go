@@ -0,0 +1,76 @@
+package githubcollected
+
+import (
+	"fmt"
+	"hash/fnv"
+	"math"
+	"net/url"
+	"path"
+	"strings"
+
+	"github.com/Legit-Labs/legitify/internal/clients/github/types"
+	"github.com/Legit-Labs/legitify/internal/common/namespace"
+	"github.com/Legit-Labs/legitify/internal/common/repo_filter"
+)
+
+// Branch is a non-default branch that is protected or matches the configured branch patterns.
+// The default branch is analyzed as part of its repository.
+type Branch struct {
+	Owner                        string                  `json:"owner"`
+	Repository                   *GitHubQLRepository     `json:"repository"`
+	Branch                       *GitHubQLBranch         `json:"branch"`
+	MatchedPatterns              []string                `json:"matched_patterns"`
+	NoBranchProtectionPermission bool                    `json:"no_branch_protection_permission"`
+	RulesSet                     []*types.RepositoryRule `json:"rules_set,omitempty"`
+}
+
+func (b Branch) Classification() repo_filter.Repository {
+	if b.Repository == nil {
+		return repo_filter.Repository{}
+	}
+
+	return b.Repository.Classification()
+}
+
+func (b Branch) IsArchived() bool {
+	return b.Repository != nil && b.Repository.IsArchived
+}
+
+func (b Branch) ViolationEntityType() string {
+	return namespace.Branch
+}
+
+func (b Branch) CanonicalLink() string {
+	// branch names may contain characters that must be escaped (e.g. '#'), but their '/' are path separators
+	segments := strings.Split(*b.Branch.Name, "/")
+	for i, segment := range segments {
+		segments[i] = url.PathEscape(segment)
+	}
+
+	return fmt.Sprintf("%s/tree/%s", b.Repository.Url, strings.Join(segments, "/"))
+}
+
+func (b Branch) Name() string {
+	return fmt.Sprintf("%s:%s", b.Repository.Name, *b.Branch.Name)
+}
+
+// ID is derived from the repository ID and the branch name, since GitHub doesn't assign numeric IDs to branches
+func (b Branch) ID() int64 {
+	h := fnv.New64a()
+	_, _ = fmt.Fprintf(h, "%d:%s", b.Repository.DatabaseId, *b.Branch.Name)
+
+	return int64(h.Sum64() & math.MaxInt64)
+}
+
+// MatchBranchPatterns returns the patterns (e.g. release/*) that match the branch name
+func MatchBranchPatterns(branch string, patterns []string) []string {
+	matched := []string{}
+	for _, pattern := range patterns {
+		// path.Match treats '/' as a separator, so '*' doesn't cross it just like in GitHub's fnmatch patterns
+		if ok, err := path.Match(pattern, branch); err == nil && ok {
+			matched = append(matched, pattern)
+		}
+	}
+
+	return matched
+}
@@ -0,0 +1,38 @@
+package githubcollected_test
+
+import (
+	"testing"
+
+	githubcollected "github.com/Legit-Labs/legitify/internal/collected/github"
+	"github.com/google/go-github/v53/github"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMatchBranchPatterns(t *testing.T) {
+	patterns := []string{"release/*", "main", "prod*"}
+
+	require.Equal(t, []string{"release/*"}, githubcollected.MatchBranchPatterns("release/1.0", patterns))
+	require.Equal(t, []string{"main"}, githubcollected.MatchBranchPatterns("main", patterns))
+	require.Equal(t, []string{"prod*"}, githubcollected.MatchBranchPatterns("production", patterns))
+	require.Empty(t, githubcollected.MatchBranchPatterns("release/1.0/hotfix", patterns), "'*' should not match '/'")
+	require.Empty(t, githubcollected.MatchBranchPatterns("feature/release", patterns))
+}
+
+func TestBranchIdentity(t *testing.T) {
+	newBranch := func(name string) githubcollected.Branch {
+		return githubcollected.Branch{
+			Repository: &githubcollected.GitHubQLRepository{DatabaseId: 42, Url: "https://github.com/org/repo"},
+			Branch:     &githubcollected.GitHubQLBranch{Name: github.String(name)},
+		}
+	}
+	release := newBranch("release/1.0")
+	hotfix := newBranch("release/1.0#hotfix")
+
+	require.Equal(t, "https://github.com/org/repo/tree/release/1.0", release.CanonicalLink())
+	require.Equal(t, "https://github.com/org/repo/tree/release/1.0%23hotfix", hotfix.CanonicalLink())
+
+	require.Equal(t, release.ID(), newBranch("release/1.0").ID())
+	require.NotEqual(t, release.ID(), hotfix.ID())
+	require.NotEqual(t, int64(42), release.ID())
+	require.Positive(t, release.ID())
+}
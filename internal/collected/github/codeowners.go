@@ -0,0 +1,232 @@
+package githubcollected
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// CodeownersPaths are the locations of the CODEOWNERS file, in the order GitHub looks for it
+var CodeownersPaths = []string{".github/CODEOWNERS", "CODEOWNERS", "docs/CODEOWNERS"}
+
+// CodeownersSensitivePaths are paths that control the CI/CD of the repository,
+// so every change to them should be reviewed by an owner
+var CodeownersSensitivePaths = []string{WorkflowsPath + "/", ".github/actions/"}
+
+// Possible values for CodeownersOwner.Status
+const (
+	CodeownerValid         = "valid"
+	CodeownerNotFound      = "not_found"
+	CodeownerNoWriteAccess = "no_write_access"
+	CodeownerUnresolved    = "unresolved"
+)
+
+// Possible values for CodeownersOwner.Type
+const (
+	CodeownerTypeUser  = "User"
+	CodeownerTypeTeam  = "Team"
+	CodeownerTypeEmail = "Email"
+)
+
+// Codeowners is the structured model of the CODEOWNERS file of a repository
+type Codeowners struct {
+	// Path is empty if the repository has no CODEOWNERS file
+	Path   string             `json:"path"`
+	Rules  []*CodeownersRule  `json:"rules"`
+	Errors []*CodeownersError `json:"errors"`
+	// Owners are the distinct owners that appear in the rules
+	Owners []*CodeownersOwner `json:"owners"`
+	// UncoveredPaths are the sensitive paths (and the CODEOWNERS file itself) that have no owner
+	UncoveredPaths []string `json:"uncovered_paths"`
+}
+
+type CodeownersRule struct {
+	Line    int      `json:"line"`
+	Pattern string   `json:"pattern"`
+	Owners  []string `json:"owners"`
+}
+
+type CodeownersError struct {
+	Line int `json:"line"`
+	// Kind is the kind of the error as reported by GitHub, e.g. "Invalid pattern" or "Unknown owner"
+	Kind    string `json:"kind"`
+	Source  string `json:"source"`
+	Message string `json:"message"`
+}
+
+type CodeownersOwner struct {
+	Name   string `json:"name"`
+	Type   string `json:"type"`
+	Status string `json:"status"`
+}
+
+var (
+	codeownersUserPattern  = regexp.MustCompile(`^@[A-Za-z0-9](?:[A-Za-z0-9-]*[A-Za-z0-9])?$`)
+	codeownersTeamPattern  = regexp.MustCompile(`^@[A-Za-z0-9][A-Za-z0-9-]*/[A-Za-z0-9][A-Za-z0-9_.-]*$`)
+	codeownersEmailPattern = regexp.MustCompile(`^[^@\s]+@[^@\s]+\.[^@\s]+$`)
+)
+
+// ParseCodeowners parses the content of the CODEOWNERS file in path.
+// Lines that GitHub would ignore are reported as errors rather than failing the whole file.
+func ParseCodeowners(path string, content []byte) *Codeowners {
+	codeowners := &Codeowners{
+		Path:   path,
+		Rules:  []*CodeownersRule{},
+		Errors: []*CodeownersError{},
+		Owners: []*CodeownersOwner{},
+	}
+	seenOwners := make(map[string]bool)
+
+	for i, line := range strings.Split(string(content), "\n") {
+		lineNumber := i + 1
+		source := strings.TrimSpace(stripCodeownersComment(line))
+		if source == "" {
+			continue
+		}
+
+		fields := strings.Fields(source)
+		rule := &CodeownersRule{
+			Line:    lineNumber,
+			Pattern: fields[0],
+			Owners:  []string{},
+		}
+		if err := validateCodeownersPattern(rule.Pattern); err != nil {
+			codeowners.Errors = append(codeowners.Errors, &CodeownersError{
+				Line:    lineNumber,
+				Kind:    "Invalid pattern",
+				Source:  source,
+				Message: err.Error(),
+			})
+			continue
+		}
+
+		for _, owner := range fields[1:] {
+			ownerType := codeownerType(owner)
+			if ownerType == "" {
+				codeowners.Errors = append(codeowners.Errors, &CodeownersError{
+					Line:    lineNumber,
+					Kind:    "Invalid owner",
+					Source:  source,
+					Message: fmt.Sprintf("%s is not a user, a team or an email address", owner),
+				})
+				continue
+			}
+			rule.Owners = append(rule.Owners, owner)
+			if !seenOwners[strings.ToLower(owner)] {
+				seenOwners[strings.ToLower(owner)] = true
+				codeowners.Owners = append(codeowners.Owners, &CodeownersOwner{
+					Name:   owner,
+					Type:   ownerType,
+					Status: CodeownerUnresolved,
+				})
+			}
+		}
+		codeowners.Rules = append(codeowners.Rules, rule)
+	}
+
+	return codeowners
+}
+
+// OwnersOf returns the owners of path, according to the last rule that matches it
+func (c *Codeowners) OwnersOf(path string) []string {
+	for i := len(c.Rules) - 1; i >= 0; i-- {
+		if codeownersPatternMatches(c.Rules[i].Pattern, path) {
+			return c.Rules[i].Owners
+		}
+	}
+
+	return nil
+}
+
+// Uncovered returns the paths that have no owners
+func (c *Codeowners) Uncovered(paths []string) []string {
+	uncovered := []string{}
+	for _, path := range paths {
+		if len(c.OwnersOf(path)) == 0 {
+			uncovered = append(uncovered, path)
+		}
+	}
+
+	return uncovered
+}
+
+func stripCodeownersComment(line string) string {
+	for i := 0; i < len(line); i++ {
+		if line[i] == '\\' {
+			i++
+			continue
+		}
+		if line[i] == '#' {
+			return line[:i]
+		}
+	}
+
+	return line
+}
+
+// validateCodeownersPattern rejects the gitignore syntax that CODEOWNERS doesn't support
+func validateCodeownersPattern(pattern string) error {
+	switch {
+	case strings.HasPrefix(pattern, "!"):
+		return fmt.Errorf("negated pattern %s is not supported", pattern)
+	case strings.ContainsAny(pattern, "[]"):
+		return fmt.Errorf("character ranges in pattern %s are not supported", pattern)
+	case strings.HasPrefix(pattern, "\\#"):
+		return fmt.Errorf("escaped pattern %s is not supported", pattern)
+	}
+
+	return nil
+}
+
+func codeownerType(owner string) string {
+	switch {
+	case codeownersTeamPattern.MatchString(owner):
+		return CodeownerTypeTeam
+	case codeownersUserPattern.MatchString(owner):
+		return CodeownerTypeUser
+	case codeownersEmailPattern.MatchString(owner):
+		return CodeownerTypeEmail
+	}
+
+	return ""
+}
+
+// codeownersPatternMatches tells whether the gitignore-style pattern matches path or one of its parent directories.
+// A trailing slash in path denotes a directory, which is matched only by patterns that match all of its content.
+func codeownersPatternMatches(pattern string, path string) bool {
+	path = strings.TrimSuffix(path, "/")
+
+	// a pattern with a slash at the beginning or in the middle is relative to the root
+	anchored := strings.Contains(strings.TrimSuffix(pattern, "/"), "/")
+	pattern = strings.Trim(pattern, "/")
+
+	var expression strings.Builder
+	expression.WriteString("^")
+	if !anchored {
+		expression.WriteString("(?:.*/)?")
+	}
+	for i := 0; i < len(pattern); i++ {
+		switch {
+		case strings.HasPrefix(pattern[i:], "**/"):
+			expression.WriteString("(?:.*/)?")
+			i += 2
+		case strings.HasPrefix(pattern[i:], "**"):
+			expression.WriteString(".*")
+			i++
+		case pattern[i] == '*':
+			expression.WriteString("[^/]*")
+		case pattern[i] == '?':
+			expression.WriteString("[^/]")
+		default:
+			expression.WriteString(regexp.QuoteMeta(string(pattern[i])))
+		}
+	}
+	expression.WriteString("(?:/.*)?$")
+
+	matcher, err := regexp.Compile(expression.String())
+	if err != nil {
+		return false
+	}
+
+	return matcher.MatchString(path)
+}
@@ -0,0 +1,69 @@
+package githubcollected_test
+
+import (
+	"testing"
+
+	githubcollected "github.com/Legit-Labs/legitify/internal/collected/github"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseCodeowners(t *testing.T) {
+	content := `
+# default owners
+*       @org/developers # trailing comment
+*.go    @gopher gopher@example.com
+!vendor @org/developers
+/docs/  not-an-owner
+/.github/workflows/ @org/devops @Gopher
+`
+	codeowners := githubcollected.ParseCodeowners(".github/CODEOWNERS", []byte(content))
+
+	require.Equal(t, ".github/CODEOWNERS", codeowners.Path)
+	require.Len(t, codeowners.Rules, 4)
+	require.Equal(t, 3, codeowners.Rules[0].Line)
+	require.Equal(t, []string{"@org/developers"}, codeowners.Rules[0].Owners)
+	require.Equal(t, "/docs/", codeowners.Rules[2].Pattern)
+	require.Empty(t, codeowners.Rules[2].Owners)
+
+	require.Len(t, codeowners.Errors, 2)
+	require.Equal(t, "Invalid pattern", codeowners.Errors[0].Kind)
+	require.Equal(t, 5, codeowners.Errors[0].Line)
+	require.Equal(t, "Invalid owner", codeowners.Errors[1].Kind)
+
+	require.Len(t, codeowners.Owners, 4)
+	require.Equal(t, githubcollected.CodeownerTypeTeam, codeowners.Owners[0].Type)
+	require.Equal(t, githubcollected.CodeownerTypeUser, codeowners.Owners[1].Type)
+	require.Equal(t, githubcollected.CodeownerTypeEmail, codeowners.Owners[2].Type)
+	require.Equal(t, "@org/devops", codeowners.Owners[3].Name)
+}
+
+func TestCodeownersOwnersOf(t *testing.T) {
+	content := `
+*           @org/developers
+*.yml       @yaml-owner
+/docs/      @org/writers
+src/**/test @tester
+/.github/   @org/devops
+`
+	codeowners := githubcollected.ParseCodeowners("CODEOWNERS", []byte(content))
+
+	require.Equal(t, []string{"@org/developers"}, codeowners.OwnersOf("main.go"))
+	require.Equal(t, []string{"@yaml-owner"}, codeowners.OwnersOf("config/app.yml"))
+	require.Equal(t, []string{"@org/writers"}, codeowners.OwnersOf("docs/guide/index.md"))
+	require.Equal(t, []string{"@org/developers"}, codeowners.OwnersOf("other/docs/index.md"))
+	require.Equal(t, []string{"@tester"}, codeowners.OwnersOf("src/a/b/test/case.go"))
+	require.Equal(t, []string{"@org/devops"}, codeowners.OwnersOf(".github/workflows/"))
+	require.Equal(t, []string{"@org/devops"}, codeowners.OwnersOf(".github/workflows/ci.yml"))
+}
+
+func TestCodeownersUncovered(t *testing.T) {
+	content := `
+*.go                @gopher
+/.github/workflows/ @org/devops
+/.github/actions/
+`
+	codeowners := githubcollected.ParseCodeowners("CODEOWNERS", []byte(content))
+
+	require.Equal(t, []string{".github/actions/", "CODEOWNERS"},
+		codeowners.Uncovered(append(githubcollected.CodeownersSensitivePaths, codeowners.Path)))
+}
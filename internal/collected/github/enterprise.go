@@ -13,6 +13,7 @@ type Enterprise struct {
 	TwoFactorRequiredSetting                      string `json:"two_factor_required_setting"`
 	SamlEnabled                                   bool   `json:"saml_enabled"`
 	EnterpriseName                                string `json:"name"`
+	Slug                                          string `json:"slug"`
 	Url                                           string `json:"url"`
 	Id                                            int64  `json:"id"`
 	UserRole                                      string
@@ -21,6 +22,9 @@ type Enterprise struct {
 	MembersCanDeleteRepositoriesSetting           string                             `json:"member_can_delete_repository"`
 	NotificationDeliveryRestrictionEnabledSetting string                             `json:"notification_delivery_restriction_enabled"`
 	CodeAndSecurityPolicySettings                 *types.AnalysisAndSecurityPolicies `json:"code_analysis_and_security_policies"`
+	Rulesets                                      []*types.Ruleset                   `json:"rulesets"`
+	// Organizations are the logins of the organizations of the enterprise
+	Organizations []string `json:"organizations"`
 }
 
 func NewEnterprise(membersCanChangeRepositoryVisibilitySetting string, name string, Url string, Id int64, isAdmin bool, repositoriesForkingPolicy string,
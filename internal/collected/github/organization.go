@@ -1,6 +1,7 @@
 package githubcollected
 
 import (
+	"github.com/Legit-Labs/legitify/internal/clients/github/types"
 	"github.com/Legit-Labs/legitify/internal/common/namespace"
 	"github.com/Legit-Labs/legitify/internal/common/permissions"
 
@@ -46,6 +47,7 @@ type Organization struct {
 	Hooks        []*github.Hook `json:"hooks"`
 	UserRole     permissions.OrganizationRole
 	OrgSecrets   []*OrganizationSecret `json:"organization_secrets,omitempty"`
+	Rulesets     []*types.Ruleset      `json:"rulesets"`
 }
 
 type OrganizationSecret struct {
@@ -0,0 +1,41 @@
+package githubcollected
+
+import (
+	"fmt"
+)
+
+type OrganizationApps struct {
+	Organization  ExtendedOrg        `json:"organization"`
+	Installations []*AppInstallation `json:"installations"`
+	// OAuthAppRestrictions is nil when the state of the restrictions is unknown
+	OAuthAppRestrictions *bool `json:"oauth_app_restrictions"`
+}
+
+// AppInstallation is a GitHub App installed on the organization. The timestamps are in UnixNano.
+type AppInstallation struct {
+	ID                  int64             `json:"id"`
+	AppID               int64             `json:"app_id"`
+	AppSlug             string            `json:"app_slug"`
+	RepositorySelection string            `json:"repository_selection"`
+	Permissions         map[string]string `json:"permissions"`
+	Events              []string          `json:"events"`
+	CreatedAt           int               `json:"created_at"`
+	Suspended           bool              `json:"suspended"`
+}
+
+func (o OrganizationApps) ViolationEntityType() string {
+	return "organization apps"
+}
+
+func (o OrganizationApps) CanonicalLink() string {
+	const linkTemplate = "https://github.com/organizations/%s/settings/installations"
+	return fmt.Sprintf(linkTemplate, *o.Organization.Login)
+}
+
+func (o OrganizationApps) Name() string {
+	return *o.Organization.Login
+}
+
+func (o OrganizationApps) ID() int64 {
+	return *o.Organization.ID
+}
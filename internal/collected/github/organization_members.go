@@ -10,12 +10,55 @@ type OrganizationMember struct {
 	User       *github.User `json:"user"`
 	LastActive int          `json:"last_active"`
 	IsAdmin    bool         `json:"is_admin"`
+	// TwoFactorDisabled is nil when the two-factor authentication status of the member cannot be read
+	TwoFactorDisabled *bool `json:"two_factor_disabled"`
+	// ExternalIdentity is nil when the member has no identity linked in the identity provider of the organization
+	ExternalIdentity *ExternalIdentity `json:"external_identity"`
 }
 
+// ExternalIdentity is the SAML SSO and SCIM identity of a member in the identity provider of the organization
+type ExternalIdentity struct {
+	Guid         string `json:"guid"`
+	SamlNameID   string `json:"saml_name_id,omitempty"`
+	ScimUsername string `json:"scim_username,omitempty"`
+	// ScimActive is false when the user was deprovisioned through SCIM, and nil when the user was not provisioned through SCIM
+	ScimActive *bool `json:"scim_active"`
+}
+
+// OrganizationMembers holds the members of an organization.
+// HasTwoFactorStatus is false when the two-factor authentication status of the members could not be read,
+// and HasSamlSso is nil when their external identities could not be read.
 type OrganizationMembers struct {
-	Organization  ExtendedOrg          `json:"organization"`
-	Members       []OrganizationMember `json:"members"`
-	HasLastActive bool                 `json:"has_last_active"`
+	Organization         ExtendedOrg           `json:"organization"`
+	Members              []OrganizationMember  `json:"members"`
+	HasLastActive        bool                  `json:"has_last_active"`
+	HasTwoFactorStatus   bool                  `json:"has_two_factor_status"`
+	HasSamlSso           *bool                 `json:"has_saml_sso"`
+	OutsideCollaborators []OutsideCollaborator `json:"outside_collaborators"`
+	PendingInvitations   []PendingInvitation   `json:"pending_invitations"`
+}
+
+// OutsideCollaborator is a user who isn't a member of the organization but has access to some of its repositories
+type OutsideCollaborator struct {
+	User         *github.User             `json:"user"`
+	LastActive   int                      `json:"last_active"`
+	Repositories []CollaboratorRepository `json:"repositories"`
+}
+
+type CollaboratorRepository struct {
+	Name       string `json:"name"`
+	Permission string `json:"permission"`
+}
+
+// PendingInvitation is an invitation to the organization, or to one of its repositories when Repository is set.
+// Expired invitations to the organization are listed by its failed invitations. CreatedAt is in UnixNano.
+type PendingInvitation struct {
+	Login      string `json:"login"`
+	Email      string `json:"email,omitempty"`
+	Role       string `json:"role"`
+	Repository string `json:"repository,omitempty"`
+	CreatedAt  int    `json:"created_at"`
+	Expired    bool   `json:"expired"`
 }
 
 func NewOrganizationMember(user *github.User, lastActive int, memberType string) OrganizationMember {
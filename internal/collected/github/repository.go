@@ -1,8 +1,12 @@
 package githubcollected
 
 import (
+	"net/url"
+	"strings"
+
 	"github.com/Legit-Labs/legitify/internal/clients/github/types"
 	"github.com/Legit-Labs/legitify/internal/common/namespace"
+	"github.com/Legit-Labs/legitify/internal/common/repo_filter"
 	"github.com/Legit-Labs/legitify/internal/scorecard"
 	"github.com/google/go-github/v53/github"
 	"github.com/shurcooL/githubv4"
@@ -38,6 +42,69 @@ type GitHubQLRepository struct {
 	DefaultBranchRef   *GitHubQLBranch    `json:"default_branch"`
 	PushedAt           *githubv4.DateTime `json:"pushed_at"`
 	ViewerPermission   string             `json:"viewerPermission"`
+	Visibility         string             `json:"visibility"`
+	PrimaryLanguage    *GitHubQLLanguage  `json:"primary_language"`
+	RepositoryTopics   GitHubQLTopics     `json:"repository_topics" graphql:"repositoryTopics(first: 20)"`
+}
+
+type GitHubQLLanguage struct {
+	Name string `json:"name"`
+}
+
+type GitHubQLTopics struct {
+	Nodes []GitHubQLTopicNode `json:"nodes"`
+}
+
+type GitHubQLTopicNode struct {
+	Topic GitHubQLTopic `json:"topic"`
+}
+
+type GitHubQLTopic struct {
+	Name string `json:"name"`
+}
+
+// Classification returns the repository metadata that the repository filter and the policy applicability are matched against
+func (r *GitHubQLRepository) Classification() repo_filter.Repository {
+	classification := repo_filter.Repository{
+		Name:       r.Name,
+		Visibility: r.Visibility,
+		Topics:     r.Topics(),
+	}
+	if r.PrimaryLanguage != nil {
+		classification.Language = r.PrimaryLanguage.Name
+	}
+
+	return classification
+}
+
+// FullName returns owner/name. The repository url is the only place the owner is available (e.g. https://github.com/owner/name),
+// so it falls back to the name when the url can't be parsed.
+func (r *GitHubQLRepository) FullName() string {
+	u, err := url.Parse(r.Url)
+	if err != nil || strings.Trim(u.Path, "/") == "" {
+		return r.Name
+	}
+
+	return strings.Trim(u.Path, "/")
+}
+
+// Owner returns the login of the organization (or user) that owns the repository, or an empty string if it is unknown
+func (r *GitHubQLRepository) Owner() string {
+	owner, _, found := strings.Cut(r.FullName(), "/")
+	if !found {
+		return ""
+	}
+
+	return owner
+}
+
+func (r *GitHubQLRepository) Topics() []string {
+	topics := make([]string, 0, len(r.RepositoryTopics.Nodes))
+	for _, node := range r.RepositoryTopics.Nodes {
+		topics = append(topics, node.Topic.Name)
+	}
+
+	return topics
 }
 
 type GitHubQLBranchProtectionRule struct {
@@ -73,6 +140,80 @@ type Repository struct {
 	RulesSet                     []*types.RepositoryRule           `json:"rules_set,omitempty"`
 	RepoSecrets                  []*RepositorySecret               `json:"repository_secrets,omitempty"`
 	SecurityAndAnalysis          *github.SecurityAndAnalysis       `json:"security_and_analysis,omitempty"`
+	Workflows                    []*Workflow                       `json:"workflows"`
+	DeployKeys                   []*RepositoryDeployKey            `json:"deploy_keys"`
+	Environments                 []*RepositoryEnvironment          `json:"environments"`
+	SecurityAlerts               *RepositorySecurityAlerts         `json:"security_alerts"`
+	Codeowners                   *Codeowners                       `json:"codeowners"`
+}
+
+// RepositorySecurityAlerts summarizes the open alerts of the repository security features.
+// A feature is nil if its alerts can't be read, e.g. because it is disabled.
+type RepositorySecurityAlerts struct {
+	// Dependabot alerts by severity (low, medium, high, critical)
+	Dependabot map[string]*OpenAlerts `json:"dependabot"`
+	// CodeScanning alerts by security severity (low, medium, high, critical),
+	// or by rule severity (note, warning, error) for rules that aren't security rules
+	CodeScanning   map[string]*OpenAlerts `json:"code_scanning"`
+	SecretScanning *OpenAlerts            `json:"secret_scanning"`
+}
+
+type OpenAlerts struct {
+	Count int `json:"count"`
+	// OldestCreatedAt is the creation date of the oldest open alert
+	OldestCreatedAt int `json:"oldest_created_at"`
+}
+
+func (a *OpenAlerts) Add(createdAt int) {
+	if a.Count == 0 || createdAt < a.OldestCreatedAt {
+		a.OldestCreatedAt = createdAt
+	}
+	a.Count++
+}
+
+type RepositoryDeployKey struct {
+	ID        int64  `json:"id"`
+	Title     string `json:"title"`
+	ReadOnly  bool   `json:"read_only"`
+	AddedBy   string `json:"added_by,omitempty"`
+	CreatedAt int    `json:"created_at"`
+	// LastUsed is 0 if the key was never used
+	LastUsed int `json:"last_used"`
+}
+
+type RepositoryEnvironment struct {
+	Name            string                 `json:"name"`
+	WaitTimer       int                    `json:"wait_timer"`
+	Reviewers       []*EnvironmentReviewer `json:"reviewers"`
+	CanAdminsBypass bool                   `json:"can_admins_bypass"`
+	// DeploymentBranchPolicy is nil if all branches can deploy to the environment
+	DeploymentBranchPolicy *EnvironmentBranchPolicy `json:"deployment_branch_policy"`
+	Secrets                []*RepositorySecret      `json:"secrets"`
+}
+
+type EnvironmentReviewer struct {
+	// Possible values for Type are: User, Team
+	Type string `json:"type"`
+	Name string `json:"name"`
+}
+
+// NewEnvironmentReviewer extracts the name of a required reviewer, which is either a user or a team
+func NewEnvironmentReviewer(reviewer *github.RequiredReviewer) *EnvironmentReviewer {
+	result := &EnvironmentReviewer{Type: reviewer.GetType()}
+	switch details := reviewer.Reviewer.(type) {
+	case *github.User:
+		result.Name = details.GetLogin()
+	case *github.Team:
+		result.Name = details.GetSlug()
+	}
+
+	return result
+}
+
+type EnvironmentBranchPolicy struct {
+	ProtectedBranches bool `json:"protected_branches"`
+	// CustomBranchPolicies are the name patterns of the branches that can deploy, if custom policies are used
+	CustomBranchPolicies []string `json:"custom_branch_policies"`
 }
 
 type RepositorySecret struct {
@@ -92,6 +233,18 @@ func (r Repository) Name() string {
 	return r.Repository.Name
 }
 
+func (r Repository) IsArchived() bool {
+	return r.Repository != nil && r.Repository.IsArchived
+}
+
+func (r Repository) Classification() repo_filter.Repository {
+	if r.Repository == nil {
+		return repo_filter.Repository{}
+	}
+
+	return r.Repository.Classification()
+}
+
 func (r Repository) ID() int64 {
 	// Deliberately using the Org; see membersList enricher
 	return r.Repository.DatabaseId
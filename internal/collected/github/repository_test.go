@@ -0,0 +1,42 @@
+package githubcollected_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	githubcollected "github.com/Legit-Labs/legitify/internal/collected/github"
+	"github.com/google/go-github/v53/github"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewEnvironmentReviewer(t *testing.T) {
+	// the protection rules of an environment, as returned by the API
+	payload := `{
+		"id": 3755,
+		"type": "required_reviewers",
+		"reviewers": [
+			{"type": "User", "reviewer": {"login": "octocat", "id": 1, "type": "User"}},
+			{"type": "Team", "reviewer": {"id": 1, "name": "Justice League", "slug": "justice-league"}}
+		]
+	}`
+
+	var rule github.ProtectionRule
+	require.Nil(t, json.Unmarshal([]byte(payload), &rule))
+	require.Len(t, rule.Reviewers, 2)
+
+	user := githubcollected.NewEnvironmentReviewer(rule.Reviewers[0])
+	require.Equal(t, &githubcollected.EnvironmentReviewer{Type: "User", Name: "octocat"}, user)
+
+	team := githubcollected.NewEnvironmentReviewer(rule.Reviewers[1])
+	require.Equal(t, &githubcollected.EnvironmentReviewer{Type: "Team", Name: "justice-league"}, team)
+}
+
+func TestRepositoryFullName(t *testing.T) {
+	repository := &githubcollected.GitHubQLRepository{Name: "repo", Url: "https://github.com/org/repo"}
+	require.Equal(t, "org/repo", repository.FullName())
+	require.Equal(t, "org", repository.Owner())
+
+	unknown := &githubcollected.GitHubQLRepository{Name: "repo"}
+	require.Equal(t, "repo", unknown.FullName())
+	require.Empty(t, unknown.Owner())
+}
@@ -0,0 +1,269 @@
+package githubcollected
+
+import (
+	"regexp"
+	"strings"
+	"sync"
+
+	"github.com/Legit-Labs/legitify/internal/clients/github/types"
+	"github.com/google/go-github/v53/github"
+)
+
+const (
+	rulesetEnforcementActive = "active"
+	rulesetTargetBranch      = "branch"
+	rulesetMatchAll          = "~ALL"
+	rulesetMatchDefault      = "~DEFAULT_BRANCH"
+	rulesetSourceEnterprise  = "Enterprise"
+	rulesetActorRole         = "RepositoryRole"
+	// rulesetRoleWrite is the actor ID of the write repository role
+	rulesetRoleWrite = 4
+)
+
+// rulesetPatterns caches the compiled ruleset patterns, since the same patterns are matched against every repository and branch
+var rulesetPatterns sync.Map
+
+// RulesetTarget is a branch that organization and enterprise rulesets may apply to
+type RulesetTarget struct {
+	Organization    string
+	Repository      string
+	RepositoryID    int64
+	Branch          string
+	IsDefaultBranch bool
+	Properties      []*types.RepositoryPropertyValue
+}
+
+// EffectiveRules returns the rules that the branch receives from its own rules and from the organization and enterprise rulesets that apply to it.
+// The rules of the branch may already include the rules of these rulesets, so rules are deduplicated by their ruleset.
+// Rulesets that everyone who can push may bypass don't protect the branch, so their rules are left out.
+func EffectiveRules(rules []*types.RepositoryRule, target RulesetTarget, rulesets []*types.Ruleset) []*types.RepositoryRule {
+	type ruleKey struct {
+		ruleset  int64
+		ruleType string
+	}
+
+	bypassed := make(map[int64]bool)
+	for _, ruleset := range rulesets {
+		if IsBypassedByEveryone(ruleset) {
+			bypassed[ruleset.ID] = true
+		}
+	}
+
+	effective := make([]*types.RepositoryRule, 0, len(rules))
+	seen := make(map[ruleKey]bool)
+	for _, rule := range rules {
+		if bypassed[rule.Id] {
+			continue
+		}
+		effective = append(effective, rule)
+		seen[ruleKey{rule.Id, rule.Type}] = true
+	}
+
+	for _, ruleset := range rulesets {
+		if bypassed[ruleset.ID] || !RulesetApplies(ruleset, target) {
+			continue
+		}
+
+		summary := ruleset.Ruleset
+		summary.Rules = nil
+		summary.Conditions = nil
+		// the bypass actors of the ruleset are decoded into its own field, which shadows the one of github.Ruleset
+		summary.BypassActors = bypassActorsOf(ruleset)
+		for _, rule := range ruleset.Rules {
+			key := ruleKey{ruleset.ID, rule.Type}
+			if seen[key] {
+				continue
+			}
+			seen[key] = true
+
+			effective = append(effective, &types.RepositoryRule{
+				Type:       rule.Type,
+				Parameters: rule.Parameters,
+				Id:         ruleset.ID,
+				Ruleset:    &summary,
+				SourceType: ruleset.GetSourceType(),
+				Source:     ruleset.Source,
+			})
+		}
+	}
+
+	return effective
+}
+
+// IsBypassedByEveryone tells whether every user who can push to the repository may bypass the ruleset
+func IsBypassedByEveryone(ruleset *types.Ruleset) bool {
+	for _, actor := range ruleset.BypassActors {
+		if actor.ActorType == rulesetActorRole && actor.ActorID == rulesetRoleWrite {
+			return true
+		}
+	}
+
+	return false
+}
+
+func bypassActorsOf(ruleset *types.Ruleset) []*github.BypassActor {
+	actors := make([]*github.BypassActor, 0, len(ruleset.BypassActors))
+	for _, actor := range ruleset.BypassActors {
+		actor := actor
+		actors = append(actors, &github.BypassActor{ActorID: &actor.ActorID, ActorType: &actor.ActorType})
+	}
+
+	return actors
+}
+
+// RulesetApplies tells whether an active branch ruleset applies to the target according to its conditions.
+// Rulesets with conditions that can't be evaluated are considered as not applying.
+func RulesetApplies(ruleset *types.Ruleset, target RulesetTarget) bool {
+	if ruleset.Enforcement != rulesetEnforcementActive {
+		return false
+	}
+	if ruleset.Target != nil && *ruleset.Target != rulesetTargetBranch {
+		return false
+	}
+
+	conditions := ruleset.Conditions
+	if conditions == nil {
+		return false
+	}
+
+	if conditions.OrganizationName == nil && ruleset.GetSourceType() == rulesetSourceEnterprise {
+		// the organizations of an enterprise ruleset may be selected by conditions that legitify doesn't evaluate
+		return false
+	}
+	if conditions.OrganizationName != nil &&
+		!matchesCondition(conditions.OrganizationName.Include, conditions.OrganizationName.Exclude, target.Organization) {
+		return false
+	}
+
+	switch {
+	case conditions.RepositoryName != nil:
+		if !matchesCondition(conditions.RepositoryName.Include, conditions.RepositoryName.Exclude, target.Repository) {
+			return false
+		}
+	case conditions.RepositoryProperty != nil:
+		if !matchesProperties(conditions.RepositoryProperty, target.Properties) {
+			return false
+		}
+	case conditions.RepositoryID != nil:
+		if !containsID(conditions.RepositoryID.RepositoryIDs, target.RepositoryID) {
+			return false
+		}
+	default:
+		return false
+	}
+
+	if conditions.RefName == nil {
+		return false
+	}
+	matchesRef := func(pattern string) bool {
+		if pattern == rulesetMatchDefault {
+			return target.IsDefaultBranch
+		}
+		return MatchRulesetPattern(pattern, "refs/heads/"+target.Branch)
+	}
+	for _, exclude := range conditions.RefName.Exclude {
+		if matchesRef(exclude) {
+			return false
+		}
+	}
+	for _, include := range conditions.RefName.Include {
+		if matchesRef(include) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func matchesCondition(include []string, exclude []string, name string) bool {
+	for _, pattern := range exclude {
+		if MatchRulesetPattern(pattern, name) {
+			return false
+		}
+	}
+	for _, pattern := range include {
+		if MatchRulesetPattern(pattern, name) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// matchesProperties requires every included property to have one of its values, and no excluded property to have one
+func matchesProperties(conditions *types.RulesetPropertyConditionParameters, properties []*types.RepositoryPropertyValue) bool {
+	hasValue := func(target types.RulesetPropertyTarget) bool {
+		for _, property := range properties {
+			if property.PropertyName != target.Name {
+				continue
+			}
+			for _, value := range property.Values {
+				for _, expected := range target.PropertyValues {
+					if value == expected {
+						return true
+					}
+				}
+			}
+		}
+		return false
+	}
+
+	for _, exclude := range conditions.Exclude {
+		if hasValue(exclude) {
+			return false
+		}
+	}
+	for _, include := range conditions.Include {
+		if !hasValue(include) {
+			return false
+		}
+	}
+
+	return len(conditions.Include) > 0
+}
+
+func containsID(ids []int64, id int64) bool {
+	for _, i := range ids {
+		if i == id {
+			return true
+		}
+	}
+
+	return false
+}
+
+// MatchRulesetPattern matches the fnmatch patterns of rulesets, in which '*' doesn't cross '/' but '**' does
+func MatchRulesetPattern(pattern string, name string) bool {
+	if pattern == rulesetMatchAll {
+		return true
+	}
+
+	if compiled, ok := rulesetPatterns.Load(pattern); ok {
+		return compiled.(*regexp.Regexp).MatchString(name)
+	}
+
+	var expr strings.Builder
+	expr.WriteString("^")
+	runes := []rune(pattern)
+	for i := 0; i < len(runes); i++ {
+		switch c := runes[i]; c {
+		case '*':
+			if i+1 < len(runes) && runes[i+1] == '*' {
+				expr.WriteString(".*")
+				i++
+			} else {
+				expr.WriteString("[^/]*")
+			}
+		case '?':
+			expr.WriteString("[^/]")
+		default:
+			expr.WriteString(regexp.QuoteMeta(string(c)))
+		}
+	}
+	expr.WriteString("$")
+
+	compiled := regexp.MustCompile(expr.String())
+	rulesetPatterns.Store(pattern, compiled)
+
+	return compiled.MatchString(name)
+}
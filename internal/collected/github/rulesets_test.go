@@ -0,0 +1,183 @@
+package githubcollected_test
+
+import (
+	"testing"
+
+	"github.com/Legit-Labs/legitify/internal/clients/github/types"
+	githubcollected "github.com/Legit-Labs/legitify/internal/collected/github"
+	"github.com/google/go-github/v53/github"
+	"github.com/stretchr/testify/require"
+)
+
+func orgRuleset(id int64, enforcement string, conditions *types.RulesetConditions, ruleTypes ...string) *types.Ruleset {
+	ruleset := &types.Ruleset{
+		Ruleset: github.Ruleset{
+			ID:          id,
+			Name:        "org ruleset",
+			Target:      github.String("branch"),
+			SourceType:  github.String("Organization"),
+			Source:      "org",
+			Enforcement: enforcement,
+		},
+		Conditions: conditions,
+	}
+	for _, t := range ruleTypes {
+		ruleset.Rules = append(ruleset.Rules, &types.RulesetRule{Type: t})
+	}
+
+	return ruleset
+}
+
+func defaultBranchOf(repositories ...string) *types.RulesetConditions {
+	return &types.RulesetConditions{
+		RefName:        &github.RulesetRefConditionParameters{Include: []string{"~DEFAULT_BRANCH"}, Exclude: []string{}},
+		RepositoryName: &github.RulesetRepositoryConditionParameters{Include: repositories},
+	}
+}
+
+func TestEffectiveRules(t *testing.T) {
+	target := githubcollected.RulesetTarget{
+		Organization:    "org",
+		Repository:      "service",
+		Branch:          "main",
+		IsDefaultBranch: true,
+	}
+	repoRules := []*types.RepositoryRule{{Type: "deletion", Id: 1}}
+
+	effective := githubcollected.EffectiveRules(repoRules, target, []*types.Ruleset{
+		orgRuleset(1, "active", defaultBranchOf("~ALL"), "deletion", "pull_request"),
+		orgRuleset(2, "evaluate", defaultBranchOf("~ALL"), "required_signatures"),
+		orgRuleset(3, "active", defaultBranchOf("other-*"), "non_fast_forward"),
+	})
+
+	require.Len(t, effective, 2, "expected the rules of the repository and the missing rule of the active org ruleset")
+	require.Equal(t, "deletion", effective[0].Type)
+	require.Equal(t, "pull_request", effective[1].Type)
+	require.Equal(t, int64(1), effective[1].Id)
+	require.Equal(t, "Organization", effective[1].SourceType)
+	require.NotNil(t, effective[1].Ruleset)
+}
+
+func TestEffectiveRulesKeepBypassActors(t *testing.T) {
+	target := githubcollected.RulesetTarget{
+		Organization:    "org",
+		Repository:      "service",
+		Branch:          "main",
+		IsDefaultBranch: true,
+	}
+	ruleset := orgRuleset(1, "active", defaultBranchOf("~ALL"), "pull_request")
+	ruleset.BypassActors = []*types.RulesetBypassActor{{ActorID: 1, ActorType: "OrganizationAdmin", BypassMode: "always"}}
+
+	effective := githubcollected.EffectiveRules(nil, target, []*types.Ruleset{ruleset})
+
+	require.Len(t, effective, 1)
+	require.Len(t, effective[0].Ruleset.BypassActors, 1, "expected the bypass actors of the org ruleset in the rule summary")
+	require.Equal(t, int64(1), effective[0].Ruleset.BypassActors[0].GetActorID())
+	require.Equal(t, "OrganizationAdmin", effective[0].Ruleset.BypassActors[0].GetActorType())
+}
+
+func TestEffectiveRulesOfEnterpriseAndBypassedRulesets(t *testing.T) {
+	target := githubcollected.RulesetTarget{
+		Organization:    "org",
+		Repository:      "service",
+		Branch:          "main",
+		IsDefaultBranch: true,
+	}
+
+	enterpriseRuleset := func(id int64, organizations *github.RulesetRefConditionParameters, ruleType string) *types.Ruleset {
+		ruleset := orgRuleset(id, "active", defaultBranchOf("~ALL"), ruleType)
+		ruleset.SourceType = github.String("Enterprise")
+		ruleset.Conditions.OrganizationName = organizations
+		return ruleset
+	}
+	bypassed := orgRuleset(4, "active", defaultBranchOf("~ALL"), "deletion")
+	bypassed.BypassActors = []*types.RulesetBypassActor{{ActorID: 4, ActorType: "RepositoryRole", BypassMode: "always"}}
+	adminsBypass := orgRuleset(5, "active", defaultBranchOf("~ALL"), "required_signatures")
+	adminsBypass.BypassActors = []*types.RulesetBypassActor{{ActorID: 5, ActorType: "RepositoryRole", BypassMode: "always"}}
+
+	// the rules of the repository already include the rules of the bypassed ruleset
+	repoRules := []*types.RepositoryRule{{Type: "deletion", Id: 4}}
+	effective := githubcollected.EffectiveRules(repoRules, target, []*types.Ruleset{
+		enterpriseRuleset(1, &github.RulesetRefConditionParameters{Include: []string{"org"}}, "pull_request"),
+		enterpriseRuleset(2, &github.RulesetRefConditionParameters{Include: []string{"other"}}, "non_fast_forward"),
+		enterpriseRuleset(3, nil, "creation"),
+		bypassed,
+		adminsBypass,
+	})
+
+	var ruleTypes []string
+	for _, rule := range effective {
+		ruleTypes = append(ruleTypes, rule.Type)
+	}
+	require.Equal(t, []string{"pull_request", "required_signatures"}, ruleTypes)
+	require.Equal(t, "Enterprise", effective[0].SourceType)
+}
+
+func TestRulesetApplies(t *testing.T) {
+	target := githubcollected.RulesetTarget{
+		Organization: "org",
+		Repository:   "service",
+		Branch:       "release/1.0",
+		Properties: []*types.RepositoryPropertyValue{
+			{PropertyName: "tier", Values: []string{"production"}},
+		},
+	}
+
+	refs := func(include ...string) *github.RulesetRefConditionParameters {
+		return &github.RulesetRefConditionParameters{Include: include}
+	}
+	tier := func(values ...string) *types.RulesetPropertyConditionParameters {
+		return &types.RulesetPropertyConditionParameters{
+			Include: []types.RulesetPropertyTarget{{Name: "tier", PropertyValues: values}},
+		}
+	}
+
+	tests := []struct {
+		name       string
+		conditions *types.RulesetConditions
+		applies    bool
+	}{
+		{
+			name:       "release branches of all repositories",
+			conditions: &types.RulesetConditions{RefName: refs("refs/heads/release/*"), RepositoryName: &github.RulesetRepositoryConditionParameters{Include: []string{"~ALL"}}},
+			applies:    true,
+		},
+		{
+			name:       "default branch only",
+			conditions: &types.RulesetConditions{RefName: refs("~DEFAULT_BRANCH"), RepositoryName: &github.RulesetRepositoryConditionParameters{Include: []string{"~ALL"}}},
+			applies:    false,
+		},
+		{
+			name:       "excluded repository",
+			conditions: &types.RulesetConditions{RefName: refs("~ALL"), RepositoryName: &github.RulesetRepositoryConditionParameters{Include: []string{"~ALL"}, Exclude: []string{"serv*"}}},
+			applies:    false,
+		},
+		{
+			name:       "matching repository property",
+			conditions: &types.RulesetConditions{RefName: refs("refs/heads/**"), RepositoryProperty: tier("production", "staging")},
+			applies:    true,
+		},
+		{
+			name:       "other repository property",
+			conditions: &types.RulesetConditions{RefName: refs("refs/heads/**"), RepositoryProperty: tier("staging")},
+			applies:    false,
+		},
+		{
+			name:       "other organization",
+			conditions: &types.RulesetConditions{RefName: refs("~ALL"), RepositoryName: &github.RulesetRepositoryConditionParameters{Include: []string{"~ALL"}}, OrganizationName: refs("other")},
+			applies:    false,
+		},
+	}
+
+	for _, test := range tests {
+		require.Equal(t, test.applies, githubcollected.RulesetApplies(orgRuleset(1, "active", test.conditions), target), test.name)
+	}
+}
+
+func TestMatchRulesetPattern(t *testing.T) {
+	require.True(t, githubcollected.MatchRulesetPattern("refs/heads/release/*", "refs/heads/release/1.0"))
+	require.False(t, githubcollected.MatchRulesetPattern("refs/heads/release/*", "refs/heads/release/1.0/hotfix"))
+	require.True(t, githubcollected.MatchRulesetPattern("refs/heads/release/**", "refs/heads/release/1.0/hotfix"))
+	require.True(t, githubcollected.MatchRulesetPattern("~ALL", "anything"))
+	require.False(t, githubcollected.MatchRulesetPattern("a.b", "axb"), "regexp characters should be matched literally")
+}
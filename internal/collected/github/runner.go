@@ -0,0 +1,50 @@
+package githubcollected
+
+import (
+	"fmt"
+
+	"github.com/Legit-Labs/legitify/internal/clients/github/types"
+	"github.com/Legit-Labs/legitify/internal/common/namespace"
+	"github.com/google/go-github/v53/github"
+)
+
+// Runner is a self-hosted runner, registered either to the organization or to one of its repositories
+type Runner struct {
+	Organization ExtendedOrg   `json:"organization"`
+	Runner       *types.Runner `json:"runner"`
+	// RunnerGroup is the group of an organization runner, and nil for a repository runner
+	RunnerGroup *github.RunnerGroup `json:"runner_group"`
+	// Repository is the repository that the runner is registered to, and nil for an organization runner
+	Repository *RunnerRepository `json:"repository"`
+	// AllowedRepositories are the repositories that can use the runner when its group is limited to selected repositories
+	AllowedRepositories []*RunnerRepository `json:"allowed_repositories"`
+}
+
+type RunnerRepository struct {
+	Name    string `json:"name"`
+	Private bool   `json:"private"`
+}
+
+func (r Runner) ViolationEntityType() string {
+	return namespace.Runner
+}
+
+func (r Runner) CanonicalLink() string {
+	if r.Repository != nil {
+		const linkTemplate = "https://github.com/%s/%s/settings/actions/runners/%d"
+		return fmt.Sprintf(linkTemplate, r.Organization.Name(), r.Repository.Name, r.ID())
+	}
+	const linkTemplate = "https://github.com/organizations/%s/settings/actions/runners/%d"
+	return fmt.Sprintf(linkTemplate, r.Organization.Name(), r.ID())
+}
+
+func (r Runner) Name() string {
+	if r.Repository != nil {
+		return fmt.Sprintf("%s/%s/%s", r.Organization.Name(), r.Repository.Name, r.Runner.GetName())
+	}
+	return fmt.Sprintf("%s/%s", r.Organization.Name(), r.Runner.GetName())
+}
+
+func (r Runner) ID() int64 {
+	return r.Runner.GetID()
+}
@@ -0,0 +1,65 @@
+package githubcollected
+
+import (
+	"fmt"
+
+	"github.com/Legit-Labs/legitify/internal/common/namespace"
+	"github.com/google/go-github/v53/github"
+)
+
+const (
+	TeamRoleMember     = "member"
+	TeamRoleMaintainer = "maintainer"
+)
+
+type Team struct {
+	Organization ExtendedOrg       `json:"organization"`
+	Team         *github.Team      `json:"team"`
+	Members      []*TeamMember     `json:"members"`
+	Repositories []*TeamRepository `json:"repositories"`
+	// IdpGroups are the identity provider groups that the team is synchronized with
+	IdpGroups []string `json:"idp_groups"`
+}
+
+type TeamMember struct {
+	Login string `json:"login"`
+	Role  string `json:"role"`
+}
+
+type TeamRepository struct {
+	Name       string `json:"name"`
+	Private    bool   `json:"private"`
+	Archived   bool   `json:"archived"`
+	Permission string `json:"permission"`
+}
+
+func (t Team) ViolationEntityType() string {
+	return namespace.Team
+}
+
+func (t Team) CanonicalLink() string {
+	if t.Team.HTMLURL != nil {
+		return *t.Team.HTMLURL
+	}
+	const linkTemplate = "https://github.com/orgs/%s/teams/%s"
+	return fmt.Sprintf(linkTemplate, t.Organization.Name(), t.Team.GetSlug())
+}
+
+func (t Team) Name() string {
+	return fmt.Sprintf("%s/%s", t.Organization.Name(), t.Team.GetSlug())
+}
+
+func (t Team) ID() int64 {
+	return t.Team.GetID()
+}
+
+// TeamRepositoryPermission returns the highest permission the team has on a repository
+func TeamRepositoryPermission(permissions map[string]bool) string {
+	for _, permission := range []string{"admin", "maintain", "push", "triage", "pull"} {
+		if permissions[permission] {
+			return permission
+		}
+	}
+
+	return ""
+}
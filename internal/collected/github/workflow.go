@@ -0,0 +1,154 @@
+package githubcollected
+
+import (
+	"fmt"
+	"sort"
+
+	"gopkg.in/yaml.v3"
+)
+
+const WorkflowsPath = ".github/workflows"
+
+// Workflow is the structured model of a GitHub Actions workflow file
+type Workflow struct {
+	Path        string               `json:"path"`
+	Name        string               `json:"name"`
+	Triggers    []string             `json:"triggers"`
+	Permissions *WorkflowPermissions `json:"permissions"`
+	Jobs        []WorkflowJob        `json:"jobs"`
+}
+
+// WorkflowPermissions is either a permission for all scopes (read-all/write-all) or a permission per scope
+type WorkflowPermissions struct {
+	All    string            `json:"all,omitempty"`
+	Scopes map[string]string `json:"scopes,omitempty"`
+}
+
+type WorkflowJob struct {
+	ID          string               `json:"id"`
+	Name        string               `json:"name"`
+	If          string               `json:"if,omitempty"`
+	Permissions *WorkflowPermissions `json:"permissions"`
+	// Uses is set for jobs that call a reusable workflow
+	Uses  string         `json:"uses,omitempty"`
+	Steps []WorkflowStep `json:"steps"`
+}
+
+type WorkflowStep struct {
+	ID   string            `json:"id,omitempty" yaml:"id"`
+	Name string            `json:"name,omitempty" yaml:"name"`
+	If   string            `json:"if,omitempty" yaml:"if"`
+	Uses string            `json:"uses,omitempty" yaml:"uses"`
+	Run  string            `json:"run,omitempty" yaml:"run"`
+	With map[string]string `json:"with,omitempty" yaml:"with"`
+}
+
+type rawWorkflow struct {
+	Name        string                    `yaml:"name"`
+	On          yaml.Node                 `yaml:"on"`
+	Permissions yaml.Node                 `yaml:"permissions"`
+	Jobs        map[string]rawWorkflowJob `yaml:"jobs"`
+}
+
+type rawWorkflowJob struct {
+	Name        string         `yaml:"name"`
+	If          string         `yaml:"if"`
+	Permissions yaml.Node      `yaml:"permissions"`
+	Uses        string         `yaml:"uses"`
+	Steps       []WorkflowStep `yaml:"steps"`
+}
+
+// ParseWorkflow parses the content of the workflow file in path
+func ParseWorkflow(path string, content []byte) (*Workflow, error) {
+	var raw rawWorkflow
+	if err := yaml.Unmarshal(content, &raw); err != nil {
+		return nil, fmt.Errorf("failed to parse workflow %s: %v", path, err)
+	}
+
+	triggers, err := parseTriggers(&raw.On)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse the triggers of workflow %s: %v", path, err)
+	}
+	permissions, err := parsePermissions(&raw.Permissions)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse the permissions of workflow %s: %v", path, err)
+	}
+
+	workflow := &Workflow{
+		Path:        path,
+		Name:        raw.Name,
+		Triggers:    triggers,
+		Permissions: permissions,
+		Jobs:        []WorkflowJob{},
+	}
+
+	for id, rawJob := range raw.Jobs {
+		jobPermissions, err := parsePermissions(&rawJob.Permissions)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse the permissions of job %s in workflow %s: %v", id, path, err)
+		}
+		steps := rawJob.Steps
+		if steps == nil {
+			steps = []WorkflowStep{}
+		}
+		workflow.Jobs = append(workflow.Jobs, WorkflowJob{
+			ID:          id,
+			Name:        rawJob.Name,
+			If:          rawJob.If,
+			Permissions: jobPermissions,
+			Uses:        rawJob.Uses,
+			Steps:       steps,
+		})
+	}
+	sort.Slice(workflow.Jobs, func(i, j int) bool {
+		return workflow.Jobs[i].ID < workflow.Jobs[j].ID
+	})
+
+	return workflow, nil
+}
+
+// parseTriggers handles the three forms of 'on': a single event, a list of events or a map of events to their filters
+func parseTriggers(node *yaml.Node) ([]string, error) {
+	switch node.Kind {
+	case 0:
+		return []string{}, nil
+	case yaml.ScalarNode:
+		return []string{node.Value}, nil
+	case yaml.SequenceNode:
+		var triggers []string
+		if err := node.Decode(&triggers); err != nil {
+			return nil, err
+		}
+		return triggers, nil
+	case yaml.MappingNode:
+		triggers := make([]string, 0, len(node.Content)/2)
+		for i := 0; i < len(node.Content); i += 2 {
+			triggers = append(triggers, node.Content[i].Value)
+		}
+		return triggers, nil
+	default:
+		return nil, fmt.Errorf("unexpected yaml node kind %v", node.Kind)
+	}
+}
+
+// parsePermissions returns nil if no permissions are set
+func parsePermissions(node *yaml.Node) (*WorkflowPermissions, error) {
+	switch node.Kind {
+	case 0:
+		return nil, nil
+	case yaml.ScalarNode:
+		return &WorkflowPermissions{All: node.Value}, nil
+	case yaml.MappingNode:
+		var scopes map[string]string
+		if err := node.Decode(&scopes); err != nil {
+			return nil, err
+		}
+		if scopes == nil {
+			// 'permissions: {}' disables all the scopes
+			scopes = map[string]string{}
+		}
+		return &WorkflowPermissions{Scopes: scopes}, nil
+	default:
+		return nil, fmt.Errorf("unexpected yaml node kind %v", node.Kind)
+	}
+}
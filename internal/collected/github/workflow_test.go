@@ -0,0 +1,68 @@
+package githubcollected_test
+
+import (
+	"testing"
+
+	githubcollected "github.com/Legit-Labs/legitify/internal/collected/github"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseWorkflow(t *testing.T) {
+	content := `
+name: CI
+on:
+  push:
+    branches: [main]
+  pull_request_target:
+permissions: read-all
+jobs:
+  test:
+    permissions:
+      contents: read
+      pull-requests: write
+    steps:
+      - uses: actions/checkout@v3
+        with:
+          fetch-depth: 0
+      - name: Test
+        run: |
+          make test
+  release:
+    uses: org/repo/.github/workflows/release.yml@main
+`
+	workflow, err := githubcollected.ParseWorkflow(".github/workflows/ci.yml", []byte(content))
+	require.Nil(t, err)
+
+	require.Equal(t, "CI", workflow.Name)
+	require.Equal(t, []string{"push", "pull_request_target"}, workflow.Triggers)
+	require.Equal(t, "read-all", workflow.Permissions.All)
+
+	require.Len(t, workflow.Jobs, 2)
+	release, test := workflow.Jobs[0], workflow.Jobs[1]
+	require.Equal(t, "org/repo/.github/workflows/release.yml@main", release.Uses)
+	require.Nil(t, release.Permissions)
+	require.Empty(t, release.Steps)
+
+	require.Equal(t, map[string]string{"contents": "read", "pull-requests": "write"}, test.Permissions.Scopes)
+	require.Len(t, test.Steps, 2)
+	require.Equal(t, "actions/checkout@v3", test.Steps[0].Uses)
+	require.Equal(t, "0", test.Steps[0].With["fetch-depth"])
+	require.Equal(t, "make test\n", test.Steps[1].Run)
+}
+
+func TestParseWorkflow_Triggers(t *testing.T) {
+	for content, expected := range map[string][]string{
+		"on: push":               {"push"},
+		"on: [push, release]":    {"push", "release"},
+		"jobs: {}":               {},
+		"on:\n  workflow_call: ": {"workflow_call"},
+	} {
+		workflow, err := githubcollected.ParseWorkflow("workflow.yml", []byte(content))
+		require.Nil(t, err)
+		require.Equal(t, expected, workflow.Triggers, content)
+		require.Nil(t, workflow.Permissions, content)
+	}
+
+	_, err := githubcollected.ParseWorkflow("workflow.yml", []byte("on: [push"))
+	require.NotNil(t, err)
+}
@@ -0,0 +1,89 @@
+package gitlab_collected
+
+import (
+	"time"
+
+	"github.com/xanzy/go-gitlab"
+)
+
+// Possible values for AccessToken.Type
+const (
+	ProjectAccessToken       = "project"
+	GroupAccessToken         = "group"
+	PersonalAccessToken      = "personal"
+	ImpersonationAccessToken = "impersonation"
+)
+
+// AccessToken is a project, group, personal or impersonation access token. The token itself is deliberately not collected.
+type AccessToken struct {
+	ID     int      `json:"id"`
+	Name   string   `json:"name"`
+	Type   string   `json:"type"`
+	Scopes []string `json:"scopes"`
+	// AccessLevel is the role of the token's bot user, for project and group access tokens
+	AccessLevel gitlab.AccessLevelValue `json:"access_level,omitempty"`
+	CreatedAt   *time.Time              `json:"created_at"`
+	LastUsedAt  *time.Time              `json:"last_used_at"`
+	// ExpiresAt is nil if the token never expires
+	ExpiresAt *gitlab.ISOTime `json:"expires_at"`
+	Active    bool            `json:"active"`
+	Revoked   bool            `json:"revoked"`
+}
+
+func NewProjectAccessToken(token *gitlab.ProjectAccessToken) *AccessToken {
+	return &AccessToken{
+		ID:          token.ID,
+		Name:        token.Name,
+		Type:        ProjectAccessToken,
+		Scopes:      token.Scopes,
+		AccessLevel: token.AccessLevel,
+		CreatedAt:   token.CreatedAt,
+		LastUsedAt:  token.LastUsedAt,
+		ExpiresAt:   token.ExpiresAt,
+		Active:      token.Active,
+		Revoked:     token.Revoked,
+	}
+}
+
+func NewGroupAccessToken(token *gitlab.GroupAccessToken) *AccessToken {
+	return &AccessToken{
+		ID:          token.ID,
+		Name:        token.Name,
+		Type:        GroupAccessToken,
+		Scopes:      token.Scopes,
+		AccessLevel: token.AccessLevel,
+		CreatedAt:   token.CreatedAt,
+		LastUsedAt:  token.LastUsedAt,
+		ExpiresAt:   token.ExpiresAt,
+		Active:      token.Active,
+		Revoked:     token.Revoked,
+	}
+}
+
+func NewPersonalAccessToken(token *gitlab.PersonalAccessToken) *AccessToken {
+	return &AccessToken{
+		ID:         token.ID,
+		Name:       token.Name,
+		Type:       PersonalAccessToken,
+		Scopes:     token.Scopes,
+		CreatedAt:  token.CreatedAt,
+		LastUsedAt: token.LastUsedAt,
+		ExpiresAt:  token.ExpiresAt,
+		Active:     token.Active,
+		Revoked:    token.Revoked,
+	}
+}
+
+// NewImpersonationToken converts an impersonation token, whose last usage is not available from the API
+func NewImpersonationToken(token *gitlab.ImpersonationToken) *AccessToken {
+	return &AccessToken{
+		ID:        token.ID,
+		Name:      token.Name,
+		Type:      ImpersonationAccessToken,
+		Scopes:    token.Scopes,
+		CreatedAt: token.CreatedAt,
+		ExpiresAt: token.ExpiresAt,
+		Active:    token.Active,
+		Revoked:   token.Revoked,
+	}
+}
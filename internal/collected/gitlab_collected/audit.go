@@ -0,0 +1,30 @@
+package gitlab_collected
+
+import (
+	"github.com/Legit-Labs/legitify/internal/common/namespace"
+	"github.com/xanzy/go-gitlab"
+)
+
+// AuditLog is the audit events of a group since the start of the analyzed time window
+type AuditLog struct {
+	Group *gitlab.Group `json:"group"`
+	// Since is the start of the analyzed time window, in RFC3339
+	Since  string               `json:"since"`
+	Events []*gitlab.AuditEvent `json:"events"`
+}
+
+func (a AuditLog) ViolationEntityType() string {
+	return namespace.Audit
+}
+
+func (a AuditLog) CanonicalLink() string {
+	return a.Group.WebURL + "/-/audit_events"
+}
+
+func (a AuditLog) Name() string {
+	return a.Group.FullPath
+}
+
+func (a AuditLog) ID() int64 {
+	return int64(a.Group.ID)
+}
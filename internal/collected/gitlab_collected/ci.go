@@ -0,0 +1,63 @@
+package gitlab_collected
+
+import (
+	"github.com/xanzy/go-gitlab"
+)
+
+// CIVariable is a CI/CD variable of a project or a group. Its value is deliberately not collected.
+type CIVariable struct {
+	Key              string                   `json:"key"`
+	VariableType     gitlab.VariableTypeValue `json:"variable_type"`
+	Protected        bool                     `json:"protected"`
+	Masked           bool                     `json:"masked"`
+	Raw              bool                     `json:"raw"`
+	EnvironmentScope string                   `json:"environment_scope"`
+}
+
+// CIRunner is a runner available to a project or a group, including its configuration details
+type CIRunner struct {
+	ID          int    `json:"id"`
+	Description string `json:"description"`
+	// Possible values for RunnerType are: instance_type, group_type, project_type
+	RunnerType  string   `json:"runner_type"`
+	IsShared    bool     `json:"is_shared"`
+	Paused      bool     `json:"paused"`
+	Locked      bool     `json:"locked"`
+	RunUntagged bool     `json:"run_untagged"`
+	Tags        []string `json:"tags"`
+	// Possible values for AccessLevel are: not_protected, ref_protected
+	AccessLevel string `json:"access_level"`
+}
+
+func NewCIVariable(key string, variableType gitlab.VariableTypeValue, protected bool, masked bool, raw bool, environmentScope string) *CIVariable {
+	return &CIVariable{
+		Key:              key,
+		VariableType:     variableType,
+		Protected:        protected,
+		Masked:           masked,
+		Raw:              raw,
+		EnvironmentScope: environmentScope,
+	}
+}
+
+// NewCIRunner creates a runner from its summary, and its details if they are available
+func NewCIRunner(runner *gitlab.Runner, details *gitlab.RunnerDetails) *CIRunner {
+	result := &CIRunner{
+		ID:          runner.ID,
+		Description: runner.Description,
+		RunnerType:  runner.RunnerType,
+		IsShared:    runner.IsShared,
+		Paused:      runner.Paused,
+		Tags:        []string{},
+	}
+	if details != nil {
+		result.Locked = details.Locked
+		result.RunUntagged = details.RunUntagged
+		result.AccessLevel = details.AccessLevel
+		if details.TagList != nil {
+			result.Tags = details.TagList
+		}
+	}
+
+	return result
+}
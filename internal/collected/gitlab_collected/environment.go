@@ -0,0 +1,69 @@
+package gitlab_collected
+
+import (
+	"regexp"
+	"strings"
+
+	"github.com/xanzy/go-gitlab"
+)
+
+// Environment is a project environment, and its protection if it is protected
+type Environment struct {
+	Name string `json:"name"`
+	// Possible values for Tier are: production, staging, testing, development, other
+	Tier string `json:"tier"`
+	// Protection is nil if the environment is not protected
+	Protection *ProtectedEnvironment `json:"protection"`
+}
+
+// ProtectedEnvironment is a protected environment, including the approval rules that gitlab.ProtectedEnvironment doesn't have
+type ProtectedEnvironment struct {
+	gitlab.ProtectedEnvironment
+	ApprovalRules []*EnvironmentApprovalRule `json:"approval_rules"`
+}
+
+type EnvironmentApprovalRule struct {
+	ID                     int                     `json:"id"`
+	UserID                 int                     `json:"user_id"`
+	GroupID                int                     `json:"group_id"`
+	AccessLevel            gitlab.AccessLevelValue `json:"access_level"`
+	AccessLevelDescription string                  `json:"access_level_description"`
+	RequiredApprovals      int                     `json:"required_approvals"`
+}
+
+// NewEnvironment matches the environment with its protection.
+// A protected environment may be a wildcard (e.g. review/*) that protects multiple environments.
+// Environments that the project doesn't protect are matched by their tier with the protected environments of
+// its groups (groupProtected), ordered from the nearest group.
+func NewEnvironment(environment *gitlab.Environment, protected []*ProtectedEnvironment, groupProtected []*ProtectedEnvironment) *Environment {
+	result := &Environment{
+		Name: environment.Name,
+		Tier: environment.Tier,
+	}
+	for _, p := range protected {
+		if p.Name == environment.Name {
+			result.Protection = p
+			return result
+		}
+	}
+	for _, p := range protected {
+		if strings.Contains(p.Name, "*") && wildcardMatches(p.Name, environment.Name) {
+			result.Protection = p
+			return result
+		}
+	}
+	for _, p := range groupProtected {
+		if p.Name == environment.Tier {
+			result.Protection = p
+			return result
+		}
+	}
+
+	return result
+}
+
+func wildcardMatches(pattern string, name string) bool {
+	expression := "^" + strings.ReplaceAll(regexp.QuoteMeta(pattern), `\*`, ".*") + "$"
+	matched, err := regexp.MatchString(expression, name)
+	return err == nil && matched
+}
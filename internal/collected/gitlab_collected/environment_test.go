@@ -0,0 +1,41 @@
+package gitlab_collected_test
+
+import (
+	"testing"
+
+	"github.com/Legit-Labs/legitify/internal/collected/gitlab_collected"
+	"github.com/stretchr/testify/require"
+	"github.com/xanzy/go-gitlab"
+)
+
+func TestNewEnvironment(t *testing.T) {
+	protect := func(name string) *gitlab_collected.ProtectedEnvironment {
+		return &gitlab_collected.ProtectedEnvironment{ProtectedEnvironment: gitlab.ProtectedEnvironment{Name: name}}
+	}
+	protected := []*gitlab_collected.ProtectedEnvironment{protect("review/*"), protect("production"), protect("*")}
+
+	environment := gitlab_collected.NewEnvironment(&gitlab.Environment{Name: "production", Tier: "production"}, protected, nil)
+	require.Equal(t, "production", environment.Protection.Name)
+
+	environment = gitlab_collected.NewEnvironment(&gitlab.Environment{Name: "review/feature", Tier: "development"}, protected, nil)
+	require.Equal(t, "review/*", environment.Protection.Name)
+
+	environment = gitlab_collected.NewEnvironment(&gitlab.Environment{Name: "staging"}, protected[:2], nil)
+	require.Nil(t, environment.Protection)
+}
+
+func TestNewEnvironmentGroupProtection(t *testing.T) {
+	protect := func(name string, approvals int) *gitlab_collected.ProtectedEnvironment {
+		return &gitlab_collected.ProtectedEnvironment{ProtectedEnvironment: gitlab.ProtectedEnvironment{Name: name, RequiredApprovalCount: approvals}}
+	}
+	groupProtected := []*gitlab_collected.ProtectedEnvironment{protect("production", 1), protect("production", 2), protect("staging", 0)}
+
+	environment := gitlab_collected.NewEnvironment(&gitlab.Environment{Name: "prod-eu", Tier: "production"}, nil, groupProtected)
+	require.Equal(t, 1, environment.Protection.RequiredApprovalCount)
+
+	environment = gitlab_collected.NewEnvironment(&gitlab.Environment{Name: "prod-eu", Tier: "production"}, []*gitlab_collected.ProtectedEnvironment{protect("prod-eu", 3)}, groupProtected)
+	require.Equal(t, 3, environment.Protection.RequiredApprovalCount)
+
+	environment = gitlab_collected.NewEnvironment(&gitlab.Environment{Name: "test", Tier: "testing"}, nil, groupProtected)
+	require.Nil(t, environment.Protection)
+}
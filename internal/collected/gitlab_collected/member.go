@@ -7,6 +7,8 @@ import (
 
 type Member struct {
 	*gitlab.User
+	// AccessTokens are the personal access tokens and impersonation tokens of the user, which only admins can list
+	AccessTokens []*AccessToken `json:"access_tokens"`
 }
 
 func (o Member) ViolationEntityType() string {
@@ -0,0 +1,169 @@
+package gitlab_collected
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/xanzy/go-gitlab"
+)
+
+// Possible values for MembershipGrant.Source
+const (
+	MembershipDirect    = "direct"
+	MembershipInherited = "inherited"
+	MembershipShared    = "shared"
+)
+
+// HierarchyGroup is a group in the hierarchy of a project or a group, with the settings its descendants inherit
+type HierarchyGroup struct {
+	ID                             int    `json:"id"`
+	FullPath                       string `json:"full_path"`
+	RequireTwoFactorAuthentication bool   `json:"require_two_factor_authentication"`
+	TwoFactorGracePeriod           int    `json:"two_factor_grace_period"`
+	PreventForkingOutsideGroup     bool   `json:"prevent_forking_outside_group"`
+	ShareWithGroupLock             bool   `json:"share_with_group_lock"`
+	MembershipLock                 bool   `json:"membership_lock"`
+}
+
+func NewHierarchyGroup(group *gitlab.Group) *HierarchyGroup {
+	return &HierarchyGroup{
+		ID:                             group.ID,
+		FullPath:                       group.FullPath,
+		RequireTwoFactorAuthentication: group.RequireTwoFactorAuth,
+		TwoFactorGracePeriod:           group.TwoFactorGracePeriod,
+		PreventForkingOutsideGroup:     group.PreventForkingOutsideGroup,
+		ShareWithGroupLock:             group.ShareWithGroupLock,
+		MembershipLock:                 group.MembershipLock,
+	}
+}
+
+// HierarchyPaths returns the full paths of the groups in the hierarchy of fullPath, from the top-level group down to fullPath itself
+func HierarchyPaths(fullPath string) []string {
+	parts := strings.Split(fullPath, "/")
+	paths := make([]string, 0, len(parts))
+	for i := range parts {
+		paths = append(paths, strings.Join(parts[:i+1], "/"))
+	}
+
+	return paths
+}
+
+// EffectiveSettings are the settings that apply to a project or a group once the settings of its hierarchy are inherited
+type EffectiveSettings struct {
+	// RequireTwoFactorAuthentication is set if any group in the hierarchy requires two-factor authentication
+	RequireTwoFactorAuthentication bool `json:"require_two_factor_authentication"`
+	// TwoFactorGracePeriod is the shortest grace period of the groups that require two-factor authentication
+	TwoFactorGracePeriod int `json:"two_factor_grace_period"`
+	// PreventForkingOutsideGroup can only be set on the top-level group
+	PreventForkingOutsideGroup bool `json:"prevent_forking_outside_group"`
+	ShareWithGroupLock         bool `json:"share_with_group_lock"`
+	MembershipLock             bool `json:"membership_lock"`
+}
+
+// NewEffectiveSettings resolves the settings of a hierarchy that is ordered from the top-level group down
+func NewEffectiveSettings(hierarchy []*HierarchyGroup) *EffectiveSettings {
+	settings := &EffectiveSettings{}
+	if len(hierarchy) == 0 {
+		return settings
+	}
+
+	settings.PreventForkingOutsideGroup = hierarchy[0].PreventForkingOutsideGroup
+	for _, group := range hierarchy {
+		if group.RequireTwoFactorAuthentication {
+			if !settings.RequireTwoFactorAuthentication || group.TwoFactorGracePeriod < settings.TwoFactorGracePeriod {
+				settings.TwoFactorGracePeriod = group.TwoFactorGracePeriod
+			}
+			settings.RequireTwoFactorAuthentication = true
+		}
+		settings.ShareWithGroupLock = settings.ShareWithGroupLock || group.ShareWithGroupLock
+		settings.MembershipLock = settings.MembershipLock || group.MembershipLock
+	}
+
+	return settings
+}
+
+// EffectiveMember is a user with access to a project or a group, and all the ways the access was granted
+type EffectiveMember struct {
+	ID       int    `json:"id"`
+	Username string `json:"username"`
+	Name     string `json:"name"`
+	State    string `json:"state"`
+	// AccessLevel is the highest access level among the grants
+	AccessLevel gitlab.AccessLevelValue `json:"access_level"`
+	Grants      []*MembershipGrant      `json:"grants"`
+}
+
+type MembershipGrant struct {
+	Source string `json:"source"`
+	// Path is the full path of the project or group that the user is a member of
+	Path        string                  `json:"path"`
+	AccessLevel gitlab.AccessLevelValue `json:"access_level"`
+}
+
+// EffectiveMembership accumulates the grants of the users until their effective members are resolved
+type EffectiveMembership struct {
+	members map[int]*EffectiveMember
+}
+
+func NewEffectiveMembership() *EffectiveMembership {
+	return &EffectiveMembership{
+		members: make(map[int]*EffectiveMember),
+	}
+}
+
+func (m *EffectiveMembership) AddProjectMembers(path string, members []*gitlab.ProjectMember) {
+	for _, member := range members {
+		m.add(member.ID, member.Username, member.Name, member.State, &MembershipGrant{
+			Source:      MembershipDirect,
+			Path:        path,
+			AccessLevel: member.AccessLevel,
+		})
+	}
+}
+
+// AddGroupMembers adds the members of the group in path.
+// The access of members of a shared group is limited to maxAccessLevel, the access level the group was shared with.
+func (m *EffectiveMembership) AddGroupMembers(source string, path string, members []*gitlab.GroupMember, maxAccessLevel gitlab.AccessLevelValue) {
+	for _, member := range members {
+		accessLevel := member.AccessLevel
+		if accessLevel > maxAccessLevel {
+			accessLevel = maxAccessLevel
+		}
+		m.add(member.ID, member.Username, member.Name, member.State, &MembershipGrant{
+			Source:      source,
+			Path:        path,
+			AccessLevel: accessLevel,
+		})
+	}
+}
+
+func (m *EffectiveMembership) add(id int, username string, name string, state string, grant *MembershipGrant) {
+	member, ok := m.members[id]
+	if !ok {
+		member = &EffectiveMember{
+			ID:       id,
+			Username: username,
+			Name:     name,
+			State:    state,
+		}
+		m.members[id] = member
+	}
+
+	member.Grants = append(member.Grants, grant)
+	if grant.AccessLevel > member.AccessLevel {
+		member.AccessLevel = grant.AccessLevel
+	}
+}
+
+// Members returns the effective members, sorted by their ID
+func (m *EffectiveMembership) Members() []*EffectiveMember {
+	members := make([]*EffectiveMember, 0, len(m.members))
+	for _, member := range m.members {
+		members = append(members, member)
+	}
+	sort.Slice(members, func(i, j int) bool {
+		return members[i].ID < members[j].ID
+	})
+
+	return members
+}
@@ -0,0 +1,54 @@
+package gitlab_collected_test
+
+import (
+	"testing"
+
+	"github.com/Legit-Labs/legitify/internal/collected/gitlab_collected"
+	"github.com/stretchr/testify/require"
+	"github.com/xanzy/go-gitlab"
+)
+
+func TestHierarchyPaths(t *testing.T) {
+	require.Equal(t, []string{"a", "a/b", "a/b/c"}, gitlab_collected.HierarchyPaths("a/b/c"))
+	require.Equal(t, []string{"a"}, gitlab_collected.HierarchyPaths("a"))
+}
+
+func TestNewEffectiveSettings(t *testing.T) {
+	settings := gitlab_collected.NewEffectiveSettings([]*gitlab_collected.HierarchyGroup{
+		{FullPath: "a", PreventForkingOutsideGroup: true, RequireTwoFactorAuthentication: true, TwoFactorGracePeriod: 48},
+		{FullPath: "a/b", RequireTwoFactorAuthentication: true, TwoFactorGracePeriod: 24},
+		{FullPath: "a/b/c", TwoFactorGracePeriod: 500, ShareWithGroupLock: true},
+	})
+	require.Equal(t, &gitlab_collected.EffectiveSettings{
+		RequireTwoFactorAuthentication: true,
+		TwoFactorGracePeriod:           24,
+		PreventForkingOutsideGroup:     true,
+		ShareWithGroupLock:             true,
+	}, settings)
+
+	require.Equal(t, &gitlab_collected.EffectiveSettings{}, gitlab_collected.NewEffectiveSettings(nil))
+}
+
+func TestEffectiveMembership(t *testing.T) {
+	membership := gitlab_collected.NewEffectiveMembership()
+	membership.AddProjectMembers("a/b/project", []*gitlab.ProjectMember{{ID: 2, Username: "dev", AccessLevel: gitlab.DeveloperPermissions}})
+	membership.AddGroupMembers(gitlab_collected.MembershipInherited, "a", []*gitlab.GroupMember{{ID: 1, Username: "owner", AccessLevel: gitlab.OwnerPermissions}}, gitlab.OwnerPermissions)
+	membership.AddGroupMembers(gitlab_collected.MembershipShared, "other", []*gitlab.GroupMember{
+		{ID: 1, Username: "owner", AccessLevel: gitlab.OwnerPermissions},
+		{ID: 2, Username: "dev", AccessLevel: gitlab.OwnerPermissions},
+	}, gitlab.MaintainerPermissions)
+
+	members := membership.Members()
+	require.Len(t, members, 2)
+
+	require.Equal(t, "owner", members[0].Username)
+	require.Equal(t, gitlab.OwnerPermissions, members[0].AccessLevel)
+	require.Len(t, members[0].Grants, 2)
+
+	require.Equal(t, "dev", members[1].Username)
+	require.Equal(t, gitlab.MaintainerPermissions, members[1].AccessLevel)
+	require.Equal(t, []*gitlab_collected.MembershipGrant{
+		{Source: gitlab_collected.MembershipDirect, Path: "a/b/project", AccessLevel: gitlab.DeveloperPermissions},
+		{Source: gitlab_collected.MembershipShared, Path: "other", AccessLevel: gitlab.MaintainerPermissions},
+	}, members[1].Grants)
+}
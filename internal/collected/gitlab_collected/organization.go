@@ -7,7 +7,14 @@ import (
 
 type Organization struct {
 	*gitlab.Group
-	Hooks []*gitlab.GroupHook `json:"hooks"`
+	Hooks             []*gitlab.GroupHook   `json:"hooks"`
+	Variables         []*CIVariable         `json:"variables"`
+	Runners           []*CIRunner           `json:"runners"`
+	DeployTokens      []*gitlab.DeployToken `json:"deploy_tokens"`
+	AccessTokens      []*AccessToken        `json:"access_tokens"`
+	Hierarchy         []*HierarchyGroup     `json:"hierarchy"`
+	EffectiveSettings *EffectiveSettings    `json:"effective_settings"`
+	EffectiveMembers  []*EffectiveMember    `json:"effective_members"`
 }
 
 func (o Organization) ViolationEntityType() string {
@@ -2,6 +2,7 @@ package gitlab_collected
 
 import (
 	"github.com/Legit-Labs/legitify/internal/common/namespace"
+	"github.com/Legit-Labs/legitify/internal/common/repo_filter"
 	gitlab2 "github.com/xanzy/go-gitlab"
 )
 
@@ -14,6 +15,16 @@ type Repository struct {
 	ApprovalConfiguration    *gitlab2.ProjectApprovals      `json:"approval_configuration"`
 	ApprovalRules            []*gitlab2.ProjectApprovalRule `json:"approval_rules"`
 	MinimumRequiredApprovals int                            `json:"minimum_required_approvals"`
+	Variables                []*CIVariable                  `json:"variables"`
+	Runners                  []*CIRunner                    `json:"runners"`
+	DeployTokens             []*gitlab2.DeployToken         `json:"deploy_tokens"`
+	DeployKeys               []*gitlab2.ProjectDeployKey    `json:"deploy_keys"`
+	AccessTokens             []*AccessToken                 `json:"access_tokens"`
+	ProtectedTags            []*gitlab2.ProtectedTag        `json:"protected_tags"`
+	Environments             []*Environment                 `json:"environments"`
+	Hierarchy                []*HierarchyGroup              `json:"hierarchy"`
+	EffectiveSettings        *EffectiveSettings             `json:"effective_settings"`
+	EffectiveMembers         []*EffectiveMember             `json:"effective_members"`
 }
 
 func (r Repository) ViolationEntityType() string {
@@ -31,3 +42,21 @@ func (r Repository) Name() string {
 func (r Repository) ID() int64 {
 	return int64(r.Project.ID)
 }
+
+func (r Repository) IsArchived() bool {
+	return r.Project != nil && r.Project.Archived
+}
+
+// Classification returns the project metadata that the repository filter and the policy applicability are matched against.
+// The language of a project requires an additional API call, so it is left out.
+func (r Repository) Classification() repo_filter.Repository {
+	if r.Project == nil {
+		return repo_filter.Repository{}
+	}
+
+	return repo_filter.Repository{
+		Name:       r.Project.Path,
+		Visibility: string(r.Project.Visibility),
+		Topics:     r.Project.Topics,
+	}
+}
@@ -8,12 +8,25 @@ import (
 type Server struct {
 	url string
 	*gitlab.Settings
+	// Admins are the users with administrator access to the instance
+	Admins []*gitlab.User `json:"admins"`
+	// AuditEventStreamingDestinations is nil if the destinations couldn't be collected
+	AuditEventStreamingDestinations []*AuditEventStreamingDestination `json:"audit_event_streaming_destinations"`
 }
 
-func NewServer(url string, settings *gitlab.Settings) *Server {
+// AuditEventStreamingDestination is an external destination that receives all the audit events of the instance
+type AuditEventStreamingDestination struct {
+	ID             string `json:"id"`
+	Name           string `json:"name"`
+	DestinationURL string `json:"destination_url"`
+}
+
+func NewServer(url string, settings *gitlab.Settings, admins []*gitlab.User, destinations []*AuditEventStreamingDestination) *Server {
 	return &Server{
-		url:      url,
-		Settings: settings,
+		url:                             url,
+		Settings:                        settings,
+		Admins:                          admins,
+		AuditEventStreamingDestinations: destinations,
 	}
 }
 
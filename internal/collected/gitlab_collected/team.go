@@ -0,0 +1,37 @@
+package gitlab_collected
+
+import (
+	"github.com/Legit-Labs/legitify/internal/common/namespace"
+	"github.com/xanzy/go-gitlab"
+)
+
+// Team is a group analyzed as a team: its members, and the projects and groups it was shared with
+type Team struct {
+	*gitlab.Group
+	Members        []*gitlab.GroupMember `json:"members"`
+	SharedProjects []*SharedWith         `json:"shared_projects"`
+	SharedGroups   []*SharedWith         `json:"shared_groups"`
+}
+
+// SharedWith is a project or a group that grants access to the members of a group that it was shared with
+type SharedWith struct {
+	ID          int                     `json:"id"`
+	FullPath    string                  `json:"full_path"`
+	AccessLevel gitlab.AccessLevelValue `json:"access_level"`
+}
+
+func (o Team) ViolationEntityType() string {
+	return namespace.Team
+}
+
+func (o Team) CanonicalLink() string {
+	return o.WebURL
+}
+
+func (o Team) Name() string {
+	return o.FullPath
+}
+
+func (o Team) ID() int64 {
+	return int64(o.Group.ID)
+}
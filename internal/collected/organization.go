@@ -0,0 +1,67 @@
+package collected
+
+import (
+	"strings"
+
+	githubcollected "github.com/Legit-Labs/legitify/internal/collected/github"
+	"github.com/Legit-Labs/legitify/internal/collected/gitlab_collected"
+)
+
+// OrganizationOf returns the organization (GitHub) or group (GitLab) the entity belongs to, if any.
+// An enterprise belongs to itself.
+func OrganizationOf(entity Entity) string {
+	switch t := entity.(type) {
+	case githubcollected.Enterprise:
+		return t.Name()
+	case githubcollected.Organization:
+		return t.Name()
+	case githubcollected.OrganizationActions:
+		return t.Name()
+	case githubcollected.OrganizationMembers:
+		return t.Name()
+	case githubcollected.RunnerGroup:
+		return t.Organization.Name()
+	case githubcollected.OrganizationApps:
+		return t.Name()
+	case githubcollected.Team:
+		return t.Organization.Name()
+	case githubcollected.Runner:
+		return t.Organization.Name()
+	case githubcollected.AuditLog:
+		if t.Organization == nil {
+			return ""
+		}
+		return t.Organization.Name()
+	case githubcollected.Repository:
+		if t.Repository == nil {
+			return ""
+		}
+		return t.Repository.Owner()
+	case githubcollected.Branch:
+		return t.Owner
+	case gitlab_collected.Organization:
+		if t.Group == nil {
+			return ""
+		}
+		return t.FullPath
+	case gitlab_collected.Repository:
+		if t.Project == nil || t.Namespace == nil {
+			return ""
+		}
+		return t.Namespace.FullPath
+	case gitlab_collected.Team:
+		if t.Group == nil {
+			return ""
+		}
+		top, _, _ := strings.Cut(t.FullPath, "/")
+		return top
+	case gitlab_collected.AuditLog:
+		if t.Group == nil {
+			return ""
+		}
+		top, _, _ := strings.Cut(t.Group.FullPath, "/")
+		return top
+	}
+
+	return ""
+}
@@ -0,0 +1,44 @@
+package collected_test
+
+import (
+	"testing"
+
+	"github.com/Legit-Labs/legitify/internal/collected"
+	githubcollected "github.com/Legit-Labs/legitify/internal/collected/github"
+	"github.com/Legit-Labs/legitify/internal/collected/gitlab_collected"
+	"github.com/stretchr/testify/require"
+	"github.com/xanzy/go-gitlab"
+)
+
+func TestOrganizationOf(t *testing.T) {
+	tests := []struct {
+		name     string
+		entity   collected.Entity
+		expected string
+	}{
+		{
+			name:     "enterprise",
+			entity:   githubcollected.Enterprise{EnterpriseName: "ent"},
+			expected: "ent",
+		},
+		{
+			name:     "github repository",
+			entity:   githubcollected.Repository{Repository: &githubcollected.GitHubQLRepository{Name: "repo", Url: "https://github.com/org/repo"}},
+			expected: "org",
+		},
+		{
+			name:     "gitlab subgroup team",
+			entity:   gitlab_collected.Team{Group: &gitlab.Group{FullPath: "group/team"}},
+			expected: "group",
+		},
+		{
+			name:     "repository without details",
+			entity:   githubcollected.Repository{},
+			expected: "",
+		},
+	}
+
+	for _, test := range tests {
+		require.Equalf(t, test.expected, collected.OrganizationOf(test.entity), test.name)
+	}
+}
@@ -0,0 +1,139 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+
+	ghclient "github.com/Legit-Labs/legitify/internal/clients/github"
+	"github.com/Legit-Labs/legitify/internal/clients/github/pagination"
+	ghcollected "github.com/Legit-Labs/legitify/internal/collected/github"
+	"github.com/Legit-Labs/legitify/internal/collectors"
+	"github.com/Legit-Labs/legitify/internal/common/group_waiter"
+	"github.com/Legit-Labs/legitify/internal/common/namespace"
+	"github.com/Legit-Labs/legitify/internal/common/permissions"
+	"github.com/google/go-github/v53/github"
+)
+
+const (
+	orgAppsPermEffect = "Cannot read organization app installations"
+)
+
+type appsCollector struct {
+	collectors.BaseCollector
+	client  *ghclient.Client
+	context context.Context
+}
+
+func NewAppsCollector(ctx context.Context, client *ghclient.Client) collectors.Collector {
+	c := &appsCollector{
+		BaseCollector: collectors.NewBaseCollector(namespace.Apps),
+		client:        client,
+		context:       ctx,
+	}
+	return c
+}
+
+func (c *appsCollector) CollectTotalEntities() int {
+	orgs, err := c.client.CollectOrganizations()
+	if err != nil {
+		log.Printf("failed to collect organizations %s", err)
+		return 0
+	}
+
+	return len(orgs)
+}
+
+func (c *appsCollector) Collect() collectors.SubCollectorChannels {
+	return c.WrappedCollection(func() {
+		orgs, err := c.client.CollectOrganizations()
+
+		if err != nil {
+			log.Printf("failed to collect organizations %s", err)
+			return
+		}
+
+		gw := group_waiter.New()
+		for _, org := range orgs {
+			org := org
+			gw.Do(func() {
+				defer c.CollectionChangeByOne()
+
+				installations, err := c.collectInstallations(org.Name())
+				if err != nil {
+					entityName := fmt.Sprintf("%s/%s", namespace.Organization, org.Name())
+					perm := collectors.NewMissingPermission(permissions.OrgAdmin, entityName, orgAppsPermEffect, namespace.Organization)
+					c.IssueMissingPermissions(perm)
+					return
+				}
+
+				restrictions, err := c.client.GetOAuthAppRestrictions(org.Name())
+				if err != nil {
+					log.Printf("failed to collect the oauth app restrictions of %s from the audit log: %s", org.Name(), err)
+				}
+
+				c.CollectData(org,
+					ghcollected.OrganizationApps{
+						Organization:         org,
+						Installations:        installations,
+						OAuthAppRestrictions: restrictions,
+					},
+					org.CanonicalLink(),
+					[]permissions.Role{org.Role})
+			})
+		}
+		gw.Wait()
+	})
+}
+
+func (c *appsCollector) collectInstallations(org string) ([]*ghcollected.AppInstallation, error) {
+	mapper := func(resp *github.OrganizationInstallations) []*github.Installation {
+		if resp == nil {
+			return []*github.Installation{}
+		}
+		return resp.Installations
+	}
+	res, err := pagination.NewMapper(c.client.Client().Organizations.ListInstallations, &github.ListOptions{}, mapper).Sync(c.context, org)
+	if err != nil {
+		return nil, err
+	}
+
+	installations := []*ghcollected.AppInstallation{}
+	for _, i := range res.Collected {
+		installation := &ghcollected.AppInstallation{
+			ID:                  i.GetID(),
+			AppID:               i.GetAppID(),
+			AppSlug:             i.GetAppSlug(),
+			RepositorySelection: i.GetRepositorySelection(),
+			Permissions:         installationPermissions(i.Permissions),
+			Events:              i.Events,
+			CreatedAt:           int(i.GetCreatedAt().UnixNano()),
+			Suspended:           i.SuspendedAt != nil,
+		}
+		if installation.Events == nil {
+			installation.Events = []string{}
+		}
+		installations = append(installations, installation)
+	}
+
+	return installations, nil
+}
+
+// installationPermissions maps each permission of the installation to its access level (read, write or admin)
+func installationPermissions(perms *github.InstallationPermissions) map[string]string {
+	result := map[string]string{}
+	if perms == nil {
+		return result
+	}
+
+	raw, err := json.Marshal(perms)
+	if err != nil {
+		return result
+	}
+	if err = json.Unmarshal(raw, &result); err != nil {
+		log.Printf("failed to parse the app installation permissions: %s", err)
+	}
+
+	return result
+}
@@ -0,0 +1,184 @@
+package github
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"sort"
+	"sync"
+	"time"
+
+	ghclient "github.com/Legit-Labs/legitify/internal/clients/github"
+	ghcollected "github.com/Legit-Labs/legitify/internal/collected/github"
+	"github.com/Legit-Labs/legitify/internal/collectors"
+	"github.com/Legit-Labs/legitify/internal/common/group_waiter"
+	"github.com/Legit-Labs/legitify/internal/common/namespace"
+	"github.com/Legit-Labs/legitify/internal/common/permissions"
+	"github.com/Legit-Labs/legitify/internal/context_utils"
+	"github.com/google/go-github/v53/github"
+)
+
+// riskyAuditActions are the audit log actions that the audit policies analyze.
+// The audit log is searched for each of them, instead of reading the whole audit log of the time window.
+var riskyAuditActions = []string{
+	"protected_branch.destroy",
+	"protected_branch.policy_override",
+	"repository_ruleset.destroy",
+	"repo.access",
+	"org.add_member",
+	"org.update_member",
+	"business.add_admin",
+	"public_key.create",
+	"secret_scanning.disable",
+	"repository_secret_scanning.disable",
+	"repository_secret_scanning_push_protection.disable",
+	"business_secret_scanning.disable",
+	"business_secret_scanning_push_protection.disable",
+	"ip_allow_list.disable",
+	"ip_allow_list.disable_for_installed_apps",
+	"ip_allow_list_entry.create",
+	"ip_allow_list_entry.update",
+	"ip_allow_list_entry.destroy",
+}
+
+const auditLogEffect = "Cannot read the audit log"
+
+type auditCollector struct {
+	collectors.BaseCollector
+	client  *ghclient.Client
+	context context.Context
+	since   time.Time
+}
+
+func NewAuditCollector(ctx context.Context, client *ghclient.Client) collectors.Collector {
+	c := &auditCollector{
+		BaseCollector: collectors.NewBaseCollector(namespace.Audit),
+		client:        client,
+		context:       ctx,
+		since:         context_utils.GetAuditSince(ctx),
+	}
+	return c
+}
+
+func (c *auditCollector) CollectTotalEntities() int {
+	orgs, err := c.client.CollectOrganizations()
+	if err != nil {
+		log.Printf("failed to collect organizations %s", err)
+		return 0
+	}
+	enterprises, err := c.client.CollectEnterprises()
+	if err != nil {
+		log.Printf("failed to collect enterprises %s", err)
+	}
+
+	total := len(enterprises)
+	for _, org := range orgs {
+		if org.Role == permissions.OrgRoleOwner {
+			total++
+		}
+	}
+
+	return total
+}
+
+func (c *auditCollector) Collect() collectors.SubCollectorChannels {
+	return c.WrappedCollection(func() {
+		orgs, err := c.client.CollectOrganizations()
+		if err != nil {
+			log.Printf("failed to collect organizations %s", err)
+			return
+		}
+
+		gw := group_waiter.New()
+		for _, org := range orgs {
+			if org.Role != permissions.OrgRoleOwner {
+				continue
+			}
+			org := org
+			gw.Do(func() {
+				defer c.CollectionChangeByOne()
+				events, err := c.riskyEvents(org.Name(), false)
+				if err != nil {
+					log.Printf("failed to collect the audit log of %s: %s", org.Name(), err)
+					c.IssueMissingPermissions(collectors.NewMissingPermission(permissions.OrgAdmin, org.Name(), auditLogEffect, namespace.Audit))
+					return
+				}
+
+				auditLog := ghcollected.AuditLog{
+					Organization: &org,
+					Since:        c.since.Format(time.RFC3339),
+					Events:       events,
+				}
+				c.CollectData(org, auditLog, auditLog.CanonicalLink(), []permissions.Role{org.Role})
+			})
+		}
+
+		enterprises, err := c.client.CollectEnterprises()
+		if err != nil {
+			log.Printf("failed to collect enterprises %s", err)
+		}
+		for _, enterprise := range enterprises {
+			enterprise := enterprise
+			gw.Do(func() {
+				defer c.CollectionChangeByOne()
+				events, err := c.riskyEvents(enterprise.Slug, true)
+				if err != nil {
+					log.Printf("failed to collect the audit log of enterprise %s: %s", enterprise.Slug, err)
+					c.IssueMissingPermissions(collectors.NewMissingPermission(permissions.EnterpriseAdmin, enterprise.Slug, auditLogEffect, namespace.Audit))
+					return
+				}
+
+				auditLog := ghcollected.AuditLog{
+					Enterprise: &enterprise,
+					Since:      c.since.Format(time.RFC3339),
+					Events:     events,
+				}
+				c.CollectDataWithContext(auditLog, auditLog.CanonicalLink(), newEnterpriseContext([]permissions.Role{enterprise.UserRole}))
+			})
+		}
+
+		gw.Wait()
+	})
+}
+
+// riskyEvents collects the events of the risky actions since the start of the time window, ordered from the newest
+func (c *auditCollector) riskyEvents(owner string, isEnterprise bool) ([]*github.AuditEntry, error) {
+	var lock sync.Mutex
+	var firstErr error
+	events := []*github.AuditEntry{}
+
+	gw := group_waiter.New()
+	for _, action := range riskyAuditActions {
+		action := action
+		phrase := fmt.Sprintf("action:%s created:>=%s", action, c.since.Format("2006-01-02"))
+		gw.Do(func() {
+			entries, err := c.client.GetAuditLog(owner, isEnterprise, phrase)
+
+			lock.Lock()
+			defer lock.Unlock()
+			if err != nil {
+				if firstErr == nil {
+					firstErr = err
+				}
+				return
+			}
+			for _, entry := range entries {
+				// the search is limited to whole days
+				if !entry.GetTimestamp().Before(c.since) {
+					events = append(events, entry)
+				}
+			}
+		})
+	}
+	gw.Wait()
+
+	if firstErr != nil {
+		return nil, firstErr
+	}
+
+	sort.Slice(events, func(i, j int) bool {
+		return events[i].GetTimestamp().After(events[j].GetTimestamp().Time)
+	})
+
+	return events, nil
+}
@@ -0,0 +1,326 @@
+package github
+
+import (
+	"context"
+	"fmt"
+	"log"
+
+	ghclient "github.com/Legit-Labs/legitify/internal/clients/github"
+	ghcollected "github.com/Legit-Labs/legitify/internal/collected/github"
+	"github.com/Legit-Labs/legitify/internal/collectors"
+	"github.com/Legit-Labs/legitify/internal/common/group_waiter"
+	"github.com/Legit-Labs/legitify/internal/common/namespace"
+	"github.com/Legit-Labs/legitify/internal/common/permissions"
+	"github.com/Legit-Labs/legitify/internal/common/types"
+	"github.com/Legit-Labs/legitify/internal/common/utils"
+	"github.com/Legit-Labs/legitify/internal/context_utils"
+	"github.com/shurcooL/githubv4"
+)
+
+type branchCollector struct {
+	collectors.BaseCollector
+	Client           *ghclient.Client
+	Context          context.Context
+	patterns         []string
+	includeUserRepos bool
+	orgRulesets      *organizationRulesets
+	filter           *repositoryFilter
+}
+
+func NewBranchCollector(ctx context.Context, client *ghclient.Client) collectors.Collector {
+	c := &branchCollector{
+		BaseCollector:    collectors.NewBaseCollector(namespace.Branch),
+		Client:           client,
+		Context:          ctx,
+		patterns:         context_utils.GetBranchPatterns(ctx),
+		includeUserRepos: context_utils.GetIncludeUserRepos(ctx),
+		orgRulesets:      newOrganizationRulesets(client),
+		filter:           newRepositoryFilter(ctx, client),
+	}
+	return c
+}
+
+type branchesQuery struct {
+	Repository struct {
+		Refs struct {
+			PageInfo ghcollected.GitHubQLPageInfo
+			Nodes    []ghcollected.GitHubQLBranch
+		} `graphql:"refs(refPrefix: \"refs/heads/\", first: 100, after: $branchCursor)"`
+	} `graphql:"repository(owner: $login, name: $name)"`
+}
+
+// CollectTotalEntities counts the repositories, since the branches are only known once the repository is scanned
+func (c *branchCollector) CollectTotalEntities() int {
+	repositories, exist := context_utils.GetRepositories(c.Context)
+	if exist {
+		return len(repositories)
+	}
+
+	total := countOrganizationRepositories(c.Context, c.Client)
+	if c.includeUserRepos {
+		total += countMembersRepositories(c.Context, c.Client)
+	}
+
+	return total
+}
+
+func (c *branchCollector) Collect() collectors.SubCollectorChannels {
+	repositories, exist := context_utils.GetRepositories(c.Context)
+
+	if exist {
+		return c.collectSpecific(repositories)
+	}
+
+	return c.collectAll()
+}
+
+func (c *branchCollector) collectSpecific(repositories []types.RepositoryWithOwner) collectors.SubCollectorChannels {
+	return c.WrappedCollection(func() {
+		gw := group_waiter.New()
+		for _, r := range repositories {
+			repo := r
+			gw.Do(func() {
+				repository, collectionContext, err := querySpecificRepository(c.Context, c.Client, repo)
+				if err != nil {
+					log.Println(err.Error())
+					return
+				}
+
+				c.collectBranches(repository, repo.Owner, collectionContext)
+			})
+		}
+
+		gw.Wait()
+	})
+}
+
+func (c *branchCollector) collectAll() collectors.SubCollectorChannels {
+	return c.WrappedCollection(func() {
+		orgs, err := c.Client.CollectOrganizations()
+
+		if err != nil {
+			log.Printf("failed to collect organizations %s", err)
+			return
+		}
+
+		gw := group_waiter.New()
+		for _, org := range orgs {
+			localOrg := org
+			gw.Do(func() {
+				_ = utils.Retry(func() (bool, error) {
+					err := c.collectRepositories(&localOrg)
+					return true, err
+				}, 5, fmt.Sprintf("collect branches for %s", *localOrg.Login))
+			})
+		}
+		gw.Wait()
+
+		if c.includeUserRepos {
+			c.collectMembersRepositories(orgs)
+		}
+	})
+}
+
+func (c *branchCollector) collectRepositories(org *ghcollected.ExtendedOrg) error {
+	variables := map[string]interface{}{
+		"login":            githubv4.String(org.Name()),
+		"repositoryCursor": (*githubv4.String)(nil),
+		"isArchived":       isArchivedFilter(context_utils.GetIncludeArchived(c.Context)),
+	}
+
+	gw := group_waiter.New()
+	defer gw.Wait()
+	for {
+		query := repoQuery{}
+		err := c.Client.GraphQLClient().Query(c.Context, &query, variables)
+
+		if err != nil {
+			return err
+		}
+
+		gw.Do(func() {
+			nodes := query.Organization.Repositories.Nodes
+			extraGw := group_waiter.New()
+			for i := range nodes {
+				node := &(nodes[i])
+				extraGw.Do(func() {
+					collectionContext := newRepositoryContext([]permissions.Role{org.Role, node.ViewerPermission},
+						hasBranchProtection(org, node.IsPrivate), org.IsEnterprise(), false, false)
+					c.collectBranches(node, org.Name(), collectionContext)
+				})
+			}
+			extraGw.Wait()
+		})
+
+		if !query.Organization.Repositories.PageInfo.HasNextPage {
+			break
+		}
+
+		variables["repositoryCursor"] = query.Organization.Repositories.PageInfo.EndCursor
+	}
+
+	return nil
+}
+
+// collectMembersRepositories collects the branches of the repositories that the members of the organizations own personally
+func (c *branchCollector) collectMembersRepositories(orgs []ghcollected.ExtendedOrg) {
+	gw := group_waiter.New()
+	for _, login := range membersLogins(c.Context, c.Client, orgs) {
+		login := login
+		gw.Do(func() {
+			_ = utils.Retry(func() (bool, error) {
+				err := c.collectUserRepositories(login)
+				return true, err
+			}, 5, fmt.Sprintf("collect branches of user %s", login))
+		})
+	}
+	gw.Wait()
+}
+
+func (c *branchCollector) collectUserRepositories(login string) error {
+	variables := map[string]interface{}{
+		"login":            githubv4.String(login),
+		"repositoryCursor": (*githubv4.String)(nil),
+		"isArchived":       isArchivedFilter(context_utils.GetIncludeArchived(c.Context)),
+	}
+
+	gw := group_waiter.New()
+	defer gw.Wait()
+	for {
+		query := userRepoQuery{}
+		err := c.Client.GraphQLClient().Query(c.Context, &query, variables)
+
+		if err != nil {
+			return err
+		}
+
+		nodes := query.User.Repositories.Nodes
+		for i := range nodes {
+			node := &(nodes[i])
+			gw.Do(func() {
+				collectionContext := newRepositoryContext([]permissions.Role{node.ViewerPermission},
+					hasBranchProtectionForUser(c.Context, c.Client, login, node.IsPrivate), false, false, false)
+				c.collectBranches(node, login, collectionContext)
+			})
+		}
+
+		if !query.User.Repositories.PageInfo.HasNextPage {
+			break
+		}
+
+		variables["repositoryCursor"] = query.User.Repositories.PageInfo.EndCursor
+	}
+
+	return nil
+}
+
+// collectBranches collects the non-default branches that are either protected or match the branch patterns
+func (c *branchCollector) collectBranches(repository *ghcollected.GitHubQLRepository, login string, repoContext *repositoryContext) {
+	defer c.CollectionChangeByOne()
+
+	if !c.filter.matches(login, repository) {
+		return
+	}
+
+	if !repoContext.IsBranchProtectionSupported() {
+		// the missing permission is already issued by the repository collector
+		return
+	}
+
+	branches, err := c.queryBranches(repository, login)
+	if err != nil {
+		log.Printf("failed to collect branches for %s: %s", collectors.FullRepoName(login, repository.Name), err)
+		return
+	}
+
+	rulesets, missingPermissions := c.orgRulesets.get(login, repoContext.Roles())
+	c.IssueMissingPermissions(missingPermissions...)
+	var target ghcollected.RulesetTarget
+	if len(rulesets) > 0 {
+		target = c.orgRulesets.rulesetTarget(login, repository, rulesets)
+	}
+
+	for _, b := range branches {
+		branch := c.collectBranch(repository, login, b)
+		if len(rulesets) > 0 {
+			target.Branch = *b.Name
+			branch.RulesSet = ghcollected.EffectiveRules(branch.RulesSet, target, rulesets)
+		}
+		entityName := collectors.FullRepoName(login, branch.Name())
+		if branch.NoBranchProtectionPermission {
+			perm := collectors.NewMissingPermission(permissions.RepoAdmin, entityName,
+				"Cannot read branch protection information", namespace.Branch)
+			c.IssueMissingPermissions(perm)
+		}
+
+		branchContext := newRepositoryContext(repoContext.Roles(), true, repoContext.Premium(),
+			!branch.NoBranchProtectionPermission, false)
+		c.CollectDataWithContext(branch, branch.CanonicalLink(), branchContext)
+	}
+}
+
+func (c *branchCollector) queryBranches(repository *ghcollected.GitHubQLRepository, login string) ([]ghcollected.GitHubQLBranch, error) {
+	var defaultBranch string
+	if repository.DefaultBranchRef != nil && repository.DefaultBranchRef.Name != nil {
+		defaultBranch = *repository.DefaultBranchRef.Name
+	}
+
+	variables := map[string]interface{}{
+		"login":        githubv4.String(login),
+		"name":         githubv4.String(repository.Name),
+		"branchCursor": (*githubv4.String)(nil),
+	}
+
+	var result []ghcollected.GitHubQLBranch
+	for {
+		query := branchesQuery{}
+		err := c.Client.GraphQLClient().Query(c.Context, &query, variables)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, branch := range query.Repository.Refs.Nodes {
+			if branch.Name == nil || *branch.Name == defaultBranch {
+				continue
+			}
+			if branch.BranchProtectionRule == nil && len(ghcollected.MatchBranchPatterns(*branch.Name, c.patterns)) == 0 {
+				continue
+			}
+			result = append(result, branch)
+		}
+
+		if !query.Repository.Refs.PageInfo.HasNextPage {
+			break
+		}
+
+		variables["branchCursor"] = query.Repository.Refs.PageInfo.EndCursor
+	}
+
+	return result, nil
+}
+
+func (c *branchCollector) collectBranch(repository *ghcollected.GitHubQLRepository, login string, b ghcollected.GitHubQLBranch) ghcollected.Branch {
+	branch := ghcollected.Branch{
+		Owner:           login,
+		Repository:      repository,
+		Branch:          &b,
+		MatchedPatterns: ghcollected.MatchBranchPatterns(*b.Name, c.patterns),
+	}
+
+	if b.BranchProtectionRule == nil {
+		noPermission, err := checkBranchProtectionPermission(c.Context, c.Client, login, repository.Name, *b.Name)
+		if err != nil {
+			// If we can't get branch protection info, rego will ignore it (as nil)
+			log.Printf("error getting branch protection info for %s: %s", branch.Name(), err)
+		}
+		branch.NoBranchProtectionPermission = noPermission
+	}
+
+	rules, err := c.Client.GetRulesForBranch(login, repository.Name, *b.Name)
+	if err != nil {
+		log.Printf("error getting rules set for %s: %s", branch.Name(), err)
+	}
+	branch.RulesSet = rules
+
+	return branch
+}
@@ -0,0 +1,164 @@
+package github
+
+import (
+	"fmt"
+	"log"
+
+	"github.com/Legit-Labs/legitify/internal/clients/github/pagination"
+	ghcollected "github.com/Legit-Labs/legitify/internal/collected/github"
+	"github.com/Legit-Labs/legitify/internal/collectors"
+	"github.com/Legit-Labs/legitify/internal/common/namespace"
+	"github.com/Legit-Labs/legitify/internal/common/permissions"
+	"github.com/google/go-github/v53/github"
+	"github.com/shurcooL/githubv4"
+)
+
+const (
+	orgMemberTwoFactorEffect  = "Cannot read the two-factor authentication status of organization members"
+	orgMemberIdentitiesEffect = "Cannot read the SAML SSO identities of organization members"
+)
+
+type externalIdentitiesQuery struct {
+	Organization struct {
+		SamlIdentityProvider *struct {
+			ExternalIdentities struct {
+				PageInfo ghcollected.GitHubQLPageInfo
+				Nodes    []struct {
+					Guid string
+					User *struct {
+						Login string
+					}
+					SamlIdentity *struct {
+						NameId string
+					}
+					ScimIdentity *struct {
+						Username string
+					}
+				}
+			} `graphql:"externalIdentities(first: 100, after: $cursor)"`
+		}
+	} `graphql:"organization(login: $login)"`
+}
+
+// withIdentities sets the two-factor authentication status and the external identity of the members.
+// It returns whether the two-factor authentication status could be read,
+// and whether the organization has a SAML identity provider, or nil if the identities could not be read.
+func (c *memberCollector) withIdentities(org *ghcollected.ExtendedOrg, members []ghcollected.OrganizationMember) (hasTwoFactorStatus bool, hasSamlSso *bool) {
+	twoFactorDisabled, err := c.twoFactorDisabledLogins(org.Name())
+	if err != nil {
+		log.Printf("error collecting members without two-factor authentication for org %s: %s\n", org.Name(), err)
+		c.IssueMissingPermissions(collectors.NewMissingPermission(permissions.OrgAdmin, org.Name(), orgMemberTwoFactorEffect, namespace.Member))
+	} else {
+		hasTwoFactorStatus = true
+		for i := range members {
+			members[i].TwoFactorDisabled = github.Bool(twoFactorDisabled[members[i].User.GetLogin()])
+		}
+	}
+
+	identities, samlSso, err := c.externalIdentities(org.Name())
+	if err != nil {
+		log.Printf("error collecting external identities for org %s: %s\n", org.Name(), err)
+		c.IssueMissingPermissions(collectors.NewMissingPermission(permissions.OrgAdmin, org.Name(), orgMemberIdentitiesEffect, namespace.Member))
+		return hasTwoFactorStatus, nil
+	}
+	for i := range members {
+		members[i].ExternalIdentity = identities[members[i].User.GetLogin()]
+	}
+
+	return hasTwoFactorStatus, github.Bool(samlSso)
+}
+
+func (c *memberCollector) twoFactorDisabledLogins(org string) (map[string]bool, error) {
+	opts := &github.ListMembersOptions{Filter: "2fa_disabled"}
+	res, err := pagination.New[*github.User](c.Client.Client().Organizations.ListMembers, opts).Sync(c.Context, org)
+	if err != nil {
+		return nil, err
+	}
+
+	logins := make(map[string]bool, len(res.Collected))
+	for _, user := range res.Collected {
+		logins[user.GetLogin()] = true
+	}
+
+	return logins, nil
+}
+
+// externalIdentities returns the external identities of the organization by the login of their linked user
+func (c *memberCollector) externalIdentities(org string) (map[string]*ghcollected.ExternalIdentity, bool, error) {
+	variables := map[string]interface{}{
+		"login":  githubv4.String(org),
+		"cursor": (*githubv4.String)(nil),
+	}
+
+	identities := make(map[string]*ghcollected.ExternalIdentity)
+	for {
+		query := externalIdentitiesQuery{}
+		if err := c.Client.GraphQLClient().Query(c.Context, &query, variables); err != nil {
+			return nil, false, err
+		}
+
+		provider := query.Organization.SamlIdentityProvider
+		if provider == nil {
+			return identities, false, nil
+		}
+
+		for _, node := range provider.ExternalIdentities.Nodes {
+			if node.User == nil {
+				// the identity is not linked to a GitHub account yet
+				continue
+			}
+			identity := &ghcollected.ExternalIdentity{Guid: node.Guid}
+			if node.SamlIdentity != nil {
+				identity.SamlNameID = node.SamlIdentity.NameId
+			}
+			if node.ScimIdentity != nil {
+				identity.ScimUsername = node.ScimIdentity.Username
+			}
+			identities[node.User.Login] = identity
+		}
+
+		if !provider.ExternalIdentities.PageInfo.HasNextPage {
+			break
+		}
+		variables["cursor"] = provider.ExternalIdentities.PageInfo.EndCursor
+	}
+
+	active, err := c.scimActiveIdentities(org)
+	if err != nil {
+		// the organization may not provision its members through SCIM
+		log.Printf("error collecting SCIM identities for org %s: %s\n", org, err)
+		return identities, true, nil
+	}
+	for _, identity := range identities {
+		if isActive, ok := active[identity.Guid]; ok {
+			identity.ScimActive = github.Bool(isActive)
+		}
+	}
+
+	return identities, true, nil
+}
+
+// scimActiveIdentities returns whether the SCIM provisioned identities are active, by their ID.
+// The ID of a SCIM identity is the GUID of its external identity.
+func (c *memberCollector) scimActiveIdentities(org string) (map[string]bool, error) {
+	const pageSize = 100
+	active := make(map[string]bool)
+	for startIndex := 1; ; startIndex += pageSize {
+		opts := &github.ListSCIMProvisionedIdentitiesOptions{StartIndex: github.Int(startIndex), Count: github.Int(pageSize)}
+		identities, _, err := c.Client.Client().SCIM.ListSCIMProvisionedIdentities(c.Context, org, opts)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list SCIM provisioned identities: %s", err)
+		}
+
+		for _, identity := range identities.Resources {
+			// a missing active attribute means the identity is active
+			active[identity.GetID()] = identity.Active == nil || *identity.Active
+		}
+
+		if len(identities.Resources) < pageSize || startIndex+pageSize > identities.GetTotalResults() {
+			break
+		}
+	}
+
+	return active, nil
+}
@@ -4,6 +4,8 @@ import (
 	"context"
 	"fmt"
 	"log"
+	"strings"
+	"sync"
 	"time"
 
 	"github.com/Legit-Labs/legitify/internal/collectors"
@@ -112,11 +114,18 @@ func (c *memberCollector) Collect() collectors.SubCollectorChannels {
 				c.CollectionChange(len(res))
 			}
 
+			hasTwoFactorStatus, hasSamlSso := c.withIdentities(&org, enrichedMembers)
+			outsideCollaborators, pendingInvitations := c.collectOutsiders(&org, hasLastActive)
+
 			c.CollectData(org,
 				ghcollected.OrganizationMembers{
-					Organization:  org,
-					Members:       enrichedMembers,
-					HasLastActive: hasLastActive,
+					Organization:         org,
+					Members:              enrichedMembers,
+					HasLastActive:        hasLastActive,
+					HasTwoFactorStatus:   hasTwoFactorStatus,
+					HasSamlSso:           hasSamlSso,
+					OutsideCollaborators: outsideCollaborators,
+					PendingInvitations:   pendingInvitations,
 				},
 				org.CanonicalLink(),
 				[]permissions.Role{org.Role})
@@ -172,6 +181,209 @@ func (c *memberCollector) collectMembers(org, memberType string) []*github.User
 	return res.Collected
 }
 
+// collectOutsiders collects the outside collaborators of the organization along with the repositories they can access,
+// and the pending invitations to the organization and to its repositories.
+func (c *memberCollector) collectOutsiders(org *ghcollected.ExtendedOrg, hasLastActive bool) ([]ghcollected.OutsideCollaborator, []ghcollected.PendingInvitation) {
+	var lock sync.Mutex
+	invitations := c.organizationInvitations(org.Name())
+
+	collaborators := []ghcollected.OutsideCollaborator{}
+	byLogin := make(map[string]*ghcollected.OutsideCollaborator)
+	outsiders, err := pagination.New[*github.User](c.Client.Client().Organizations.ListOutsideCollaborators, &github.ListOutsideCollaboratorsOptions{}).Sync(c.Context, org.Name())
+	if err != nil {
+		perm := collectors.NewMissingPermission(permissions.OrgAdmin, org.Name(), orgOutsideCollaboratorsEffect, namespace.Member)
+		c.IssueMissingPermissions(perm)
+	} else {
+		collaborators = make([]ghcollected.OutsideCollaborator, len(outsiders.Collected))
+		for i, user := range outsiders.Collected {
+			collaborators[i] = ghcollected.OutsideCollaborator{
+				User:         user,
+				LastActive:   -1,
+				Repositories: []ghcollected.CollaboratorRepository{},
+			}
+			byLogin[user.GetLogin()] = &collaborators[i]
+		}
+	}
+
+	// the outside collaborators are listed along with the repositories, so only the invitations are read one by one
+	repositories, err := c.collaboratorRepositories(org.Name())
+	if err != nil {
+		log.Printf("error collecting the repositories and their outside collaborators for org %s: %s\n", org.Name(), err)
+		return collaborators, invitations
+	}
+
+	gw := group_waiter.New()
+	for _, repository := range repositories {
+		repository := repository
+		gw.Do(func() {
+			repoInvitations, err := pagination.New[*github.RepositoryInvitation](c.Client.Client().Repositories.ListInvitations, &github.ListOptions{}).
+				Sync(c.Context, org.Name(), repository.name)
+			if err != nil {
+				log.Printf("error collecting invitations for %s: %s\n", collectors.FullRepoName(org.Name(), repository.name), err)
+			}
+
+			if repository.truncated && len(byLogin) > 0 {
+				opts := &github.ListCollaboratorsOptions{Affiliation: "outside"}
+				res, err := pagination.New[*github.User](c.Client.Client().Repositories.ListCollaborators, opts).Sync(c.Context, org.Name(), repository.name)
+				if err != nil {
+					log.Printf("error collecting outside collaborators for %s: %s\n", collectors.FullRepoName(org.Name(), repository.name), err)
+				} else {
+					for _, user := range res.Collected {
+						repository.permissions[user.GetLogin()] = user.GetRoleName()
+					}
+				}
+			}
+
+			lock.Lock()
+			defer lock.Unlock()
+			for _, invitation := range repoInvitations.Collected {
+				invitations = append(invitations, ghcollected.PendingInvitation{
+					Login:      invitation.GetInvitee().GetLogin(),
+					Role:       invitation.GetPermissions(),
+					Repository: repository.name,
+					CreatedAt:  int(invitation.GetCreatedAt().UnixNano()),
+					Expired:    time.Since(invitation.GetCreatedAt().Time) > repositoryInvitationExpiration,
+				})
+			}
+			for login, permission := range repository.permissions {
+				if collaborator, ok := byLogin[login]; ok {
+					collaborator.Repositories = append(collaborator.Repositories, ghcollected.CollaboratorRepository{
+						Name:       repository.name,
+						Permission: permission,
+					})
+				}
+			}
+		})
+	}
+	gw.Wait()
+
+	if hasLastActive {
+		for i := range collaborators {
+			collaborator := &collaborators[i]
+			gw.Do(func() {
+				lastActive, err := c.collectMemberLastActiveTime(org.Name(), collaborator.User.GetLogin())
+				if err != nil {
+					c.IssueMissingPermissions(c.memberMissingPermission(org, collaborator.User))
+					return
+				}
+				if !lastActive.IsZero() {
+					collaborator.LastActive = int(lastActive.UnixNano())
+				}
+			})
+		}
+		gw.Wait()
+	}
+
+	return collaborators, invitations
+}
+
+// organizationInvitations collects the pending invitations to the organization, and the invitations that expired.
+// Invitations that are not accepted within 7 days expire, and move to the failed invitations of the organization.
+func (c *memberCollector) organizationInvitations(org string) []ghcollected.PendingInvitation {
+	invitations := []ghcollected.PendingInvitation{}
+
+	pending, err := pagination.New[*github.Invitation](c.Client.Client().Organizations.ListPendingOrgInvitations, &github.ListOptions{}).Sync(c.Context, org)
+	if err != nil {
+		log.Printf("error collecting pending invitations for org %s: %s\n", org, err)
+	} else {
+		for _, invitation := range pending.Collected {
+			invitations = append(invitations, ghcollected.PendingInvitation{
+				Login:     invitation.GetLogin(),
+				Email:     invitation.GetEmail(),
+				Role:      invitation.GetRole(),
+				CreatedAt: int(invitation.GetCreatedAt().UnixNano()),
+			})
+		}
+	}
+
+	failed, err := pagination.New[*github.Invitation](c.Client.Client().Organizations.ListFailedOrgInvitations, &github.ListOptions{}).Sync(c.Context, org)
+	if err != nil {
+		log.Printf("error collecting failed invitations for org %s: %s\n", org, err)
+	} else {
+		for _, invitation := range failed.Collected {
+			// invitations may also fail for other reasons, e.g. when the invitee was blocked
+			if !strings.Contains(strings.ToLower(invitation.GetFailedReason()), "expired") {
+				continue
+			}
+			invitations = append(invitations, ghcollected.PendingInvitation{
+				Login:     invitation.GetLogin(),
+				Email:     invitation.GetEmail(),
+				Role:      invitation.GetRole(),
+				CreatedAt: int(invitation.GetCreatedAt().UnixNano()),
+				Expired:   true,
+			})
+		}
+	}
+
+	return invitations
+}
+
+type outsideCollaboratorsQuery struct {
+	Organization struct {
+		Repositories struct {
+			PageInfo ghcollected.GitHubQLPageInfo
+			Nodes    []struct {
+				Name          string
+				Collaborators *struct {
+					TotalCount int
+					Edges      []struct {
+						Permission string
+						Node       struct {
+							Login string
+						}
+					}
+				} `graphql:"collaborators(affiliation: OUTSIDE, first: 100)"`
+			}
+		} `graphql:"repositories(first: 50, after: $cursor)"`
+	} `graphql:"organization(login: $login)"`
+}
+
+// collaboratorRepository is a repository with the permission of its outside collaborators by login.
+// The collaborators of a truncated repository did not fit in the query, and are read from the repository.
+type collaboratorRepository struct {
+	name        string
+	permissions map[string]string
+	truncated   bool
+}
+
+// collaboratorRepositories returns the repositories of the organization along with their outside collaborators
+func (c *memberCollector) collaboratorRepositories(org string) ([]*collaboratorRepository, error) {
+	variables := map[string]interface{}{
+		"login":  githubv4.String(org),
+		"cursor": (*githubv4.String)(nil),
+	}
+
+	repositories := []*collaboratorRepository{}
+	for {
+		query := outsideCollaboratorsQuery{}
+		if err := c.Client.GraphQLClient().Query(c.Context, &query, variables); err != nil {
+			return nil, err
+		}
+
+		for _, node := range query.Organization.Repositories.Nodes {
+			repository := &collaboratorRepository{
+				name:        node.Name,
+				permissions: make(map[string]string),
+			}
+			if node.Collaborators == nil || node.Collaborators.TotalCount > len(node.Collaborators.Edges) {
+				repository.truncated = true
+			} else {
+				for _, edge := range node.Collaborators.Edges {
+					repository.permissions[edge.Node.Login] = strings.ToLower(edge.Permission)
+				}
+			}
+			repositories = append(repositories, repository)
+		}
+
+		if !query.Organization.Repositories.PageInfo.HasNextPage {
+			break
+		}
+		variables["cursor"] = query.Organization.Repositories.PageInfo.EndCursor
+	}
+
+	return repositories, nil
+}
+
 // collectMemberLastActiveTime will search and retrieve the most recent timestamp where a member was seen active,
 // based on both web and git activity.
 // Note: Org must be part of an enterprise.
@@ -199,10 +411,14 @@ func (c *memberCollector) collectMemberLastActiveTime(org, actor string) (*time.
 	return &LastActive, nil
 }
 
+// repository invitations expire after 7 days, but are listed until they are cancelled
+const repositoryInvitationExpiration = 7 * 24 * time.Hour
+
 const (
-	orgMemberLastActiveEffect = "Cannot read organization member last active time"
-	orgInfoEffect             = "Cannot read organization information"
-	orgNotEnterpriseEffect    = "Some information cannot be collected because the organization is not part of an enterprise"
+	orgMemberLastActiveEffect     = "Cannot read organization member last active time"
+	orgInfoEffect                 = "Cannot read organization information"
+	orgNotEnterpriseEffect        = "Some information cannot be collected because the organization is not part of an enterprise"
+	orgOutsideCollaboratorsEffect = "Cannot read organization outside collaborators"
 )
 
 func (c *memberCollector) memberMissingPermission(org *ghcollected.ExtendedOrg, member *github.User) collectors.MissingPermission {
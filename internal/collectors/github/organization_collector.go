@@ -97,11 +97,21 @@ func (c *organizationCollector) collectExtraData(org *ghcollected.ExtendedOrg) g
 		log.Printf("failed to collect secrets for %s, %s", org.Name(), err)
 	}
 
+	rulesets, err := c.Client.GetOrganizationRulesets(org.Name())
+	if err != nil {
+		rulesets = nil
+		log.Printf("failed to collect rulesets for %s, %s", org.Name(), err)
+		perm := collectors.NewMissingPermission(permissions.OrgAdmin, org.Name(),
+			"Cannot read organization rulesets", namespace.Organization)
+		c.IssueMissingPermissions(perm)
+	}
+
 	return ghcollected.Organization{
 		Organization: org,
 		SamlEnabled:  samlEnabled,
 		Hooks:        hooks,
 		OrgSecrets:   secrets,
+		Rulesets:     rulesets,
 	}
 }
 
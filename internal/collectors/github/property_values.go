@@ -0,0 +1,54 @@
+package github
+
+import (
+	"sync"
+
+	ghclient "github.com/Legit-Labs/legitify/internal/clients/github"
+	"github.com/Legit-Labs/legitify/internal/clients/github/types"
+)
+
+// propertyValuesByClient shares the property values between the collectors of the same client,
+// since the repository and branch collectors go over the same repositories
+var propertyValuesByClient sync.Map
+
+// organizationPropertyValues caches the custom property values of the repositories of each organization,
+// which are listed once per organization rather than fetched for every repository
+type organizationPropertyValues struct {
+	client *ghclient.Client
+	lock   sync.Mutex
+	byOrg  map[string]*orgPropertyValues
+}
+
+type orgPropertyValues struct {
+	byRepository map[string][]*types.RepositoryPropertyValue
+	err          error
+}
+
+func sharedPropertyValues(client *ghclient.Client) *organizationPropertyValues {
+	values, _ := propertyValuesByClient.LoadOrStore(client, &organizationPropertyValues{
+		client: client,
+		byOrg:  make(map[string]*orgPropertyValues),
+	})
+
+	return values.(*organizationPropertyValues)
+}
+
+// get returns the property values of the repository.
+// A failure to list the values of the organization is returned for each of its repositories, without listing them again.
+func (o *organizationPropertyValues) get(org string, repository string) ([]*types.RepositoryPropertyValue, error) {
+	o.lock.Lock()
+	defer o.lock.Unlock()
+
+	values, ok := o.byOrg[org]
+	if !ok {
+		values = &orgPropertyValues{byRepository: make(map[string][]*types.RepositoryPropertyValue)}
+		repositories, err := o.client.GetOrganizationPropertyValues(org)
+		values.err = err
+		for _, repository := range repositories {
+			values.byRepository[repository.RepositoryName] = repository.Properties
+		}
+		o.byOrg[org] = values
+	}
+
+	return values.byRepository[repository], values.err
+}
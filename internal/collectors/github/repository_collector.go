@@ -9,6 +9,8 @@ import (
 	"github.com/Legit-Labs/legitify/internal/scorecard"
 	"log"
 	"net/http"
+	"strings"
+	"sync/atomic"
 
 	"github.com/Legit-Labs/legitify/internal/common/group_waiter"
 	"github.com/Legit-Labs/legitify/internal/common/permissions"
@@ -27,6 +29,10 @@ type repositoryCollector struct {
 	Client           *ghclient.Client
 	Context          context.Context
 	scorecardEnabled bool
+	includeArchived  bool
+	includeUserRepos bool
+	orgRulesets      *organizationRulesets
+	filter           *repositoryFilter
 }
 
 func NewRepositoryCollector(ctx context.Context, client *ghclient.Client) collectors.Collector {
@@ -35,6 +41,10 @@ func NewRepositoryCollector(ctx context.Context, client *ghclient.Client) collec
 		Client:           client,
 		Context:          ctx,
 		scorecardEnabled: context_utils.GetScorecardEnabled(ctx),
+		includeArchived:  context_utils.GetIncludeArchived(ctx),
+		includeUserRepos: context_utils.GetIncludeUserRepos(ctx),
+		orgRulesets:      newOrganizationRulesets(client),
+		filter:           newRepositoryFilter(ctx, client),
 	}
 	return c
 }
@@ -43,46 +53,103 @@ type totalCountRepoQuery struct {
 	Organization struct {
 		Repositories struct {
 			TotalCount githubv4.Int
-		} `graphql:"repositories(first: 1, isArchived: false)"`
+		} `graphql:"repositories(first: 1, isArchived: $isArchived)"`
 	} `graphql:"organization(login: $login)"`
 }
 
+type totalCountUserRepoQuery struct {
+	User struct {
+		Repositories struct {
+			TotalCount githubv4.Int
+		} `graphql:"repositories(first: 1, ownerAffiliations: [OWNER], isArchived: $isArchived)"`
+	} `graphql:"user(login: $login)"`
+}
+
+// isArchivedFilter filters out the archived repositories, unless they should be included
+func isArchivedFilter(includeArchived bool) *githubv4.Boolean {
+	if includeArchived {
+		return nil
+	}
+
+	return githubv4.NewBoolean(false)
+}
+
 func (rc *repositoryCollector) CollectTotalEntities() int {
 	repositories, exist := context_utils.GetRepositories(rc.Context)
 	if exist {
 		return len(repositories)
 	}
 
+	total := countOrganizationRepositories(rc.Context, rc.Client)
+	if rc.includeUserRepos {
+		total += countMembersRepositories(rc.Context, rc.Client)
+	}
+
+	return total
+}
+
+func countOrganizationRepositories(ctx context.Context, client *ghclient.Client) int {
 	gw := group_waiter.New()
-	orgs, err := rc.Client.CollectOrganizations()
+	orgs, err := client.CollectOrganizations()
 
 	if err != nil {
 		log.Printf("failed to collect organization %s", err)
 		return 0
 	}
 
-	var totalCount int32 = 0
+	var totalCount atomic.Int32
 	for _, org := range orgs {
 		org := org
 		gw.Do(func() {
 			variables := map[string]interface{}{
-				"login": githubv4.String(org.Name()),
+				"login":      githubv4.String(org.Name()),
+				"isArchived": isArchivedFilter(context_utils.GetIncludeArchived(ctx)),
 			}
 
 			totalCountQuery := totalCountRepoQuery{}
 
-			e := rc.Client.GraphQLClient().Query(rc.Context, &totalCountQuery, variables)
+			e := client.GraphQLClient().Query(ctx, &totalCountQuery, variables)
 
 			if e != nil {
 				return
 			}
 
-			totalCount += int32(totalCountQuery.Organization.Repositories.TotalCount)
+			totalCount.Add(int32(totalCountQuery.Organization.Repositories.TotalCount))
+		})
+	}
+	gw.Wait()
+
+	return int(totalCount.Load())
+}
+
+func countMembersRepositories(ctx context.Context, client *ghclient.Client) int {
+	orgs, err := client.CollectOrganizations()
+	if err != nil {
+		log.Printf("failed to collect organization %s", err)
+		return 0
+	}
+
+	gw := group_waiter.New()
+	var totalCount atomic.Int32
+	for _, login := range membersLogins(ctx, client, orgs) {
+		login := login
+		gw.Do(func() {
+			variables := map[string]interface{}{
+				"login":      githubv4.String(login),
+				"isArchived": isArchivedFilter(context_utils.GetIncludeArchived(ctx)),
+			}
+
+			totalCountQuery := totalCountUserRepoQuery{}
+			if err := client.GraphQLClient().Query(ctx, &totalCountQuery, variables); err != nil {
+				return
+			}
+
+			totalCount.Add(int32(totalCountQuery.User.Repositories.TotalCount))
 		})
 	}
 	gw.Wait()
 
-	return int(totalCount)
+	return int(totalCount.Load())
 }
 
 func (rc *repositoryCollector) Collect() collectors.SubCollectorChannels {
@@ -95,55 +162,64 @@ func (rc *repositoryCollector) Collect() collectors.SubCollectorChannels {
 	return rc.collectAll()
 }
 
-func (rc *repositoryCollector) collectSpecific(repositories []types.RepositoryWithOwner) collectors.SubCollectorChannels {
-	type specificRepoQuery struct {
-		RepositoryOwner struct {
-			Organization struct {
-				ViewerCanAdminister *bool
-			} `graphql:"... on Organization"`
+type specificRepoQuery struct {
+	RepositoryOwner struct {
+		Organization struct {
+			ViewerCanAdminister *bool
+		} `graphql:"... on Organization"`
 
-			Login      githubv4.String
-			Repository ghcollected.GitHubQLRepository `graphql:"repository(name: $name)"`
-		} `graphql:"repositoryOwner(login: $login)"`
+		Login      githubv4.String
+		Repository ghcollected.GitHubQLRepository `graphql:"repository(name: $name)"`
+	} `graphql:"repositoryOwner(login: $login)"`
+}
+
+// querySpecificRepository queries a repository that is specified by the user along with its collection context
+func querySpecificRepository(ctx context.Context, client *ghclient.Client, repo types.RepositoryWithOwner) (*ghcollected.GitHubQLRepository, *repositoryContext, error) {
+	variables := map[string]interface{}{
+		"login": githubv4.String(repo.Owner),
+		"name":  githubv4.String(repo.Name),
 	}
 
+	query := specificRepoQuery{}
+	err := client.GraphQLClient().Query(ctx, &query, variables)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var collectionContext *repositoryContext
+
+	if query.RepositoryOwner.Organization.ViewerCanAdminister != nil {
+
+		org, err := client.Organization(repo.Owner)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		hasBp := hasBranchProtection(org, query.RepositoryOwner.Repository.IsPrivate)
+		collectionContext = newRepositoryContext([]permissions.Role{org.Role, query.RepositoryOwner.Repository.ViewerPermission},
+			hasBp, org.IsEnterprise(), false, false)
+	} else {
+		hasBp := hasBranchProtectionForUser(ctx, client, repo.Owner, query.RepositoryOwner.Repository.IsPrivate)
+		collectionContext = newRepositoryContext([]permissions.Role{query.RepositoryOwner.Repository.ViewerPermission},
+			hasBp, false, false, false)
+	}
+
+	return &query.RepositoryOwner.Repository, collectionContext, nil
+}
+
+func (rc *repositoryCollector) collectSpecific(repositories []types.RepositoryWithOwner) collectors.SubCollectorChannels {
 	return rc.WrappedCollection(func() {
 		gw := group_waiter.New()
 		for _, r := range repositories {
 			repo := r
 			gw.Do(func() {
-				variables := map[string]interface{}{
-					"login": githubv4.String(repo.Owner),
-					"name":  githubv4.String(repo.Name),
-				}
-
-				query := specificRepoQuery{}
-				err := rc.Client.GraphQLClient().Query(rc.Context, &query, variables)
+				repository, collectionContext, err := querySpecificRepository(rc.Context, rc.Client, repo)
 				if err != nil {
 					log.Println(err.Error())
 					return
 				}
 
-				var collectionContext *repositoryContext
-
-				if query.RepositoryOwner.Organization.ViewerCanAdminister != nil {
-
-					org, err := rc.Client.Organization(repo.Owner)
-					if err != nil {
-						log.Println(err.Error())
-						return
-					}
-
-					hasBp := hasBranchProtection(org, query.RepositoryOwner.Repository.IsPrivate)
-					collectionContext = newRepositoryContext([]permissions.Role{org.Role, query.RepositoryOwner.Repository.ViewerPermission},
-						hasBp, org.IsEnterprise(), false, false)
-				} else {
-					hasBp := rc.hasBranchProtectionForUser(repo.Owner, query.RepositoryOwner.Repository.IsPrivate)
-					collectionContext = newRepositoryContext([]permissions.Role{query.RepositoryOwner.Repository.ViewerPermission},
-						hasBp, false, false, false)
-				}
-
-				rc.collectRepository(&query.RepositoryOwner.Repository, repo.Owner, collectionContext)
+				rc.collectRepository(repository, repo.Owner, collectionContext)
 			})
 		}
 
@@ -171,6 +247,10 @@ func (rc *repositoryCollector) collectAll() collectors.SubCollectorChannels {
 			})
 		}
 		gw.Wait()
+
+		if rc.includeUserRepos {
+			rc.collectMembersRepositories(orgs)
+		}
 	})
 }
 
@@ -179,14 +259,24 @@ type repoQuery struct {
 		Repositories struct {
 			PageInfo ghcollected.GitHubQLPageInfo
 			Nodes    []ghcollected.GitHubQLRepository
-		} `graphql:"repositories(first: 50, after: $repositoryCursor, isArchived: false)"`
+		} `graphql:"repositories(first: 50, after: $repositoryCursor, isArchived: $isArchived)"`
 	} `graphql:"organization(login: $login)"`
 }
 
+type userRepoQuery struct {
+	User struct {
+		Repositories struct {
+			PageInfo ghcollected.GitHubQLPageInfo
+			Nodes    []ghcollected.GitHubQLRepository
+		} `graphql:"repositories(first: 50, after: $repositoryCursor, ownerAffiliations: [OWNER], isArchived: $isArchived)"`
+	} `graphql:"user(login: $login)"`
+}
+
 func (rc *repositoryCollector) collectRepositories(org *ghcollected.ExtendedOrg) error {
 	variables := map[string]interface{}{
 		"login":            githubv4.String(org.Name()),
 		"repositoryCursor": (*githubv4.String)(nil),
+		"isArchived":       isArchivedFilter(rc.includeArchived),
 	}
 
 	gw := group_waiter.New()
@@ -223,8 +313,87 @@ func (rc *repositoryCollector) collectRepositories(org *ghcollected.ExtendedOrg)
 	return nil
 }
 
+// membersLogins returns the distinct logins of the members of the organizations
+func membersLogins(ctx context.Context, client *ghclient.Client, orgs []ghcollected.ExtendedOrg) []string {
+	seen := make(map[string]bool)
+	var logins []string
+	for _, org := range orgs {
+		members, err := pagination.New[*github.User](client.Client().Organizations.ListMembers, &github.ListMembersOptions{}).Sync(ctx, org.Name())
+		if err != nil {
+			log.Printf("failed to collect members of %s: %s", org.Name(), err)
+			continue
+		}
+
+		for _, member := range members.Collected {
+			if !seen[member.GetLogin()] {
+				seen[member.GetLogin()] = true
+				logins = append(logins, member.GetLogin())
+			}
+		}
+	}
+
+	return logins
+}
+
+// collectMembersRepositories collects the repositories that the members of the organizations own personally
+func (rc *repositoryCollector) collectMembersRepositories(orgs []ghcollected.ExtendedOrg) {
+	gw := group_waiter.New()
+	for _, login := range membersLogins(rc.Context, rc.Client, orgs) {
+		login := login
+		gw.Do(func() {
+			_ = utils.Retry(func() (bool, error) {
+				err := rc.collectUserRepositories(login)
+				return true, err
+			}, 5, fmt.Sprintf("collect repositories of user %s", login))
+		})
+	}
+	gw.Wait()
+}
+
+func (rc *repositoryCollector) collectUserRepositories(login string) error {
+	variables := map[string]interface{}{
+		"login":            githubv4.String(login),
+		"repositoryCursor": (*githubv4.String)(nil),
+		"isArchived":       isArchivedFilter(rc.includeArchived),
+	}
+
+	gw := group_waiter.New()
+	defer gw.Wait()
+	for {
+		query := userRepoQuery{}
+		err := rc.Client.GraphQLClient().Query(rc.Context, &query, variables)
+
+		if err != nil {
+			return err
+		}
+
+		nodes := query.User.Repositories.Nodes
+		for i := range nodes {
+			node := &(nodes[i])
+			gw.Do(func() {
+				collectionContext := newRepositoryContext([]permissions.Role{node.ViewerPermission},
+					hasBranchProtectionForUser(rc.Context, rc.Client, login, node.IsPrivate), false, false, false)
+				rc.collectRepository(node, login, collectionContext)
+			})
+		}
+
+		if !query.User.Repositories.PageInfo.HasNextPage {
+			break
+		}
+
+		variables["repositoryCursor"] = query.User.Repositories.PageInfo.EndCursor
+	}
+
+	return nil
+}
+
 func (rc *repositoryCollector) collectRepository(repository *ghcollected.GitHubQLRepository, login string, collectionContext *repositoryContext) {
-	repo := rc.collectExtraData(login, repository, collectionContext.isBranchProtectionSupported)
+	if !rc.filter.matches(login, repository) {
+		rc.CollectionChangeByOne()
+		return
+	}
+
+	repo := rc.collectExtraData(login, repository, collectionContext)
 	entityName := collectors.FullRepoName(login, repo.Repository.Name)
 	missingPermissions := rc.checkMissingPermissions(repo, entityName, collectionContext)
 	rc.IssueMissingPermissions(missingPermissions...)
@@ -236,7 +405,7 @@ func (rc *repositoryCollector) collectRepository(repository *ghcollected.GitHubQ
 
 func (rc *repositoryCollector) collectExtraData(login string,
 	repository *ghcollected.GitHubQLRepository,
-	isBranchProtectionSupported bool) ghcollected.Repository {
+	collectionContext *repositoryContext) ghcollected.Repository {
 	var err error
 	repo := ghcollected.Repository{
 		Repository: repository,
@@ -261,7 +430,24 @@ func (rc *repositoryCollector) collectExtraData(login string,
 		log.Printf("failed to collect repository Security and Analysis settings for %s: %s", repo.Repository.Name, err)
 	}
 
-	if isBranchProtectionSupported {
+	repo, err = rc.withWorkflows(repo, login)
+	if err != nil {
+		log.Printf("failed to collect repository workflows for %s: %s", collectors.FullRepoName(login, repo.Repository.Name), err)
+	}
+
+	repo = rc.withDeployKeys(repo, login)
+	repo, err = rc.withEnvironments(repo, login)
+	if err != nil {
+		log.Printf("failed to collect repository environments for %s: %s", collectors.FullRepoName(login, repo.Repository.Name), err)
+	}
+
+	repo = rc.withSecurityAlerts(repo, login)
+	repo, err = rc.withCodeowners(repo, login)
+	if err != nil {
+		log.Printf("failed to collect the CODEOWNERS file of %s: %s", collectors.FullRepoName(login, repo.Repository.Name), err)
+	}
+
+	if collectionContext.IsBranchProtectionSupported() {
 		repo, err = rc.fixBranchProtectionInfo(repo, login)
 		if err != nil {
 			// If we can't get branch protection info, rego will ignore it (as nil)
@@ -271,6 +457,7 @@ func (rc *repositoryCollector) collectExtraData(login string,
 		if err != nil {
 			log.Printf("error getting rules set for %s: %s", repository.Name, err)
 		}
+		repo = rc.withOrganizationRulesets(repo, login, collectionContext.Roles())
 	} else {
 		perm := collectors.NewMissingPermission(permissions.RepoAdmin, collectors.FullRepoName(login, repo.Repository.Name), orgIsFreeEffect, namespace.Repository)
 		rc.IssueMissingPermissions(perm)
@@ -292,12 +479,12 @@ func hasBranchProtection(org *ghcollected.ExtendedOrg, isPrivateRepository bool)
 	return org.IsEnterprise() || !isPrivateRepository
 }
 
-func (rc *repositoryCollector) hasBranchProtectionForUser(userLogin string, isPrivateRepository bool) bool {
+func hasBranchProtectionForUser(ctx context.Context, client *ghclient.Client, userLogin string, isPrivateRepository bool) bool {
 	if isPrivateRepository {
 		return true
 	}
 
-	user, _, err := rc.Client.Client().Users.Get(rc.Context, userLogin)
+	user, _, err := client.Client().Users.Get(ctx, userLogin)
 	if err != nil {
 		return false
 	}
@@ -397,6 +584,27 @@ func (rc *repositoryCollector) withRulesSet(repository ghcollected.Repository, o
 	return repository, nil
 }
 
+// withOrganizationRulesets adds the rules of the organization and enterprise rulesets that apply to the default branch,
+// so the branch policies evaluate the effective protection of the branch
+func (rc *repositoryCollector) withOrganizationRulesets(repository ghcollected.Repository, org string, roles []permissions.Role) ghcollected.Repository {
+	if repository.Repository.DefaultBranchRef == nil || repository.Repository.DefaultBranchRef.Name == nil {
+		return repository // no branches
+	}
+
+	rulesets, missingPermissions := rc.orgRulesets.get(org, roles)
+	rc.IssueMissingPermissions(missingPermissions...)
+	if len(rulesets) == 0 {
+		return repository
+	}
+
+	target := rc.orgRulesets.rulesetTarget(org, repository.Repository, rulesets)
+	target.Branch = *repository.Repository.DefaultBranchRef.Name
+	target.IsDefaultBranch = true
+	repository.RulesSet = ghcollected.EffectiveRules(repository.RulesSet, target, rulesets)
+
+	return repository
+}
+
 func (rc *repositoryCollector) withSecrets(repository ghcollected.Repository, login string) (ghcollected.Repository, error) {
 	secrets, err := rc.Client.GetRepositorySecrets(repository.Name(), login)
 	if err != nil {
@@ -424,6 +632,402 @@ func (rc *repositoryCollector) withSecurityAndAnalysis(repo ghcollected.Reposito
 	return repo, nil
 }
 
+// withWorkflows collects and parses the workflow files of the default branch
+func (rc *repositoryCollector) withWorkflows(repo ghcollected.Repository, login string) (ghcollected.Repository, error) {
+	repo.Workflows = []*ghcollected.Workflow{}
+	if repo.Repository.DefaultBranchRef == nil || repo.Repository.DefaultBranchRef.Name == nil {
+		return repo, nil // no branches
+	}
+
+	var workflowsQuery struct {
+		Repository struct {
+			Object *struct {
+				Tree struct {
+					Entries []struct {
+						Name   string
+						Path   string
+						Object *struct {
+							Blob struct {
+								Text     *string
+								IsBinary bool
+							} `graphql:"... on Blob"`
+						}
+					}
+				} `graphql:"... on Tree"`
+			} `graphql:"object(expression: $expression)"`
+		} `graphql:"repository(owner: $login, name: $name)"`
+	}
+
+	variables := map[string]interface{}{
+		"login":      githubv4.String(login),
+		"name":       githubv4.String(repo.Name()),
+		"expression": githubv4.String(*repo.Repository.DefaultBranchRef.Name + ":" + ghcollected.WorkflowsPath),
+	}
+
+	err := rc.Client.GraphQLClient().Query(rc.Context, &workflowsQuery, variables)
+	if err != nil {
+		return repo, err
+	}
+
+	if workflowsQuery.Repository.Object == nil {
+		return repo, nil // no workflows
+	}
+
+	for _, entry := range workflowsQuery.Repository.Object.Tree.Entries {
+		if !strings.HasSuffix(entry.Name, ".yml") && !strings.HasSuffix(entry.Name, ".yaml") {
+			continue
+		}
+		if entry.Object == nil || entry.Object.Blob.IsBinary || entry.Object.Blob.Text == nil {
+			continue
+		}
+
+		workflow, err := ghcollected.ParseWorkflow(entry.Path, []byte(*entry.Object.Blob.Text))
+		if err != nil {
+			// an invalid workflow doesn't run, so it doesn't affect the posture
+			log.Printf("%s: %v", collectors.FullRepoName(login, repo.Repository.Name), err)
+			continue
+		}
+		repo.Workflows = append(repo.Workflows, workflow)
+	}
+
+	return repo, nil
+}
+
+func (rc *repositoryCollector) withDeployKeys(repo ghcollected.Repository, org string) ghcollected.Repository {
+	res, err := pagination.New[*github.Key](rc.Client.Client().Repositories.ListKeys, &github.ListOptions{}).Sync(rc.Context, org, repo.Repository.Name)
+	if err != nil {
+		perm := collectors.NewMissingPermission(permissions.RepoAdmin, collectors.FullRepoName(org, repo.Repository.Name),
+			"Cannot read repository deploy keys", namespace.Repository)
+		rc.IssueMissingPermissions(perm)
+		return repo
+	}
+
+	repo.DeployKeys = []*ghcollected.RepositoryDeployKey{}
+	for _, key := range res.Collected {
+		deployKey := &ghcollected.RepositoryDeployKey{
+			ID:        key.GetID(),
+			Title:     key.GetTitle(),
+			ReadOnly:  key.GetReadOnly(),
+			AddedBy:   key.GetAddedBy(),
+			CreatedAt: int(key.GetCreatedAt().UnixNano()),
+		}
+		if key.LastUsed != nil {
+			deployKey.LastUsed = int(key.GetLastUsed().UnixNano())
+		}
+		repo.DeployKeys = append(repo.DeployKeys, deployKey)
+	}
+
+	return repo
+}
+
+func (rc *repositoryCollector) withEnvironments(repo ghcollected.Repository, org string) (ghcollected.Repository, error) {
+	mapper := func(resp *github.EnvResponse) []*github.Environment {
+		if resp == nil {
+			return []*github.Environment{}
+		}
+		return resp.Environments
+	}
+	res, err := pagination.NewMapper(rc.Client.Client().Repositories.ListEnvironments, &github.EnvironmentListOptions{}, mapper).
+		Sync(rc.Context, org, repo.Repository.Name)
+	if err != nil {
+		return repo, err
+	}
+
+	repo.Environments = []*ghcollected.RepositoryEnvironment{}
+	for _, env := range res.Collected {
+		environment := &ghcollected.RepositoryEnvironment{
+			Name:            env.GetName(),
+			CanAdminsBypass: env.GetCanAdminsBypass(),
+			Reviewers:       []*ghcollected.EnvironmentReviewer{},
+		}
+
+		for _, rule := range env.ProtectionRules {
+			environment.WaitTimer += rule.GetWaitTimer()
+			for _, reviewer := range rule.Reviewers {
+				environment.Reviewers = append(environment.Reviewers, ghcollected.NewEnvironmentReviewer(reviewer))
+			}
+		}
+
+		if env.DeploymentBranchPolicy != nil {
+			environment.DeploymentBranchPolicy = &ghcollected.EnvironmentBranchPolicy{
+				ProtectedBranches: env.DeploymentBranchPolicy.GetProtectedBranches(),
+			}
+			if env.DeploymentBranchPolicy.GetCustomBranchPolicies() {
+				policies, err := rc.Client.GetDeploymentBranchPolicies(org, repo.Repository.Name, environment.Name)
+				if err != nil {
+					log.Printf("failed to collect the deployment branch policies of %s in %s: %s", environment.Name, collectors.FullRepoName(org, repo.Repository.Name), err)
+				} else {
+					for _, policy := range policies {
+						environment.DeploymentBranchPolicy.CustomBranchPolicies = append(environment.DeploymentBranchPolicy.CustomBranchPolicies, policy.GetName())
+					}
+				}
+			}
+		}
+
+		environment.Secrets, err = rc.environmentSecrets(repo, environment.Name)
+		if err != nil {
+			log.Printf("failed to collect the secrets of %s in %s: %s", environment.Name, collectors.FullRepoName(org, repo.Repository.Name), err)
+		}
+
+		repo.Environments = append(repo.Environments, environment)
+	}
+
+	return repo, nil
+}
+
+func (rc *repositoryCollector) environmentSecrets(repo ghcollected.Repository, environment string) ([]*ghcollected.RepositorySecret, error) {
+	mapper := func(secrets *github.Secrets) []*github.Secret {
+		if secrets == nil {
+			return []*github.Secret{}
+		}
+		return secrets.Secrets
+	}
+	res, err := pagination.NewMapper(rc.Client.Client().Actions.ListEnvSecrets, &github.ListOptions{}, mapper).
+		Sync(rc.Context, int(repo.Repository.DatabaseId), environment)
+	if err != nil {
+		return nil, err
+	}
+
+	secrets := []*ghcollected.RepositorySecret{}
+	for _, secret := range res.Collected {
+		secrets = append(secrets, &ghcollected.RepositorySecret{
+			Name:      secret.Name,
+			UpdatedAt: int(secret.UpdatedAt.Time.UnixNano()),
+		})
+	}
+
+	return secrets, nil
+}
+
+// withSecurityAlerts summarizes the open Dependabot, code scanning and secret scanning alerts of the repository
+func (rc *repositoryCollector) withSecurityAlerts(repo ghcollected.Repository, org string) ghcollected.Repository {
+	entityName := collectors.FullRepoName(org, repo.Repository.Name)
+	alerts := &ghcollected.RepositorySecurityAlerts{}
+
+	var dependabotErr, codeScanningErr, secretScanningErr error
+	alerts.Dependabot, dependabotErr = rc.dependabotAlerts(org, repo.Repository.Name)
+	if dependabotErr != nil {
+		log.Printf("failed to collect Dependabot alerts for %s: %s", entityName, dependabotErr)
+	}
+	alerts.CodeScanning, codeScanningErr = rc.codeScanningAlerts(org, repo.Repository.Name)
+	if codeScanningErr != nil {
+		log.Printf("failed to collect code scanning alerts for %s: %s", entityName, codeScanningErr)
+	}
+	alerts.SecretScanning, secretScanningErr = rc.secretScanningAlerts(org, repo.Repository.Name)
+	if secretScanningErr != nil {
+		log.Printf("failed to collect secret scanning alerts for %s: %s", entityName, secretScanningErr)
+	}
+
+	// a disabled feature fails as well, so only a failure of all of them is reported
+	if dependabotErr != nil && codeScanningErr != nil && secretScanningErr != nil {
+		perm := collectors.NewMissingPermission(permissions.RepoSecurityEvents, entityName,
+			"Cannot read repository security alerts", namespace.Repository)
+		rc.IssueMissingPermissions(perm)
+		return repo
+	}
+
+	repo.SecurityAlerts = alerts
+	return repo
+}
+
+func (rc *repositoryCollector) dependabotAlerts(org string, name string) (map[string]*ghcollected.OpenAlerts, error) {
+	bySeverity := make(map[string]*ghcollected.OpenAlerts)
+	opts := &github.ListAlertsOptions{
+		State:             github.String("open"),
+		ListCursorOptions: github.ListCursorOptions{PerPage: 100},
+	}
+	for {
+		alerts, resp, err := rc.Client.Client().Dependabot.ListRepoAlerts(rc.Context, org, name, opts)
+		if err != nil {
+			return nil, err
+		}
+		for _, alert := range alerts {
+			addOpenAlert(bySeverity, alert.GetSecurityAdvisory().GetSeverity(), alert.GetCreatedAt())
+		}
+		if resp.After == "" {
+			break
+		}
+		opts.After = resp.After
+	}
+
+	return bySeverity, nil
+}
+
+func (rc *repositoryCollector) codeScanningAlerts(org string, name string) (map[string]*ghcollected.OpenAlerts, error) {
+	bySeverity := make(map[string]*ghcollected.OpenAlerts)
+	opts := &github.AlertListOptions{
+		State:       "open",
+		ListOptions: github.ListOptions{PerPage: 100},
+	}
+	for {
+		alerts, resp, err := rc.Client.Client().CodeScanning.ListAlertsForRepo(rc.Context, org, name, opts)
+		if err != nil {
+			return nil, err
+		}
+		for _, alert := range alerts {
+			severity := alert.GetRule().GetSecuritySeverityLevel()
+			if severity == "" {
+				severity = alert.GetRule().GetSeverity()
+			}
+			addOpenAlert(bySeverity, severity, alert.GetCreatedAt())
+		}
+		if resp.NextPage == 0 {
+			break
+		}
+		opts.ListOptions.Page = resp.NextPage
+	}
+
+	return bySeverity, nil
+}
+
+func (rc *repositoryCollector) secretScanningAlerts(org string, name string) (*ghcollected.OpenAlerts, error) {
+	result := &ghcollected.OpenAlerts{}
+	opts := &github.SecretScanningAlertListOptions{
+		State:       "open",
+		ListOptions: github.ListOptions{PerPage: 100},
+	}
+	for {
+		alerts, resp, err := rc.Client.Client().SecretScanning.ListAlertsForRepo(rc.Context, org, name, opts)
+		if err != nil {
+			return nil, err
+		}
+		for _, alert := range alerts {
+			result.Add(int(alert.GetCreatedAt().UnixNano()))
+		}
+		if resp.NextPage == 0 {
+			break
+		}
+		opts.ListOptions.Page = resp.NextPage
+	}
+
+	return result, nil
+}
+
+func addOpenAlert(bySeverity map[string]*ghcollected.OpenAlerts, severity string, createdAt github.Timestamp) {
+	severity = strings.ToLower(severity)
+	if _, ok := bySeverity[severity]; !ok {
+		bySeverity[severity] = &ghcollected.OpenAlerts{}
+	}
+	bySeverity[severity].Add(int(createdAt.UnixNano()))
+}
+
+// withCodeowners parses the CODEOWNERS file of the default branch and resolves its owners
+func (rc *repositoryCollector) withCodeowners(repo ghcollected.Repository, org string) (ghcollected.Repository, error) {
+	if repo.Repository.DefaultBranchRef == nil || repo.Repository.DefaultBranchRef.Name == nil {
+		return repo, nil // no branches
+	}
+
+	opts := &github.RepositoryContentGetOptions{Ref: *repo.Repository.DefaultBranchRef.Name}
+	codeowners := &ghcollected.Codeowners{}
+	for _, path := range ghcollected.CodeownersPaths {
+		file, _, resp, err := rc.Client.Client().Repositories.GetContents(rc.Context, org, repo.Repository.Name, path, opts)
+		if err != nil {
+			if resp != nil && resp.StatusCode == http.StatusNotFound {
+				continue
+			}
+			return repo, err
+		}
+		if file == nil {
+			continue // a directory
+		}
+		content, err := file.GetContent()
+		if err != nil {
+			return repo, err
+		}
+		codeowners = ghcollected.ParseCodeowners(path, []byte(content))
+		break
+	}
+
+	if codeowners.Path != "" {
+		// GitHub's validation is authoritative, and the local parsing is only a fallback
+		if validation, _, err := rc.Client.Client().Repositories.GetCodeownersErrors(rc.Context, org, repo.Repository.Name); err == nil {
+			codeowners.Errors = []*ghcollected.CodeownersError{}
+			for _, codeownersError := range validation.Errors {
+				codeowners.Errors = append(codeowners.Errors, &ghcollected.CodeownersError{
+					Line:    codeownersError.Line,
+					Kind:    codeownersError.Kind,
+					Source:  codeownersError.Source,
+					Message: codeownersError.Message,
+				})
+			}
+		}
+		rc.resolveCodeowners(repo, org, codeowners.Owners)
+		paths := append([]string{}, ghcollected.CodeownersSensitivePaths...)
+		codeowners.UncoveredPaths = codeowners.Uncovered(append(paths, codeowners.Path))
+	}
+
+	repo.Codeowners = codeowners
+	return repo, nil
+}
+
+// resolveCodeowners checks that the users and teams in the CODEOWNERS file exist and have write access to the repository.
+// Owners that can't be checked (e.g. emails, or when the collaborators are not available) remain unresolved.
+func (rc *repositoryCollector) resolveCodeowners(repo ghcollected.Repository, org string, owners []*ghcollected.CodeownersOwner) {
+	var teams map[string]string
+	for _, owner := range owners {
+		switch owner.Type {
+		case ghcollected.CodeownerTypeUser:
+			if repo.Collaborators == nil {
+				continue
+			}
+			login := strings.TrimPrefix(owner.Name, "@")
+			owner.Status = ghcollected.CodeownerNotFound
+			for _, collaborator := range repo.Collaborators {
+				if strings.EqualFold(collaborator.GetLogin(), login) {
+					owner.Status = ghcollected.CodeownerNoWriteAccess
+					if collaborator.GetPermissions()["push"] {
+						owner.Status = ghcollected.CodeownerValid
+					}
+					break
+				}
+			}
+			if owner.Status == ghcollected.CodeownerNotFound {
+				_, resp, err := rc.Client.Client().Users.Get(rc.Context, login)
+				if err == nil {
+					owner.Status = ghcollected.CodeownerNoWriteAccess
+				} else if resp == nil || resp.StatusCode != http.StatusNotFound {
+					owner.Status = ghcollected.CodeownerUnresolved
+				}
+			}
+		case ghcollected.CodeownerTypeTeam:
+			if teams == nil {
+				res, err := pagination.New[*github.Team](rc.Client.Client().Repositories.ListTeams, &github.ListOptions{}).Sync(rc.Context, org, repo.Repository.Name)
+				if err != nil {
+					log.Printf("failed to collect the teams of %s: %s", collectors.FullRepoName(org, repo.Repository.Name), err)
+					return
+				}
+				teams = make(map[string]string)
+				for _, team := range res.Collected {
+					teams[strings.ToLower(org+"/"+team.GetSlug())] = team.GetPermission()
+				}
+			}
+			permission, ok := teams[strings.ToLower(strings.TrimPrefix(owner.Name, "@"))]
+			switch {
+			case !ok:
+				owner.Status = rc.teamOwnerStatus(owner.Name)
+			case permission == "push" || permission == "maintain" || permission == "admin":
+				owner.Status = ghcollected.CodeownerValid
+			default:
+				owner.Status = ghcollected.CodeownerNoWriteAccess
+			}
+		}
+	}
+}
+
+// teamOwnerStatus tells whether a team that has no access to the repository exists
+func (rc *repositoryCollector) teamOwnerStatus(name string) string {
+	org, slug, _ := strings.Cut(strings.TrimPrefix(name, "@"), "/")
+	_, resp, err := rc.Client.Client().Teams.GetTeamBySlug(rc.Context, org, slug)
+	switch {
+	case err == nil:
+		return ghcollected.CodeownerNoWriteAccess
+	case resp != nil && resp.StatusCode == http.StatusNotFound:
+		return ghcollected.CodeownerNotFound
+	default:
+		return ghcollected.CodeownerUnresolved
+	}
+}
+
 // fixBranchProtectionInfo fixes the branch protection info for the repository,
 // to reflect whether there is no branch protection, or just no permission to fetch the info.
 func (rc *repositoryCollector) fixBranchProtectionInfo(repository ghcollected.Repository, org string) (ghcollected.Repository, error) {
@@ -434,12 +1038,23 @@ func (rc *repositoryCollector) fixBranchProtectionInfo(repository ghcollected.Re
 		return repository, nil // branch protection info already available
 	}
 
-	repoName := repository.Repository.Name
-	branchName := *repository.Repository.DefaultBranchRef.Name
-	_, _, err := rc.Client.Client().Repositories.GetBranchProtection(rc.Context, org, repoName, branchName)
+	noPermission, err := checkBranchProtectionPermission(rc.Context, rc.Client, org, repository.Repository.Name,
+		*repository.Repository.DefaultBranchRef.Name)
+	if err != nil {
+		return repository, err
+	}
+	repository.NoBranchProtectionPermission = noPermission
+
+	return repository, nil
+}
+
+// checkBranchProtectionPermission tells whether a branch with no branch protection info
+// is really not protected, or there is just no permission to fetch the info.
+func checkBranchProtectionPermission(ctx context.Context, client *ghclient.Client, org string, repoName string, branchName string) (noPermission bool, err error) {
+	_, _, err = client.Client().Repositories.GetBranchProtection(ctx, org, repoName, branchName)
 	if err == nil {
 		log.Printf("inconsistent permissions (GitHub bug): graphQL query failed, but branch protection info is available. Ignoring\n")
-		return repository, nil
+		return false, nil
 	}
 
 	isNoPermErr := func(err error) bool {
@@ -451,14 +1066,13 @@ func (rc *repositoryCollector) fixBranchProtectionInfo(repository ghcollected.Re
 
 	switch {
 	case isNoPermErr(err):
-		repository.NoBranchProtectionPermission = true
+		return true, nil
 	case err == github.ErrBranchNotProtected:
 		// Already the default value for the NoBranchProtectionPerm & BranchProtectionRule fields
+		return false, nil
 	default: // Any other error is an operational error
-		return repository, err
+		return false, err
 	}
-
-	return repository, nil
 }
 
 func (rc *repositoryCollector) checkMissingPermissions(repo ghcollected.Repository, entityName string, repoContext *repositoryContext) []collectors.MissingPermission {
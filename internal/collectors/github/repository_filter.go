@@ -0,0 +1,48 @@
+package github
+
+import (
+	"context"
+	"log"
+
+	ghclient "github.com/Legit-Labs/legitify/internal/clients/github"
+	ghcollected "github.com/Legit-Labs/legitify/internal/collected/github"
+	"github.com/Legit-Labs/legitify/internal/collectors"
+	"github.com/Legit-Labs/legitify/internal/common/repo_filter"
+	"github.com/Legit-Labs/legitify/internal/context_utils"
+)
+
+// repositoryFilter selects the repositories to collect, before their expensive enrichment.
+// The custom properties are fetched only when the filter depends on them.
+type repositoryFilter struct {
+	properties *organizationPropertyValues
+	filter     *repo_filter.Filter
+}
+
+func newRepositoryFilter(ctx context.Context, client *ghclient.Client) *repositoryFilter {
+	return &repositoryFilter{
+		properties: sharedPropertyValues(client),
+		filter:     context_utils.GetRepositoryFilter(ctx),
+	}
+}
+
+func (f *repositoryFilter) matches(login string, repository *ghcollected.GitHubQLRepository) bool {
+	if f.filter.IsEmpty() {
+		return true
+	}
+
+	metadata := repository.Classification()
+
+	if f.filter.NeedsProperties() {
+		values, err := f.properties.get(login, repository.Name)
+		if err != nil {
+			log.Printf("failed to collect custom properties for %s: %s", collectors.FullRepoName(login, repository.Name), err)
+			return false
+		}
+		metadata.Properties = make(map[string][]string)
+		for _, value := range values {
+			metadata.Properties[value.PropertyName] = value.Values
+		}
+	}
+
+	return f.filter.Matches(metadata)
+}
@@ -0,0 +1,113 @@
+package github
+
+import (
+	"log"
+	"sync"
+
+	ghclient "github.com/Legit-Labs/legitify/internal/clients/github"
+	"github.com/Legit-Labs/legitify/internal/clients/github/types"
+	ghcollected "github.com/Legit-Labs/legitify/internal/collected/github"
+	"github.com/Legit-Labs/legitify/internal/collectors"
+	"github.com/Legit-Labs/legitify/internal/common/namespace"
+	"github.com/Legit-Labs/legitify/internal/common/permissions"
+)
+
+// organizationRulesets caches the rulesets of the organizations and of the analyzed enterprises, since they apply to many repositories and branches
+type organizationRulesets struct {
+	client     *ghclient.Client
+	properties *organizationPropertyValues
+	lock       sync.Mutex
+	byOrg      map[string][]*types.Ruleset
+	enterprise []*types.Ruleset
+	// enterpriseMissing holds the missing permissions of the enterprise rulesets, until they are issued with the first organization
+	enterpriseMissing []collectors.MissingPermission
+	enterpriseOnce    sync.Once
+}
+
+func newOrganizationRulesets(client *ghclient.Client) *organizationRulesets {
+	return &organizationRulesets{
+		client:     client,
+		properties: sharedPropertyValues(client),
+		byOrg:      make(map[string][]*types.Ruleset),
+	}
+}
+
+// get returns the rulesets of the organization together with the rulesets of the enterprises,
+// and the missing permissions the first time they can't be read
+func (o *organizationRulesets) get(org string, roles []permissions.Role) ([]*types.Ruleset, []collectors.MissingPermission) {
+	if !isOrganizationRepository(roles) {
+		return nil, nil
+	}
+
+	o.enterpriseOnce.Do(o.collectEnterpriseRulesets)
+
+	o.lock.Lock()
+	defer o.lock.Unlock()
+
+	missing := o.enterpriseMissing
+	o.enterpriseMissing = nil
+
+	if rulesets, ok := o.byOrg[org]; ok {
+		return rulesets, missing
+	}
+
+	rulesets, err := o.client.GetOrganizationRulesets(org)
+	if err != nil {
+		log.Printf("failed to collect rulesets for %s: %s", org, err)
+		missing = append(missing, collectors.NewMissingPermission(permissions.OrgAdmin, org,
+			"Cannot read organization rulesets, their protection is taken into account only when reported by the repository", namespace.Organization))
+		rulesets = nil
+	}
+	rulesets = append(rulesets, o.enterprise...)
+	o.byOrg[org] = rulesets
+
+	return rulesets, missing
+}
+
+func (o *organizationRulesets) collectEnterpriseRulesets() {
+	for _, enterprise := range o.client.Enterprises() {
+		rulesets, err := o.client.GetEnterpriseRulesets(enterprise)
+		if err != nil {
+			log.Printf("failed to collect rulesets for enterprise %s: %s", enterprise, err)
+			o.enterpriseMissing = append(o.enterpriseMissing, collectors.NewMissingPermission(permissions.EnterpriseAdmin, enterprise,
+				"Cannot read enterprise rulesets, their protection is taken into account only when reported by the repository", namespace.Enterprise))
+			continue
+		}
+		o.enterprise = append(o.enterprise, rulesets...)
+	}
+}
+
+// rulesetTarget builds the target that the organization rulesets are matched against.
+// The repository properties are fetched only when a ruleset depends on them.
+func (o *organizationRulesets) rulesetTarget(org string, repository *ghcollected.GitHubQLRepository, rulesets []*types.Ruleset) ghcollected.RulesetTarget {
+	target := ghcollected.RulesetTarget{
+		Organization: org,
+		Repository:   repository.Name,
+		RepositoryID: repository.DatabaseId,
+	}
+
+	for _, ruleset := range rulesets {
+		if ruleset.Conditions == nil || ruleset.Conditions.RepositoryProperty == nil {
+			continue
+		}
+
+		properties, err := o.properties.get(org, repository.Name)
+		if err != nil {
+			log.Printf("failed to collect custom properties for %s: %s", collectors.FullRepoName(org, repository.Name), err)
+		}
+		target.Properties = properties
+		break
+	}
+
+	return target
+}
+
+func isOrganizationRepository(roles []permissions.Role) bool {
+	for _, role := range roles {
+		if permissions.IsOrgRole(role) {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,224 @@
+package github
+
+import (
+	"context"
+	"log"
+	"sync"
+	"sync/atomic"
+
+	ghclient "github.com/Legit-Labs/legitify/internal/clients/github"
+	"github.com/Legit-Labs/legitify/internal/clients/github/pagination"
+	ghcollected "github.com/Legit-Labs/legitify/internal/collected/github"
+	"github.com/Legit-Labs/legitify/internal/collectors"
+	"github.com/Legit-Labs/legitify/internal/common/group_waiter"
+	"github.com/Legit-Labs/legitify/internal/common/namespace"
+	"github.com/Legit-Labs/legitify/internal/common/permissions"
+	"github.com/google/go-github/v53/github"
+)
+
+type runnerCollector struct {
+	collectors.BaseCollector
+	client       *ghclient.Client
+	context      context.Context
+	orgLock      sync.Mutex
+	runnersByOrg map[string][]ghcollected.Runner
+}
+
+func NewRunnerCollector(ctx context.Context, client *ghclient.Client) collectors.Collector {
+	c := &runnerCollector{
+		BaseCollector: collectors.NewBaseCollector(namespace.Runner),
+		client:        client,
+		context:       ctx,
+		runnersByOrg:  make(map[string][]ghcollected.Runner),
+	}
+	return c
+}
+
+func (c *runnerCollector) CollectTotalEntities() int {
+	gw := group_waiter.New()
+	orgs, err := c.client.CollectOrganizations()
+	if err != nil {
+		log.Printf("failed to collect organizations %s", err)
+		return 0
+	}
+
+	var totalCount atomic.Int64
+	for _, org := range orgs {
+		org := org
+		gw.Do(func() {
+			runners := c.collectForOrg(org)
+			totalCount.Add(int64(len(runners)))
+		})
+	}
+	gw.Wait()
+
+	return int(totalCount.Load())
+}
+
+func (c *runnerCollector) Collect() collectors.SubCollectorChannels {
+	return c.WrappedCollection(func() {
+		orgs, err := c.client.CollectOrganizations()
+
+		if err != nil {
+			log.Printf("failed to collect organizations %s", err)
+			return
+		}
+
+		for _, org := range orgs {
+			for _, runner := range c.collectForOrg(org) {
+				c.CollectionChangeByOne()
+				c.CollectData(org, runner, runner.CanonicalLink(), []permissions.Role{org.Role})
+			}
+		}
+	})
+}
+
+func (c *runnerCollector) collectForOrg(org ghcollected.ExtendedOrg) []ghcollected.Runner {
+	c.orgLock.Lock()
+	defer c.orgLock.Unlock()
+
+	if runners, ok := c.runnersByOrg[org.Name()]; ok {
+		return runners
+	}
+
+	runners := c.collectOrganizationRunners(org)
+	runners = append(runners, c.collectRepositoryRunners(org)...)
+	c.runnersByOrg[org.Name()] = runners
+
+	return runners
+}
+
+// collectOrganizationRunners collects the runners of the organization along with the group they are assigned to
+func (c *runnerCollector) collectOrganizationRunners(org ghcollected.ExtendedOrg) []ghcollected.Runner {
+	runners, err := c.client.GetOrganizationRunners(org.Name())
+	if err != nil {
+		perm := collectors.NewMissingPermission(permissions.OrgAdmin, org.Name(),
+			"Cannot read organization self-hosted runners", namespace.Organization)
+		c.IssueMissingPermissions(perm)
+		return nil
+	}
+	if len(runners) == 0 {
+		return nil
+	}
+
+	groupsMapper := func(rg *github.RunnerGroups) []*github.RunnerGroup {
+		if rg == nil {
+			return []*github.RunnerGroup{}
+		}
+		return rg.RunnerGroups
+	}
+	groups, err := pagination.NewMapper(c.client.Client().Actions.ListOrganizationRunnerGroups, nil, groupsMapper).Sync(c.context, org.Name())
+	if err != nil {
+		log.Printf("failed to collect runner groups for %s: %s", org.Name(), err)
+	}
+
+	type groupAccess struct {
+		group        *github.RunnerGroup
+		repositories []*ghcollected.RunnerRepository
+	}
+	groupByRunner := make(map[int64]groupAccess)
+	for _, group := range groups.Collected {
+		access := groupAccess{group: group, repositories: []*ghcollected.RunnerRepository{}}
+		if group.GetVisibility() == "selected" {
+			access.repositories = c.collectGroupRepositories(org, group)
+		}
+
+		runnersMapper := func(r *github.Runners) []*github.Runner {
+			if r == nil {
+				return []*github.Runner{}
+			}
+			return r.Runners
+		}
+		groupRunners, err := pagination.NewMapper(c.client.Client().Actions.ListRunnerGroupRunners, &github.ListOptions{}, runnersMapper).
+			Sync(c.context, org.Name(), group.GetID())
+		if err != nil {
+			log.Printf("failed to collect the runners of group %s in %s: %s", group.GetName(), org.Name(), err)
+			continue
+		}
+		for _, runner := range groupRunners.Collected {
+			groupByRunner[runner.GetID()] = access
+		}
+	}
+
+	result := make([]ghcollected.Runner, 0, len(runners))
+	for _, runner := range runners {
+		access := groupByRunner[runner.GetID()]
+		result = append(result, ghcollected.Runner{
+			Organization:        org,
+			Runner:              runner,
+			RunnerGroup:         access.group,
+			AllowedRepositories: access.repositories,
+		})
+	}
+
+	return result
+}
+
+func (c *runnerCollector) collectGroupRepositories(org ghcollected.ExtendedOrg, group *github.RunnerGroup) []*ghcollected.RunnerRepository {
+	mapper := func(r *github.ListRepositories) []*github.Repository {
+		if r == nil {
+			return []*github.Repository{}
+		}
+		return r.Repositories
+	}
+	repositories, err := pagination.NewMapper(c.client.Client().Actions.ListRepositoryAccessRunnerGroup, &github.ListOptions{}, mapper).
+		Sync(c.context, org.Name(), group.GetID())
+	if err != nil {
+		log.Printf("failed to collect the repositories of runner group %s in %s: %s", group.GetName(), org.Name(), err)
+	}
+
+	result := []*ghcollected.RunnerRepository{}
+	for _, repository := range repositories.Collected {
+		result = append(result, &ghcollected.RunnerRepository{
+			Name:    repository.GetName(),
+			Private: repository.GetPrivate(),
+		})
+	}
+
+	return result
+}
+
+// collectRepositoryRunners collects the runners that are registered directly to the repositories of the organization
+func (c *runnerCollector) collectRepositoryRunners(org ghcollected.ExtendedOrg) []ghcollected.Runner {
+	repositories, err := pagination.New[*github.Repository](c.client.Client().Repositories.ListByOrg, &github.RepositoryListByOrgOptions{}).Sync(c.context, org.Name())
+	if err != nil {
+		log.Printf("failed to collect repositories for %s: %s", org.Name(), err)
+		return nil
+	}
+
+	var lock sync.Mutex
+	var result []ghcollected.Runner
+	gw := group_waiter.New()
+	for _, repository := range repositories.Collected {
+		repository := repository
+		if repository.GetArchived() {
+			continue
+		}
+		gw.Do(func() {
+			runners, err := c.client.GetRepositoryRunners(org.Name(), repository.GetName())
+			if err != nil {
+				perm := collectors.NewMissingPermission(permissions.RepoAdmin, collectors.FullRepoName(org.Name(), repository.GetName()),
+					"Cannot read repository self-hosted runners", namespace.Repository)
+				c.IssueMissingPermissions(perm)
+				return
+			}
+
+			lock.Lock()
+			defer lock.Unlock()
+			for _, runner := range runners {
+				result = append(result, ghcollected.Runner{
+					Organization: org,
+					Runner:       runner,
+					Repository: &ghcollected.RunnerRepository{
+						Name:    repository.GetName(),
+						Private: repository.GetPrivate(),
+					},
+					AllowedRepositories: []*ghcollected.RunnerRepository{},
+				})
+			}
+		})
+	}
+	gw.Wait()
+
+	return result
+}
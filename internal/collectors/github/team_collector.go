@@ -0,0 +1,163 @@
+package github
+
+import (
+	"context"
+	"log"
+	"sync"
+	"sync/atomic"
+
+	ghclient "github.com/Legit-Labs/legitify/internal/clients/github"
+	"github.com/Legit-Labs/legitify/internal/clients/github/pagination"
+	ghcollected "github.com/Legit-Labs/legitify/internal/collected/github"
+	"github.com/Legit-Labs/legitify/internal/collectors"
+	"github.com/Legit-Labs/legitify/internal/common/group_waiter"
+	"github.com/Legit-Labs/legitify/internal/common/namespace"
+	"github.com/Legit-Labs/legitify/internal/common/permissions"
+	"github.com/google/go-github/v53/github"
+)
+
+type teamCollector struct {
+	collectors.BaseCollector
+	client     *ghclient.Client
+	context    context.Context
+	orgLock    sync.Mutex
+	teamsByOrg map[string][]*github.Team
+}
+
+func NewTeamCollector(ctx context.Context, client *ghclient.Client) collectors.Collector {
+	c := &teamCollector{
+		BaseCollector: collectors.NewBaseCollector(namespace.Team),
+		client:        client,
+		context:       ctx,
+		teamsByOrg:    make(map[string][]*github.Team),
+	}
+	return c
+}
+
+func (c *teamCollector) collectForOrg(org ghcollected.ExtendedOrg) []*github.Team {
+	c.orgLock.Lock()
+	defer c.orgLock.Unlock()
+
+	if teams, ok := c.teamsByOrg[org.Name()]; ok {
+		return teams
+	}
+
+	c.teamsByOrg[org.Name()] = nil
+	result, err := pagination.New[*github.Team](c.client.Client().Teams.ListTeams, &github.ListOptions{}).Sync(c.context, org.Name())
+	if err != nil {
+		perm := collectors.NewMissingPermission(permissions.OrgRead, org.Name(),
+			"Cannot read organization teams", namespace.Organization)
+		c.IssueMissingPermissions(perm)
+		return nil
+	}
+	if org.Role != permissions.OrgRoleOwner {
+		perm := collectors.NewMissingPermission(permissions.OrgAdmin, org.Name(),
+			"Cannot read the secret teams the user is not a member of", namespace.Organization)
+		c.IssueMissingPermissions(perm)
+	}
+	c.teamsByOrg[org.Name()] = result.Collected
+
+	return c.teamsByOrg[org.Name()]
+}
+
+func (c *teamCollector) CollectTotalEntities() int {
+	gw := group_waiter.New()
+	orgs, err := c.client.CollectOrganizations()
+	if err != nil {
+		log.Printf("failed to collect organizations %s", err)
+		return 0
+	}
+
+	var totalCount atomic.Int64
+	for _, org := range orgs {
+		org := org
+		gw.Do(func() {
+			teams := c.collectForOrg(org)
+			totalCount.Add(int64(len(teams)))
+		})
+	}
+	gw.Wait()
+
+	return int(totalCount.Load())
+}
+
+func (c *teamCollector) Collect() collectors.SubCollectorChannels {
+	return c.WrappedCollection(func() {
+		orgs, err := c.client.CollectOrganizations()
+
+		if err != nil {
+			log.Printf("failed to collect organizations %s", err)
+			return
+		}
+
+		gw := group_waiter.New()
+		for _, org := range orgs {
+			org := org
+			for _, team := range c.collectForOrg(org) {
+				team := team
+				gw.Do(func() {
+					defer c.CollectionChangeByOne()
+
+					entity, err := c.collectTeam(org, team)
+					if err != nil {
+						log.Printf("failed to collect team %s/%s: %s", org.Name(), team.GetSlug(), err)
+						return
+					}
+
+					c.CollectData(org,
+						entity,
+						entity.CanonicalLink(),
+						[]permissions.Role{org.Role})
+				})
+			}
+		}
+		gw.Wait()
+	})
+}
+
+func (c *teamCollector) collectTeam(org ghcollected.ExtendedOrg, team *github.Team) (ghcollected.Team, error) {
+	entity := ghcollected.Team{
+		Organization: org,
+		Team:         team,
+		Members:      []*ghcollected.TeamMember{},
+		Repositories: []*ghcollected.TeamRepository{},
+		IdpGroups:    []string{},
+	}
+
+	for _, role := range []string{ghcollected.TeamRoleMaintainer, ghcollected.TeamRoleMember} {
+		opts := &github.TeamListTeamMembersOptions{Role: role}
+		members, err := pagination.New[*github.User](c.client.Client().Teams.ListTeamMembersBySlug, opts).Sync(c.context, org.Name(), team.GetSlug())
+		if err != nil {
+			return entity, err
+		}
+		for _, member := range members.Collected {
+			entity.Members = append(entity.Members, &ghcollected.TeamMember{
+				Login: member.GetLogin(),
+				Role:  role,
+			})
+		}
+	}
+
+	repositories, err := pagination.New[*github.Repository](c.client.Client().Teams.ListTeamReposBySlug, &github.ListOptions{}).Sync(c.context, org.Name(), team.GetSlug())
+	if err != nil {
+		return entity, err
+	}
+	for _, repository := range repositories.Collected {
+		entity.Repositories = append(entity.Repositories, &ghcollected.TeamRepository{
+			Name:       repository.GetName(),
+			Private:    repository.GetPrivate(),
+			Archived:   repository.GetArchived(),
+			Permission: ghcollected.TeamRepositoryPermission(repository.Permissions),
+		})
+	}
+
+	// team synchronization is only available with an identity provider in GitHub Enterprise Cloud
+	groups, _, err := c.client.Client().Teams.ListIDPGroupsForTeamBySlug(c.context, org.Name(), team.GetSlug())
+	if err == nil {
+		for _, group := range groups.Groups {
+			entity.IdpGroups = append(entity.IdpGroups, group.GetGroupName())
+		}
+	}
+
+	return entity, nil
+}
@@ -0,0 +1,99 @@
+package gitlab
+
+import (
+	"sync"
+
+	"github.com/Legit-Labs/legitify/internal/clients/gitlab"
+	"github.com/Legit-Labs/legitify/internal/clients/gitlab/pagination"
+	"github.com/Legit-Labs/legitify/internal/collected/gitlab_collected"
+	gitlab2 "github.com/xanzy/go-gitlab"
+)
+
+func projectAccessTokens(client *gitlab.Client, pid int) ([]*gitlab_collected.AccessToken, error) {
+	res, err := pagination.New[*gitlab2.ProjectAccessToken](client.Client().ProjectAccessTokens.ListProjectAccessTokens, nil).Sync(pid)
+	if err != nil {
+		return nil, err
+	}
+
+	tokens := []*gitlab_collected.AccessToken{}
+	for _, token := range res.Collected {
+		tokens = append(tokens, gitlab_collected.NewProjectAccessToken(token))
+	}
+
+	return tokens, nil
+}
+
+func groupAccessTokens(client *gitlab.Client, gid int) ([]*gitlab_collected.AccessToken, error) {
+	res, err := pagination.New[*gitlab2.GroupAccessToken](client.Client().GroupAccessTokens.ListGroupAccessTokens, nil).Sync(gid)
+	if err != nil {
+		return nil, err
+	}
+
+	tokens := []*gitlab_collected.AccessToken{}
+	for _, token := range res.Collected {
+		tokens = append(tokens, gitlab_collected.NewGroupAccessToken(token))
+	}
+
+	return tokens, nil
+}
+
+// userTokenResolver collects the personal access tokens and impersonation tokens of users, which requires admin access.
+// The personal access tokens of the instance are listed once and attached by user, and the tokens of every user are cached,
+// since a user is collected once for every group it is a member of.
+type userTokenResolver struct {
+	client   *gitlab.Client
+	personal sync.Map
+	users    sync.Map
+}
+
+func newUserTokenResolver(client *gitlab.Client) *userTokenResolver {
+	return &userTokenResolver{
+		client: client,
+	}
+}
+
+func (r *userTokenResolver) userAccessTokens(uid int) ([]*gitlab_collected.AccessToken, error) {
+	return cached(&r.users, uid, func() ([]*gitlab_collected.AccessToken, error) {
+		personal, err := r.personalAccessTokens()
+		if err != nil {
+			return nil, err
+		}
+		impersonation, err := pagination.New[*gitlab2.ImpersonationToken](r.client.Client().Users.GetAllImpersonationTokens, nil).Sync(uid)
+		if err != nil {
+			return nil, err
+		}
+
+		// impersonation tokens are listed as personal access tokens as well
+		impersonationIDs := make(map[int]bool)
+		tokens := []*gitlab_collected.AccessToken{}
+		for _, token := range impersonation.Collected {
+			impersonationIDs[token.ID] = true
+			tokens = append(tokens, gitlab_collected.NewImpersonationToken(token))
+		}
+		for _, token := range personal[uid] {
+			if !impersonationIDs[token.ID] {
+				tokens = append(tokens, gitlab_collected.NewPersonalAccessToken(token))
+			}
+		}
+
+		return tokens, nil
+	})
+}
+
+// personalAccessTokens lists the personal access tokens of the instance once, by the user they belong to
+func (r *userTokenResolver) personalAccessTokens() (map[int][]*gitlab2.PersonalAccessToken, error) {
+	return cached(&r.personal, 0, func() (map[int][]*gitlab2.PersonalAccessToken, error) {
+		res, err := pagination.New[*gitlab2.PersonalAccessToken](r.client.Client().PersonalAccessTokens.ListPersonalAccessTokens,
+			&gitlab2.ListPersonalAccessTokensOptions{}).Sync()
+		if err != nil {
+			return nil, err
+		}
+
+		byUser := make(map[int][]*gitlab2.PersonalAccessToken)
+		for _, token := range res.Collected {
+			byUser[token.UserID] = append(byUser[token.UserID], token)
+		}
+
+		return byUser, nil
+	})
+}
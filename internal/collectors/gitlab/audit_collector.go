@@ -0,0 +1,96 @@
+package gitlab
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"github.com/Legit-Labs/legitify/internal/clients/gitlab"
+	"github.com/Legit-Labs/legitify/internal/clients/gitlab/pagination"
+	"github.com/Legit-Labs/legitify/internal/collected/gitlab_collected"
+	"github.com/Legit-Labs/legitify/internal/collectors"
+	"github.com/Legit-Labs/legitify/internal/common/group_waiter"
+	"github.com/Legit-Labs/legitify/internal/common/namespace"
+	"github.com/Legit-Labs/legitify/internal/common/permissions"
+	"github.com/Legit-Labs/legitify/internal/context_utils"
+	gitlab2 "github.com/xanzy/go-gitlab"
+)
+
+const (
+	auditEventsEffect        = "Cannot read the group audit events"
+	auditEventsPremiumEffect = "Cannot read the group audit events without a Premium license"
+)
+
+type auditCollector struct {
+	collectors.BaseCollector
+	Client  *gitlab.Client
+	Context context.Context
+	since   time.Time
+}
+
+func NewAuditCollector(ctx context.Context, client *gitlab.Client) collectors.Collector {
+	c := &auditCollector{
+		BaseCollector: collectors.NewBaseCollector(namespace.Audit),
+		Client:        client,
+		Context:       ctx,
+		since:         context_utils.GetAuditSince(ctx),
+	}
+	return c
+}
+
+func (c *auditCollector) CollectTotalEntities() int {
+	groups, err := c.Client.Groups()
+	if err != nil {
+		log.Printf("failed to collect groups %s", err)
+		return 0
+	}
+
+	return len(groups)
+}
+
+func (c *auditCollector) Collect() collectors.SubCollectorChannels {
+	return c.WrappedCollection(func() {
+		groups, err := c.Client.Groups()
+		if err != nil {
+			log.Printf("failed to collect groups %s", err)
+			return
+		}
+
+		gw := group_waiter.New()
+		for _, g := range groups {
+			g := g
+			gw.Do(func() {
+				defer c.CollectionChangeByOne()
+
+				// group audit events require the group owner role and a premium license
+				isPremium := c.Client.IsGroupPremium(g.FullPath)
+				if !isPremium {
+					c.IssueMissingPermissions(collectors.NewMissingPermission(permissions.GroupRoleOwner, g.FullPath, auditEventsPremiumEffect, namespace.Audit))
+					return
+				}
+
+				opts := &gitlab2.ListAuditEventsOptions{CreatedAfter: &c.since}
+				events, err := pagination.New[*gitlab2.AuditEvent](c.Client.Client().AuditEvents.ListGroupAuditEvents, opts).Sync(g.ID)
+				if err != nil {
+					log.Printf("failed to query group audit events: %d - %s", g.ID, g.Name)
+					c.IssueMissingPermissions(collectors.NewMissingPermission(permissions.GroupRoleOwner, g.FullPath, auditEventsEffect, namespace.Audit))
+					return
+				}
+
+				entity := gitlab_collected.AuditLog{
+					Group:  g,
+					Since:  c.since.Format(time.RFC3339),
+					Events: events.Collected,
+				}
+				if entity.Events == nil {
+					entity.Events = []*gitlab2.AuditEvent{}
+				}
+
+				c.CollectDataWithContext(entity, entity.CanonicalLink(),
+					newCollectionContext(g, []permissions.Role{permissions.GroupRoleOwner}, isPremium))
+			})
+		}
+
+		gw.Wait()
+	})
+}
@@ -0,0 +1,75 @@
+package gitlab
+
+import (
+	"log"
+	"sync"
+
+	"github.com/Legit-Labs/legitify/internal/clients/gitlab"
+	"github.com/Legit-Labs/legitify/internal/clients/gitlab/pagination"
+	"github.com/Legit-Labs/legitify/internal/collected/gitlab_collected"
+	gitlab2 "github.com/xanzy/go-gitlab"
+)
+
+func projectVariables(client *gitlab.Client, pid int) ([]*gitlab_collected.CIVariable, error) {
+	res, err := pagination.New[*gitlab2.ProjectVariable](client.Client().ProjectVariables.ListVariables, nil).Sync(pid)
+	if err != nil {
+		return nil, err
+	}
+
+	variables := []*gitlab_collected.CIVariable{}
+	for _, v := range res.Collected {
+		variables = append(variables, gitlab_collected.NewCIVariable(v.Key, v.VariableType, v.Protected, v.Masked, v.Raw, v.EnvironmentScope))
+	}
+
+	return variables, nil
+}
+
+func groupVariables(client *gitlab.Client, gid int) ([]*gitlab_collected.CIVariable, error) {
+	res, err := pagination.New[*gitlab2.GroupVariable](client.Client().GroupVariables.ListVariables, nil).Sync(gid)
+	if err != nil {
+		return nil, err
+	}
+
+	variables := []*gitlab_collected.CIVariable{}
+	for _, v := range res.Collected {
+		variables = append(variables, gitlab_collected.NewCIVariable(v.Key, v.VariableType, v.Protected, v.Masked, v.Raw, v.EnvironmentScope))
+	}
+
+	return variables, nil
+}
+
+const instanceRunnerType = "instance_type"
+
+// runnerResolver adds the configuration of the runners.
+// Runners are shared by many projects and groups, so their details are cached.
+type runnerResolver struct {
+	client  *gitlab.Client
+	details sync.Map
+}
+
+func newRunnerResolver(client *gitlab.Client) *runnerResolver {
+	return &runnerResolver{
+		client: client,
+	}
+}
+
+// withDetails adds the configuration of every runner.
+// The details of instance runners are available only to admins, so they are missing otherwise.
+func (r *runnerResolver) withDetails(runners []*gitlab2.Runner) []*gitlab_collected.CIRunner {
+	result := []*gitlab_collected.CIRunner{}
+	for _, runner := range runners {
+		var details *gitlab2.RunnerDetails
+		if runner.RunnerType != instanceRunnerType || r.client.IsAdmin() {
+			details, _ = cached(&r.details, runner.ID, func() (*gitlab2.RunnerDetails, error) {
+				details, _, err := r.client.Client().Runners.GetRunnerDetails(runner.ID)
+				if err != nil {
+					log.Printf("failed to get the details of runner %d: %v", runner.ID, err)
+				}
+				return details, err
+			})
+		}
+		result = append(result, gitlab_collected.NewCIRunner(runner, details))
+	}
+
+	return result
+}
@@ -5,6 +5,7 @@ import (
 	"log"
 
 	"github.com/Legit-Labs/legitify/internal/clients/gitlab"
+	"github.com/Legit-Labs/legitify/internal/clients/gitlab/pagination"
 	"github.com/Legit-Labs/legitify/internal/collected/gitlab_collected"
 	"github.com/Legit-Labs/legitify/internal/collectors"
 	"github.com/Legit-Labs/legitify/internal/common/group_waiter"
@@ -16,8 +17,10 @@ import (
 
 type groupCollector struct {
 	collectors.BaseCollector
-	Client  *gitlab.Client
-	Context context.Context
+	Client     *gitlab.Client
+	Context    context.Context
+	membership *membershipResolver
+	runners    *runnerResolver
 }
 
 func NewGroupCollector(ctx context.Context, client *gitlab.Client) collectors.Collector {
@@ -25,6 +28,8 @@ func NewGroupCollector(ctx context.Context, client *gitlab.Client) collectors.Co
 		BaseCollector: collectors.NewBaseCollector(namespace.Organization),
 		Client:        client,
 		Context:       ctx,
+		membership:    newMembershipResolver(client),
+		runners:       newRunnerResolver(client),
 	}
 	return c
 }
@@ -66,9 +71,45 @@ func (c *groupCollector) Collect() collectors.SubCollectorChannels {
 					log.Printf("failed to query group hooks: %d - %s", g.ID, g.Name)
 				}
 
+				variables, err := groupVariables(c.Client, fullGroup.ID)
+				if err != nil {
+					log.Printf("failed to query group CI/CD variables: %d - %s", g.ID, g.Name)
+				}
+
+				var runners []*gitlab_collected.CIRunner
+				res, err := pagination.New[*gitlab2.Runner](c.Client.Client().Runners.ListGroupsRunners, nil).Sync(fullGroup.ID)
+				if err != nil {
+					log.Printf("failed to query group runners: %d - %s", g.ID, g.Name)
+				} else {
+					runners = c.runners.withDetails(res.Collected)
+				}
+
+				deployTokens, err := pagination.New[*gitlab2.DeployToken](c.Client.Client().DeployTokens.ListGroupDeployTokens, nil).Sync(fullGroup.ID)
+				if err != nil {
+					log.Printf("failed to query group deploy tokens: %d - %s", g.ID, g.Name)
+				}
+
+				accessTokens, err := groupAccessTokens(c.Client, fullGroup.ID)
+				if err != nil {
+					log.Printf("failed to query group access tokens: %d - %s", g.ID, g.Name)
+				}
+
 				entity := gitlab_collected.Organization{
-					Group: fullGroup,
-					Hooks: hooks,
+					Group:        fullGroup,
+					Hooks:        hooks,
+					Variables:    variables,
+					Runners:      runners,
+					DeployTokens: deployTokens.Collected,
+					AccessTokens: accessTokens,
+				}
+
+				resolved, err := c.membership.resolveGroup(fullGroup)
+				if err != nil {
+					log.Printf("failed to resolve group effective membership: %d - %s", g.ID, g.Name)
+				} else {
+					entity.Hierarchy = resolved.hierarchy
+					entity.EffectiveSettings = resolved.settings
+					entity.EffectiveMembers = resolved.members
 				}
 
 				c.CollectDataWithContext(entity, g.WebURL,
@@ -0,0 +1,164 @@
+package gitlab
+
+import (
+	"sync"
+
+	"github.com/Legit-Labs/legitify/internal/clients/gitlab"
+	"github.com/Legit-Labs/legitify/internal/clients/gitlab/pagination"
+	"github.com/Legit-Labs/legitify/internal/collected/gitlab_collected"
+	gitlab2 "github.com/xanzy/go-gitlab"
+)
+
+// membershipResolver resolves the group hierarchy and the effective members of projects and groups.
+// Projects and groups share their ancestors, so the groups and their members are cached.
+type membershipResolver struct {
+	client        *gitlab.Client
+	groups        sync.Map
+	directMembers sync.Map
+	allMembers    sync.Map
+}
+
+type resolvedMembership struct {
+	hierarchy []*gitlab_collected.HierarchyGroup
+	settings  *gitlab_collected.EffectiveSettings
+	members   []*gitlab_collected.EffectiveMember
+}
+
+type cacheEntry[T any] struct {
+	once  sync.Once
+	value T
+	err   error
+}
+
+// cached fetches the value of key once, even if it is requested concurrently
+func cached[T any](cache *sync.Map, key interface{}, fetch func() (T, error)) (T, error) {
+	loaded, _ := cache.LoadOrStore(key, &cacheEntry[T]{})
+	entry := loaded.(*cacheEntry[T])
+	entry.once.Do(func() {
+		entry.value, entry.err = fetch()
+	})
+
+	return entry.value, entry.err
+}
+
+func newMembershipResolver(client *gitlab.Client) *membershipResolver {
+	return &membershipResolver{
+		client: client,
+	}
+}
+
+// resolveProject resolves the membership of a project, including the members of its ancestor groups and of the groups it was shared with
+func (r *membershipResolver) resolveProject(project *gitlab2.Project) (*resolvedMembership, error) {
+	var paths []string
+	if project.Namespace != nil && project.Namespace.Kind == "group" {
+		paths = gitlab_collected.HierarchyPaths(project.Namespace.FullPath)
+	}
+	ancestors, err := r.hierarchy(paths)
+	if err != nil {
+		return nil, err
+	}
+
+	direct, err := pagination.New[*gitlab2.ProjectMember](r.client.Client().ProjectMembers.ListProjectMembers, nil).Sync(project.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	membership := gitlab_collected.NewEffectiveMembership()
+	membership.AddProjectMembers(project.PathWithNamespace, direct.Collected)
+	if err = r.addGroups(membership, gitlab_collected.MembershipInherited, ancestors); err != nil {
+		return nil, err
+	}
+	for _, shared := range project.SharedWithGroups {
+		if err = r.addSharedGroup(membership, shared.GroupID, shared.GroupFullPath, shared.GroupAccessLevel); err != nil {
+			return nil, err
+		}
+	}
+
+	return newResolvedMembership(ancestors, membership), nil
+}
+
+// resolveGroup resolves the membership of a group, including the members of its ancestor groups and of the groups it was shared with
+func (r *membershipResolver) resolveGroup(group *gitlab2.Group) (*resolvedMembership, error) {
+	paths := gitlab_collected.HierarchyPaths(group.FullPath)
+	ancestors, err := r.hierarchy(paths[:len(paths)-1])
+	if err != nil {
+		return nil, err
+	}
+
+	membership := gitlab_collected.NewEffectiveMembership()
+	if err = r.addGroups(membership, gitlab_collected.MembershipInherited, ancestors); err != nil {
+		return nil, err
+	}
+	if err = r.addGroups(membership, gitlab_collected.MembershipDirect, []*gitlab2.Group{group}); err != nil {
+		return nil, err
+	}
+
+	return newResolvedMembership(append(ancestors, group), membership), nil
+}
+
+func newResolvedMembership(hierarchy []*gitlab2.Group, membership *gitlab_collected.EffectiveMembership) *resolvedMembership {
+	groups := []*gitlab_collected.HierarchyGroup{}
+	for _, group := range hierarchy {
+		groups = append(groups, gitlab_collected.NewHierarchyGroup(group))
+	}
+
+	return &resolvedMembership{
+		hierarchy: groups,
+		settings:  gitlab_collected.NewEffectiveSettings(groups),
+		members:   membership.Members(),
+	}
+}
+
+func (r *membershipResolver) hierarchy(paths []string) ([]*gitlab2.Group, error) {
+	groups := []*gitlab2.Group{}
+	for _, path := range paths {
+		path := path
+		group, err := cached(&r.groups, path, func() (*gitlab2.Group, error) {
+			group, _, err := r.client.Client().Groups.GetGroup(path, &gitlab2.GetGroupOptions{WithProjects: gitlab2.Bool(false)})
+			return group, err
+		})
+		if err != nil {
+			return nil, err
+		}
+		groups = append(groups, group)
+	}
+
+	return groups, nil
+}
+
+// addGroups adds the members of the groups, and the members of the groups they were shared with
+func (r *membershipResolver) addGroups(membership *gitlab_collected.EffectiveMembership, source string, groups []*gitlab2.Group) error {
+	for _, group := range groups {
+		members, err := r.groupMembers(&r.directMembers, r.client.Client().Groups.ListGroupMembers, group.ID)
+		if err != nil {
+			return err
+		}
+		membership.AddGroupMembers(source, group.FullPath, members, gitlab2.OwnerPermissions)
+
+		for _, shared := range group.SharedWithGroups {
+			if err = r.addSharedGroup(membership, shared.GroupID, shared.GroupFullPath, shared.GroupAccessLevel); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// addSharedGroup adds the members of a shared group, including its inherited members, up to the access level it was shared with
+func (r *membershipResolver) addSharedGroup(membership *gitlab_collected.EffectiveMembership, gid int, path string, accessLevel int) error {
+	members, err := r.groupMembers(&r.allMembers, r.client.Client().Groups.ListAllGroupMembers, gid)
+	if err != nil {
+		return err
+	}
+	membership.AddGroupMembers(gitlab_collected.MembershipShared, path, members, gitlab2.AccessLevelValue(accessLevel))
+
+	return nil
+}
+
+func (r *membershipResolver) groupMembers(cache *sync.Map, list interface{}, gid int) ([]*gitlab2.GroupMember, error) {
+	return cached(cache, gid, func() ([]*gitlab2.GroupMember, error) {
+		res, err := pagination.New[*gitlab2.GroupMember](list, nil).Sync(gid)
+		return res.Collected, err
+	})
+}
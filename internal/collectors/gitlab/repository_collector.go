@@ -2,7 +2,10 @@ package gitlab
 
 import (
 	"context"
+	"fmt"
 	"log"
+	"net/http"
+	"sync"
 	"sync/atomic"
 
 	"github.com/Legit-Labs/legitify/internal/clients/gitlab"
@@ -11,6 +14,7 @@ import (
 	"github.com/Legit-Labs/legitify/internal/collectors"
 	"github.com/Legit-Labs/legitify/internal/common/group_waiter"
 	"github.com/Legit-Labs/legitify/internal/common/permissions"
+	"github.com/Legit-Labs/legitify/internal/common/repo_filter"
 	"github.com/Legit-Labs/legitify/internal/common/types"
 	"github.com/Legit-Labs/legitify/internal/context_utils"
 	gitlab2 "github.com/xanzy/go-gitlab"
@@ -20,15 +24,26 @@ import (
 
 type repositoryCollector struct {
 	collectors.BaseCollector
-	Client  *gitlab.Client
-	Context context.Context
+	Client           *gitlab.Client
+	Context          context.Context
+	membership       *membershipResolver
+	runners          *runnerResolver
+	groupProtected   sync.Map
+	includeArchived  bool
+	includeUserRepos bool
+	filter           *repo_filter.Filter
 }
 
 func NewRepositoryCollector(ctx context.Context, client *gitlab.Client) collectors.Collector {
 	c := &repositoryCollector{
-		BaseCollector: collectors.NewBaseCollector(namespace.Repository),
-		Client:        client,
-		Context:       ctx,
+		BaseCollector:    collectors.NewBaseCollector(namespace.Repository),
+		Client:           client,
+		Context:          ctx,
+		membership:       newMembershipResolver(client),
+		runners:          newRunnerResolver(client),
+		includeArchived:  context_utils.GetIncludeArchived(ctx),
+		includeUserRepos: context_utils.GetIncludeUserRepos(ctx),
+		filter:           context_utils.GetRepositoryFilter(ctx),
 	}
 	return c
 }
@@ -50,7 +65,7 @@ func (rc *repositoryCollector) CollectTotalEntities() int {
 	for _, group := range groups {
 		group := group
 		gw.Do(func() {
-			_, resp, err := rc.Client.Client().Groups.ListGroupProjects(group.ID, &gitlab2.ListGroupProjectsOptions{})
+			_, resp, err := rc.Client.Client().Groups.ListGroupProjects(group.ID, &gitlab2.ListGroupProjectsOptions{Archived: rc.archivedFilter()})
 			if err != nil {
 				log.Printf("failed to collect metadata for repositories of group %s (%d): %s", group.Name, group.ID, err)
 			} else {
@@ -58,6 +73,19 @@ func (rc *repositoryCollector) CollectTotalEntities() int {
 			}
 		})
 	}
+	if rc.includeUserRepos {
+		for _, user := range rc.membersOf(groups) {
+			user := user
+			gw.Do(func() {
+				_, resp, err := rc.Client.Client().Projects.ListUserProjects(user.ID, &gitlab2.ListProjectsOptions{Archived: rc.archivedFilter()})
+				if err != nil {
+					log.Printf("failed to collect metadata for repositories of user %s (%d): %s", user.Username, user.ID, err)
+				} else {
+					total.Add(int64(resp.TotalItems))
+				}
+			})
+		}
+	}
 	gw.Wait()
 
 	return int(total.Load())
@@ -121,6 +149,20 @@ func (rc *repositoryCollector) extendProjectWithMembers(project gitlab_collected
 	return extendedProject, nil
 }
 
+func (rc *repositoryCollector) extendProjectWithEffectiveMembership(project gitlab_collected.Repository) (gitlab_collected.Repository, error) {
+	resolved, err := rc.membership.resolveProject(project.Project)
+	if err != nil {
+		log.Printf("failed to resolve project: %s effective membership. error message: %s", project.Name(), err)
+		return project, err
+	}
+
+	extendedProject := project
+	extendedProject.Hierarchy = resolved.hierarchy
+	extendedProject.EffectiveSettings = resolved.settings
+	extendedProject.EffectiveMembers = resolved.members
+	return extendedProject, nil
+}
+
 func (rc *repositoryCollector) extendProjectWithWebhooks(project gitlab_collected.Repository) (gitlab_collected.Repository, error) {
 	res, err := pagination.New[*gitlab2.ProjectHook](rc.Client.Client().Projects.ListProjectHooks, nil).Sync(int(project.ID()))
 	if err != nil {
@@ -179,6 +221,142 @@ func (rc *repositoryCollector) extendProjectWithMinimumRequiredApprovals(project
 	return project, nil
 }
 
+func (rc *repositoryCollector) extendProjectWithVariables(project gitlab_collected.Repository) (gitlab_collected.Repository, error) {
+	variables, err := projectVariables(rc.Client, int(project.ID()))
+	if err != nil {
+		log.Printf("failed to list project: %s CI/CD variables. error message: %s", project.Name(), err)
+		return project, err
+	}
+
+	extendedProject := project
+	extendedProject.Variables = variables
+	return extendedProject, nil
+}
+
+func (rc *repositoryCollector) extendProjectWithRunners(project gitlab_collected.Repository) (gitlab_collected.Repository, error) {
+	res, err := pagination.New[*gitlab2.Runner](rc.Client.Client().Runners.ListProjectRunners, nil).Sync(int(project.ID()))
+	if err != nil {
+		log.Printf("failed to list project: %s runners. error message: %s", project.Name(), err)
+		return project, err
+	}
+
+	extendedProject := project
+	extendedProject.Runners = rc.runners.withDetails(res.Collected)
+	return extendedProject, nil
+}
+
+func (rc *repositoryCollector) extendProjectWithDeployTokens(project gitlab_collected.Repository) (gitlab_collected.Repository, error) {
+	res, err := pagination.New[*gitlab2.DeployToken](rc.Client.Client().DeployTokens.ListProjectDeployTokens, nil).Sync(int(project.ID()))
+	if err != nil {
+		log.Printf("failed to list project: %s deploy tokens. error message: %s", project.Name(), err)
+		return project, err
+	}
+
+	extendedProject := project
+	extendedProject.DeployTokens = res.Collected
+	return extendedProject, nil
+}
+
+func (rc *repositoryCollector) extendProjectWithDeployKeys(project gitlab_collected.Repository) (gitlab_collected.Repository, error) {
+	res, err := pagination.New[*gitlab2.ProjectDeployKey](rc.Client.Client().DeployKeys.ListProjectDeployKeys, nil).Sync(int(project.ID()))
+	if err != nil {
+		log.Printf("failed to list project: %s deploy keys. error message: %s", project.Name(), err)
+		return project, err
+	}
+
+	extendedProject := project
+	extendedProject.DeployKeys = res.Collected
+	return extendedProject, nil
+}
+
+func (rc *repositoryCollector) extendProjectWithAccessTokens(project gitlab_collected.Repository) (gitlab_collected.Repository, error) {
+	tokens, err := projectAccessTokens(rc.Client, int(project.ID()))
+	if err != nil {
+		log.Printf("failed to list project: %s access tokens. error message: %s", project.Name(), err)
+		return project, err
+	}
+
+	extendedProject := project
+	extendedProject.AccessTokens = tokens
+	return extendedProject, nil
+}
+
+func (rc *repositoryCollector) extendProjectWithProtectedTags(project gitlab_collected.Repository) (gitlab_collected.Repository, error) {
+	res, err := pagination.New[*gitlab2.ProtectedTag](rc.Client.Client().ProtectedTags.ListProtectedTags, nil).Sync(int(project.ID()))
+	if err != nil {
+		log.Printf("failed to list project: %s protected tags. error message: %s", project.Name(), err)
+		return project, err
+	}
+
+	extendedProject := project
+	// an empty list rather than nil, as no protected tags is a finding
+	extendedProject.ProtectedTags = append([]*gitlab2.ProtectedTag{}, res.Collected...)
+	return extendedProject, nil
+}
+
+func (rc *repositoryCollector) extendProjectWithEnvironments(project gitlab_collected.Repository) (gitlab_collected.Repository, error) {
+	environments, err := pagination.New[*gitlab2.Environment](rc.Client.Client().Environments.ListEnvironments, nil).Sync(int(project.ID()))
+	if err != nil {
+		log.Printf("failed to list project: %s environments. error message: %s", project.Name(), err)
+		return project, err
+	}
+
+	// protected environments are a premium feature, so environments may have no protection info
+	protected, err := pagination.New[*gitlab_collected.ProtectedEnvironment](rc.listProtectedEnvironments, nil).Sync(int(project.ID()))
+	if err != nil {
+		log.Printf("failed to list project: %s protected environments. error message: %s", project.Name(), err)
+	}
+
+	// group protected environments protect the environments of their tier in all the projects of the group
+	var groupProtected []*gitlab_collected.ProtectedEnvironment
+	for i := len(project.Hierarchy) - 1; i >= 0; i-- {
+		group := project.Hierarchy[i]
+		environments, err := cached(&rc.groupProtected, group.ID, func() ([]*gitlab_collected.ProtectedEnvironment, error) {
+			res, err := pagination.New[*gitlab_collected.ProtectedEnvironment](rc.listGroupProtectedEnvironments, nil).Sync(group.ID)
+			if err != nil {
+				log.Printf("failed to list group: %s protected environments. error message: %s", group.FullPath, err)
+				return nil, err
+			}
+			return res.Collected, nil
+		})
+		if err == nil {
+			groupProtected = append(groupProtected, environments...)
+		}
+	}
+
+	extendedProject := project
+	extendedProject.Environments = []*gitlab_collected.Environment{}
+	for _, environment := range environments.Collected {
+		extendedProject.Environments = append(extendedProject.Environments, gitlab_collected.NewEnvironment(environment, protected.Collected, groupProtected))
+	}
+	return extendedProject, nil
+}
+
+// listProtectedEnvironments lists the protected environments including their approval rules, which go-gitlab doesn't parse
+func (rc *repositoryCollector) listProtectedEnvironments(pid int, opt *gitlab2.ListProtectedEnvironmentsOptions, options ...gitlab2.RequestOptionFunc) ([]*gitlab_collected.ProtectedEnvironment, *gitlab2.Response, error) {
+	return rc.requestProtectedEnvironments(fmt.Sprintf("projects/%d/protected_environments", pid), opt, options)
+}
+
+// listGroupProtectedEnvironments lists the protected environments of a group, which are named after the tier they protect
+func (rc *repositoryCollector) listGroupProtectedEnvironments(gid int, opt *gitlab2.ListProtectedEnvironmentsOptions, options ...gitlab2.RequestOptionFunc) ([]*gitlab_collected.ProtectedEnvironment, *gitlab2.Response, error) {
+	return rc.requestProtectedEnvironments(fmt.Sprintf("groups/%d/protected_environments", gid), opt, options)
+}
+
+func (rc *repositoryCollector) requestProtectedEnvironments(path string, opt *gitlab2.ListProtectedEnvironmentsOptions, options []gitlab2.RequestOptionFunc) ([]*gitlab_collected.ProtectedEnvironment, *gitlab2.Response, error) {
+	req, err := rc.Client.Client().NewRequest(http.MethodGet, path, opt, options)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var environments []*gitlab_collected.ProtectedEnvironment
+	resp, err := rc.Client.Client().Do(req, &environments)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return environments, resp, nil
+}
+
 func (rc *repositoryCollector) collectAll() collectors.SubCollectorChannels {
 	return rc.WrappedCollection(func() {
 		groups, err := rc.Client.Groups()
@@ -190,7 +368,8 @@ func (rc *repositoryCollector) collectAll() collectors.SubCollectorChannels {
 		for _, group := range groups {
 			g := group
 			gw.Do(func() {
-				ch := pagination.New[*gitlab2.Project](rc.Client.Client().Groups.ListGroupProjects, nil).Async(g.ID)
+				opts := &gitlab2.ListGroupProjectsOptions{Archived: rc.archivedFilter()}
+				ch := pagination.New[*gitlab2.Project](rc.Client.Client().Groups.ListGroupProjects, opts).Async(g.ID)
 				for res := range ch {
 					if res.Err != nil {
 						log.Printf("failed to list projects for group %s (%d): %v", g.Name, g.ID, res.Err)
@@ -205,22 +384,112 @@ func (rc *repositoryCollector) collectAll() collectors.SubCollectorChannels {
 				}
 			})
 		}
+		if rc.includeUserRepos {
+			for _, user := range rc.membersOf(groups) {
+				user := user
+				gw.Do(func() {
+					opts := &gitlab2.ListProjectsOptions{Archived: rc.archivedFilter()}
+					projects, err := pagination.New[*gitlab2.Project](rc.Client.Client().Projects.ListUserProjects, opts).Sync(user.ID)
+					if err != nil {
+						log.Printf("failed to list projects for user %s (%d): %v", user.Username, user.ID, err)
+						return
+					}
+					for _, completedProject := range projects.Collected {
+						completedProject := completedProject
+						gw.Do(func() {
+							rc.extendedCollection(completedProject, rc.Client.IsGroupPremium(completedProject.Namespace.FullPath))
+						})
+					}
+				})
+			}
+		}
 		gw.Wait()
 	})
 }
 
+// archivedFilter filters out the archived projects, unless they should be included
+func (rc *repositoryCollector) archivedFilter() *bool {
+	if rc.includeArchived {
+		return nil
+	}
+
+	return gitlab2.Bool(false)
+}
+
+// membersOf returns the distinct direct members of the groups
+func (rc *repositoryCollector) membersOf(groups []*gitlab2.Group) []*gitlab2.GroupMember {
+	seen := make(map[int]bool)
+	var members []*gitlab2.GroupMember
+	for _, group := range groups {
+		groupMembers, err := rc.Client.GroupMembers(group)
+		if err != nil {
+			log.Printf("failed to list members of group %s (%d): %v", group.Name, group.ID, err)
+			continue
+		}
+
+		for _, member := range groupMembers {
+			if !seen[member.ID] {
+				seen[member.ID] = true
+				members = append(members, member)
+			}
+		}
+	}
+
+	return members
+}
+
+// matchesFilter checks the project against the repository filter, before its expensive extended collection.
+// The languages are fetched only when the filter depends on them.
+func (rc *repositoryCollector) matchesFilter(project *gitlab2.Project) bool {
+	if rc.filter.IsEmpty() {
+		return true
+	}
+
+	metadata := gitlab_collected.Repository{Project: project}.Classification()
+
+	if rc.filter.NeedsLanguage() {
+		languages, _, err := rc.Client.Client().Projects.GetProjectLanguages(project.ID)
+		if err != nil {
+			log.Printf("failed to collect languages for project %s (%d): %v", project.PathWithNamespace, project.ID, err)
+			return false
+		}
+		// the primary language is the one with the highest percentage
+		var percentage float32
+		for language, p := range *languages {
+			if p > percentage {
+				metadata.Language, percentage = language, p
+			}
+		}
+	}
+
+	return rc.filter.Matches(metadata)
+}
+
 func (rc *repositoryCollector) extendedCollection(completeProjectsList *gitlab2.Project, isPremium bool) {
+	if !rc.matchesFilter(completeProjectsList) {
+		rc.CollectionChangeByOne()
+		return
+	}
+
 	proj := gitlab_collected.Repository{
 		Project: completeProjectsList,
 	}
 	extensionFunctions := []func(gitlab_collected.Repository) (gitlab_collected.Repository, error){
 		rc.extendProjectWithMembers,
+		rc.extendProjectWithEffectiveMembership,
 		rc.extendProjectWithProtectedBranches,
 		rc.extendProjectWithWebhooks,
 		rc.extendProjectWithPushRules,
 		rc.extendProjectWithMergeRequestApprovalRules,
 		rc.extendProjectWithApprovalConfiguration,
 		rc.extendProjectWithMinimumRequiredApprovals,
+		rc.extendProjectWithVariables,
+		rc.extendProjectWithRunners,
+		rc.extendProjectWithDeployTokens,
+		rc.extendProjectWithDeployKeys,
+		rc.extendProjectWithAccessTokens,
+		rc.extendProjectWithProtectedTags,
+		rc.extendProjectWithEnvironments,
 	}
 	var err error
 	for _, f := range extensionFunctions {
@@ -5,11 +5,23 @@ import (
 	"log"
 
 	"github.com/Legit-Labs/legitify/internal/clients/gitlab"
+	"github.com/Legit-Labs/legitify/internal/clients/gitlab/pagination"
 	"github.com/Legit-Labs/legitify/internal/collectors"
 	"github.com/Legit-Labs/legitify/internal/common/namespace"
+	gitlab2 "github.com/xanzy/go-gitlab"
 	"golang.org/x/net/context"
 )
 
+const auditEventStreamingDestinationsQuery = `query {
+	instanceExternalAuditEventDestinations {
+		nodes {
+			id
+			name
+			destination_url: destinationUrl
+		}
+	}
+}`
+
 type serverCollector struct {
 	collectors.BaseCollector
 	Client   *gitlab.Client
@@ -48,9 +60,35 @@ func (c *serverCollector) Collect() collectors.SubCollectorChannels {
 			return
 		}
 
-		entity := gitlab_collected.NewServer(c.Client.ServerUrl(), settings)
+		admins, err := pagination.New[*gitlab2.User](c.Client.Client().Users.ListUsers,
+			&gitlab2.ListUsersOptions{Admins: gitlab2.Bool(true)}).Sync()
+		if err != nil {
+			log.Printf("failed to collect server admins %s", err)
+		}
+
+		destinations, err := c.auditEventStreamingDestinations()
+		if err != nil {
+			log.Printf("failed to collect server audit event streaming destinations %s", err)
+		}
+
+		entity := gitlab_collected.NewServer(c.Client.ServerUrl(), settings, admins.Collected, destinations)
 		c.CollectDataWithContext(entity, entity.CanonicalLink(),
 			newServerCollectionContext())
 		c.CollectionChangeByOne()
 	})
 }
+
+// auditEventStreamingDestinations lists the instance-level streaming destinations, which are available only through GraphQL
+func (c *serverCollector) auditEventStreamingDestinations() ([]*gitlab_collected.AuditEventStreamingDestination, error) {
+	var result struct {
+		Destinations struct {
+			Nodes []*gitlab_collected.AuditEventStreamingDestination `json:"nodes"`
+		} `json:"instanceExternalAuditEventDestinations"`
+	}
+	if err := c.Client.GraphQL(auditEventStreamingDestinationsQuery, &result); err != nil {
+		return nil, err
+	}
+
+	destinations := []*gitlab_collected.AuditEventStreamingDestination{}
+	return append(destinations, result.Destinations.Nodes...), nil
+}
@@ -0,0 +1,148 @@
+package gitlab
+
+import (
+	"context"
+	"log"
+	"sync"
+
+	"github.com/Legit-Labs/legitify/internal/clients/gitlab"
+	"github.com/Legit-Labs/legitify/internal/clients/gitlab/pagination"
+	"github.com/Legit-Labs/legitify/internal/collected/gitlab_collected"
+	"github.com/Legit-Labs/legitify/internal/collectors"
+	"github.com/Legit-Labs/legitify/internal/common/group_waiter"
+	"github.com/Legit-Labs/legitify/internal/common/namespace"
+	"github.com/Legit-Labs/legitify/internal/common/permissions"
+	gitlab2 "github.com/xanzy/go-gitlab"
+)
+
+type teamCollector struct {
+	collectors.BaseCollector
+	Client  *gitlab.Client
+	Context context.Context
+}
+
+func NewTeamCollector(ctx context.Context, client *gitlab.Client) collectors.Collector {
+	c := &teamCollector{
+		BaseCollector: collectors.NewBaseCollector(namespace.Team),
+		Client:        client,
+		Context:       ctx,
+	}
+	return c
+}
+
+func (c *teamCollector) CollectTotalEntities() int {
+	groups, err := c.Client.Groups()
+	if err != nil {
+		log.Printf("failed to collect groups %s", err)
+		return 0
+	}
+
+	return len(groups)
+}
+
+func (c *teamCollector) Collect() collectors.SubCollectorChannels {
+	return c.WrappedCollection(func() {
+		groups, err := c.Client.Groups()
+		if err != nil {
+			log.Printf("failed to collect groups %s", err)
+			return
+		}
+
+		// the groups a group was shared with are only known from the groups that were shared
+		fullGroups := c.fullGroups(groups)
+		sharedGroups := make(map[int][]*gitlab_collected.SharedWith)
+		for _, group := range fullGroups {
+			for _, shared := range group.SharedWithGroups {
+				sharedGroups[shared.GroupID] = append(sharedGroups[shared.GroupID], &gitlab_collected.SharedWith{
+					ID:          group.ID,
+					FullPath:    group.FullPath,
+					AccessLevel: gitlab2.AccessLevelValue(shared.GroupAccessLevel),
+				})
+			}
+		}
+
+		gw := group_waiter.New()
+		for _, g := range fullGroups {
+			g := g
+			gw.Do(func() {
+				defer c.CollectionChangeByOne()
+
+				members, err := pagination.New[*gitlab2.GroupMember](c.Client.Client().Groups.ListAllGroupMembers, &gitlab2.ListGroupMembersOptions{}).Sync(g.ID)
+				if err != nil {
+					log.Printf("failed to query group members: %d - %s", g.ID, g.Name)
+					return
+				}
+
+				sharedProjects, err := c.sharedProjects(g)
+				if err != nil {
+					log.Printf("failed to query group shared projects: %d - %s", g.ID, g.Name)
+				}
+
+				entity := gitlab_collected.Team{
+					Group:          g,
+					Members:        members.Collected,
+					SharedProjects: sharedProjects,
+					SharedGroups:   sharedGroups[g.ID],
+				}
+				if entity.SharedGroups == nil {
+					entity.SharedGroups = []*gitlab_collected.SharedWith{}
+				}
+
+				isPremium := c.Client.IsGroupPremium(g.FullPath)
+				c.CollectDataWithContext(entity, g.WebURL,
+					newCollectionContext(g, []permissions.RepositoryRole{permissions.RepoRoleAdmin}, isPremium))
+			})
+		}
+
+		gw.Wait()
+	})
+}
+
+func (c *teamCollector) fullGroups(groups []*gitlab2.Group) []*gitlab2.Group {
+	var lock sync.Mutex
+	var result []*gitlab2.Group
+
+	gw := group_waiter.New()
+	for _, g := range groups {
+		g := g
+		gw.Do(func() {
+			fullGroup, _, err := c.Client.Client().Groups.GetGroup(g.ID, &gitlab2.GetGroupOptions{WithProjects: gitlab2.Bool(false)})
+			if err != nil {
+				log.Printf("failed to query group: %d - %s", g.ID, g.Name)
+				return
+			}
+
+			lock.Lock()
+			defer lock.Unlock()
+			result = append(result, fullGroup)
+		})
+	}
+	gw.Wait()
+
+	return result
+}
+
+// sharedProjects returns the projects of other namespaces that were shared with the group
+func (c *teamCollector) sharedProjects(group *gitlab2.Group) ([]*gitlab_collected.SharedWith, error) {
+	opts := &gitlab2.ListGroupProjectsOptions{WithShared: gitlab2.Bool(true)}
+	projects, err := pagination.New[*gitlab2.Project](c.Client.Client().Groups.ListGroupProjects, opts).Sync(group.ID)
+	if err != nil {
+		return []*gitlab_collected.SharedWith{}, err
+	}
+
+	result := []*gitlab_collected.SharedWith{}
+	for _, project := range projects.Collected {
+		for _, shared := range project.SharedWithGroups {
+			if shared.GroupID != group.ID {
+				continue
+			}
+			result = append(result, &gitlab_collected.SharedWith{
+				ID:          project.ID,
+				FullPath:    project.PathWithNamespace,
+				AccessLevel: gitlab2.AccessLevelValue(shared.GroupAccessLevel),
+			})
+		}
+	}
+
+	return result, nil
+}
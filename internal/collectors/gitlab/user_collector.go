@@ -18,6 +18,7 @@ type userCollector struct {
 	collectors.BaseCollector
 	Client  *gitlab.Client
 	Context context.Context
+	tokens  *userTokenResolver
 }
 
 func NewUserCollector(ctx context.Context, client *gitlab.Client) collectors.Collector {
@@ -25,6 +26,7 @@ func NewUserCollector(ctx context.Context, client *gitlab.Client) collectors.Col
 		BaseCollector: collectors.NewBaseCollector(namespace.Member),
 		Client:        client,
 		Context:       ctx,
+		tokens:        newUserTokenResolver(client),
 	}
 	return c
 }
@@ -95,6 +97,12 @@ func (c *userCollector) collectGroupUsers(group *gitlab2.Group) {
 			entity := gitlab_collected.Member{
 				User: u,
 			}
+			if c.Client.IsAdmin() {
+				entity.AccessTokens, err = c.tokens.userAccessTokens(u.ID)
+				if err != nil {
+					log.Printf("failed to collect the access tokens of user %s - %s", m.Name, err)
+				}
+			}
 			c.CollectDataWithContext(&entity, entity.CanonicalLink(),
 				newCollectionContext(nil, []permissions.Role{permissions.GroupRoleOwner},
 					c.Client.IsGroupPremium(group.FullPath)))
@@ -14,6 +14,11 @@ const (
 	Member       Namespace = "member"
 	Actions      Namespace = "actions"
 	RunnerGroup  Namespace = "runner_group"
+	Branch       Namespace = "branch"
+	Apps         Namespace = "apps"
+	Team         Namespace = "team"
+	Runner       Namespace = "runner"
+	Audit        Namespace = "audit"
 )
 
 var All = []Namespace{
@@ -23,6 +28,26 @@ var All = []Namespace{
 	Member,
 	Actions,
 	RunnerGroup,
+	Branch,
+	Apps,
+	Team,
+	Runner,
+	Audit,
+}
+
+// Default are the namespaces that are analyzed unless --namespace is given.
+// The audit namespace is opt-in, since it issues an audit log search for every risky action.
+var Default = []Namespace{
+	Organization,
+	Enterprise,
+	Repository,
+	Member,
+	Actions,
+	RunnerGroup,
+	Branch,
+	Apps,
+	Team,
+	Runner,
 }
 
 func ValidateNamespaces(namespace []Namespace) error {
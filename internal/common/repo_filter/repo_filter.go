@@ -0,0 +1,152 @@
+package repo_filter
+
+import (
+	"fmt"
+	"path"
+	"strings"
+)
+
+// The fields a repository can be filtered by.
+// The custom properties of a repository are filtered by PropertyPrefix followed by the property name (e.g. property.tier:production).
+const (
+	FieldName       = "name"
+	FieldTopic      = "topic"
+	FieldVisibility = "visibility"
+	FieldLanguage   = "language"
+	PropertyPrefix  = "property."
+)
+
+// Repository is the repository metadata a filter is matched against
+type Repository struct {
+	Name       string
+	Visibility string
+	Language   string
+	Topics     []string
+	// Properties are the values of the custom properties of the repository, by the property name
+	Properties map[string][]string
+}
+
+// Filter selects repositories by their metadata.
+// A repository is selected if every filtered field matches, and a field matches if any of its patterns match one of its values.
+// The patterns are case-insensitive globs (e.g. name:payments-*).
+type Filter struct {
+	patterns map[string][]string
+}
+
+// Parse parses conditions of the form field:pattern
+func Parse(conditions []string) (*Filter, error) {
+	filter := &Filter{patterns: make(map[string][]string)}
+	for _, condition := range conditions {
+		condition = strings.TrimSpace(condition)
+		if condition == "" {
+			continue
+		}
+
+		field, pattern, found := strings.Cut(condition, ":")
+		field = strings.ToLower(strings.TrimSpace(field))
+		pattern = strings.ToLower(strings.TrimSpace(pattern))
+		if !found || pattern == "" {
+			return nil, fmt.Errorf("invalid repository filter %s: expected field:pattern", condition)
+		}
+		if !isValidField(field) {
+			return nil, fmt.Errorf("invalid repository filter %s: unknown field %s (valid fields: %s, %s, %s, %s, %s<name>)",
+				condition, field, FieldName, FieldTopic, FieldVisibility, FieldLanguage, PropertyPrefix)
+		}
+		if _, err := path.Match(pattern, ""); err != nil {
+			return nil, fmt.Errorf("invalid repository filter %s: %v", condition, err)
+		}
+
+		filter.patterns[field] = append(filter.patterns[field], pattern)
+	}
+
+	return filter, nil
+}
+
+func isValidField(field string) bool {
+	switch field {
+	case FieldName, FieldTopic, FieldVisibility, FieldLanguage:
+		return true
+	}
+
+	return strings.HasPrefix(field, PropertyPrefix) && len(field) > len(PropertyPrefix)
+}
+
+// IsEmpty returns true if the filter selects all the repositories
+func (f *Filter) IsEmpty() bool {
+	return f == nil || len(f.patterns) == 0
+}
+
+// NeedsProperties returns true if the filter depends on the custom properties of the repositories
+func (f *Filter) NeedsProperties() bool {
+	if f == nil {
+		return false
+	}
+	for field := range f.patterns {
+		if strings.HasPrefix(field, PropertyPrefix) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// NeedsLanguage returns true if the filter depends on the language of the repositories
+func (f *Filter) NeedsLanguage() bool {
+	if f == nil {
+		return false
+	}
+	_, ok := f.patterns[FieldLanguage]
+	return ok
+}
+
+func (f *Filter) Matches(repository Repository) bool {
+	if f.IsEmpty() {
+		return true
+	}
+
+	for field, patterns := range f.patterns {
+		if !matchesAny(patterns, repository.values(field)) {
+			return false
+		}
+	}
+
+	return true
+}
+
+func (r Repository) values(field string) []string {
+	switch field {
+	case FieldName:
+		return []string{r.Name}
+	case FieldTopic:
+		return r.Topics
+	case FieldVisibility:
+		return []string{r.Visibility}
+	case FieldLanguage:
+		return []string{r.Language}
+	}
+
+	name := strings.TrimPrefix(field, PropertyPrefix)
+	for property, values := range r.Properties {
+		if strings.EqualFold(property, name) {
+			return values
+		}
+	}
+
+	return nil
+}
+
+func matchesAny(patterns []string, values []string) bool {
+	for _, value := range values {
+		if value == "" {
+			continue
+		}
+		for _, pattern := range patterns {
+			// the patterns are validated by Parse
+			if matched, _ := path.Match(pattern, strings.ToLower(value)); matched {
+				return true
+			}
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,62 @@
+package repo_filter_test
+
+import (
+	"testing"
+
+	"github.com/Legit-Labs/legitify/internal/common/repo_filter"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParse(t *testing.T) {
+	filter, err := repo_filter.Parse(nil)
+	require.Nilf(t, err, "empty filter")
+	require.True(t, filter.IsEmpty())
+
+	filter, err = repo_filter.Parse([]string{"topic:prod", " Visibility: private ", "property.tier:production"})
+	require.Nilf(t, err, "valid filter")
+	require.False(t, filter.IsEmpty())
+	require.True(t, filter.NeedsProperties())
+	require.False(t, filter.NeedsLanguage())
+
+	for _, invalid := range []string{"prod", "topic:", "owner:legit", "property.:prod", "name:[payments"} {
+		_, err = repo_filter.Parse([]string{invalid})
+		require.NotNilf(t, err, "invalid filter %s", invalid)
+	}
+}
+
+func TestMatches(t *testing.T) {
+	payments := repo_filter.Repository{
+		Name:       "payments-api",
+		Visibility: "PRIVATE",
+		Language:   "Go",
+		Topics:     []string{"prod", "payments"},
+		Properties: map[string][]string{"Tier": {"production"}},
+	}
+	sandbox := repo_filter.Repository{
+		Name:       "sandbox-1",
+		Visibility: "public",
+		Topics:     []string{"sandbox"},
+	}
+
+	tests := []struct {
+		name       string
+		conditions []string
+		payments   bool
+		sandbox    bool
+	}{
+		{name: "no conditions", conditions: nil, payments: true, sandbox: true},
+		{name: "name glob", conditions: []string{"name:payments-*"}, payments: true, sandbox: false},
+		{name: "all fields must match", conditions: []string{"topic:prod", "visibility:public"}, payments: false, sandbox: false},
+		{name: "any pattern of a field matches", conditions: []string{"topic:prod", "topic:sandbox"}, payments: true, sandbox: true},
+		{name: "case insensitive", conditions: []string{"visibility:private", "language:go"}, payments: true, sandbox: false},
+		{name: "missing language", conditions: []string{"language:*"}, payments: true, sandbox: false},
+		{name: "custom property", conditions: []string{"property.tier:prod*"}, payments: true, sandbox: false},
+	}
+
+	for _, test := range tests {
+		filter, err := repo_filter.Parse(test.conditions)
+		require.Nilf(t, err, test.name)
+		require.Equalf(t, test.payments, filter.Matches(payments), "%s: payments", test.name)
+		require.Equalf(t, test.sandbox, filter.Matches(sandbox), "%s: sandbox", test.name)
+	}
+}
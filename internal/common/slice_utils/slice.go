@@ -16,3 +16,12 @@ func CastInterfaces[T any](slice []interface{}) []T {
 		return i.(T)
 	})
 }
+
+func Contains[T comparable](slice []T, value T) bool {
+	for _, v := range slice {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
@@ -2,7 +2,9 @@ package context_utils
 
 import (
 	"context"
+	"time"
 
+	"github.com/Legit-Labs/legitify/internal/common/repo_filter"
 	"github.com/Legit-Labs/legitify/internal/common/types"
 
 	"github.com/Legit-Labs/legitify/internal/common/permissions"
@@ -19,6 +21,12 @@ const (
 	isCloudKey                    contextKey = "isCloud"
 	simulateSecondaryRateLimitKey contextKey = "simulateSecondaryRateLimit"
 	ignoredPoliciesKey            contextKey = "ignoredPolicies"
+	branchPatternsKey             contextKey = "branchPatterns"
+	includeArchivedKey            contextKey = "includeArchived"
+	includeUserReposKey           contextKey = "includeUserRepos"
+	repositoryFilterKey           contextKey = "repositoryFilter"
+	auditSinceKey                 contextKey = "auditSince"
+	terraformEnabledKey           contextKey = "terraformEnabled"
 )
 
 func NewContextWithRepos(repos []types.RepositoryWithOwner) context.Context {
@@ -51,6 +59,27 @@ func NewContextWithIgnoredPolicies(ctx context.Context, ignoredPolicies []string
 	return context.WithValue(ctx, ignoredPoliciesKey, ignoredPolicies)
 }
 
+func NewContextWithBranchPatterns(ctx context.Context, branchPatterns []string) context.Context {
+	return context.WithValue(ctx, branchPatternsKey, branchPatterns)
+}
+
+func NewContextWithIncludedRepositories(ctx context.Context, includeArchived bool, includeUserRepos bool) context.Context {
+	c := context.WithValue(ctx, includeArchivedKey, includeArchived)
+	return context.WithValue(c, includeUserReposKey, includeUserRepos)
+}
+
+func NewContextWithRepositoryFilter(ctx context.Context, filter *repo_filter.Filter) context.Context {
+	return context.WithValue(ctx, repositoryFilterKey, filter)
+}
+
+func NewContextWithAuditSince(ctx context.Context, since time.Time) context.Context {
+	return context.WithValue(ctx, auditSinceKey, since)
+}
+
+func NewContextWithTerraform(ctx context.Context, terraformEnabled bool) context.Context {
+	return context.WithValue(ctx, terraformEnabledKey, terraformEnabled)
+}
+
 func GetTokenScopes(ctx context.Context) permissions.TokenScopes {
 	return ctx.Value(tokenScopesKey).(permissions.TokenScopes)
 }
@@ -65,6 +94,11 @@ func GetScorecardVerbose(ctx context.Context) bool {
 	return ok && val
 }
 
+func GetTerraformEnabled(ctx context.Context) bool {
+	val, ok := ctx.Value(terraformEnabledKey).(bool)
+	return ok && val
+}
+
 func GetRepositories(ctx context.Context) ([]types.RepositoryWithOwner, bool) {
 	val, ok := ctx.Value(repositoryKey).([]types.RepositoryWithOwner)
 	return val, ok
@@ -89,3 +123,46 @@ func GetIgnoredPolicies(ctx context.Context) []string {
 
 	return val
 }
+
+func GetBranchPatterns(ctx context.Context) []string {
+	val, ok := ctx.Value(branchPatternsKey).([]string)
+
+	if !ok {
+		return []string{}
+	}
+
+	return val
+}
+
+func GetIncludeArchived(ctx context.Context) bool {
+	val, ok := ctx.Value(includeArchivedKey).(bool)
+	return ok && val
+}
+
+func GetIncludeUserRepos(ctx context.Context) bool {
+	val, ok := ctx.Value(includeUserReposKey).(bool)
+	return ok && val
+}
+
+func GetRepositoryFilter(ctx context.Context) *repo_filter.Filter {
+	val, ok := ctx.Value(repositoryFilterKey).(*repo_filter.Filter)
+
+	if !ok {
+		return &repo_filter.Filter{}
+	}
+
+	return val
+}
+
+// DefaultAuditDays is the default length of the time window of the audit log analysis
+const DefaultAuditDays = 7
+
+func GetAuditSince(ctx context.Context) time.Time {
+	val, ok := ctx.Value(auditSinceKey).(time.Time)
+
+	if !ok {
+		return time.Now().AddDate(0, 0, -DefaultAuditDays)
+	}
+
+	return val
+}
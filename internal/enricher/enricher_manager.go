@@ -11,6 +11,7 @@ import (
 	"github.com/Legit-Labs/legitify/internal/common/group_waiter"
 	"github.com/Legit-Labs/legitify/internal/common/namespace"
 	"github.com/Legit-Labs/legitify/internal/common/severity"
+	"github.com/Legit-Labs/legitify/internal/context_utils"
 	"github.com/Legit-Labs/legitify/internal/enricher/enrichers"
 	"github.com/open-policy-agent/opa/ast"
 )
@@ -22,6 +23,15 @@ var (
 	}
 )
 
+// implicitEnrichers are attempted for every policy, but only enrich some of the results.
+// The terraform enrichment is only used by the terraform output format, so it is skipped for the other formats.
+func implicitEnrichers(ctx context.Context) []string {
+	if context_utils.GetTerraformEnabled(ctx) {
+		return []string{enrichers.Terraform}
+	}
+	return nil
+}
+
 type EnricherManager interface {
 	Enrich(ctx context.Context, analyzedDataChannel <-chan analyzers.AnalyzedData) <-chan EnrichedData
 	Parse(name string, data interface{}) (enrichers.Enrichment, error)
@@ -44,13 +54,28 @@ type EnrichedData struct {
 }
 
 var mapping = map[string]enrichers.Enricher{
-	enrichers.EntityId:       enrichers.NewEntityIdEnricher(),
-	enrichers.EntityName:     enrichers.NewEntityNameEnricher(),
-	enrichers.OrganizationId: enrichers.NewOrganizationIdEnricher(),
-	enrichers.Scorecard:      enrichers.NewScorecardEnricher(),
-	enrichers.MembersList:    enrichers.NewMembersListEnricher(),
-	enrichers.HooksList:      enrichers.NewHooksListEnricher(),
-	enrichers.SecretsList:    enrichers.NewSecretsListEnricher(),
+	enrichers.EntityId:             enrichers.NewEntityIdEnricher(),
+	enrichers.EntityName:           enrichers.NewEntityNameEnricher(),
+	enrichers.OrganizationId:       enrichers.NewOrganizationIdEnricher(),
+	enrichers.Scorecard:            enrichers.NewScorecardEnricher(),
+	enrichers.MembersList:          enrichers.NewMembersListEnricher(),
+	enrichers.HooksList:            enrichers.NewHooksListEnricher(),
+	enrichers.SecretsList:          enrichers.NewSecretsListEnricher(),
+	enrichers.Terraform:            enrichers.NewTerraformEnricher(),
+	enrichers.PostureScore:         enrichers.NewPostureScoreEnricher(),
+	enrichers.WorkflowsList:        enrichers.NewWorkflowsListEnricher(),
+	enrichers.DeployKeysList:       enrichers.NewDeployKeysListEnricher(),
+	enrichers.EnvironmentsList:     enrichers.NewEnvironmentsListEnricher(),
+	enrichers.AppsList:             enrichers.NewAppsListEnricher(),
+	enrichers.CollaboratorsList:    enrichers.NewCollaboratorsListEnricher(),
+	enrichers.CodeownersList:       enrichers.NewCodeownersListEnricher(),
+	enrichers.VariablesList:        enrichers.NewVariablesListEnricher(),
+	enrichers.DeployTokensList:     enrichers.NewDeployTokensListEnricher(),
+	enrichers.AccessTokensList:     enrichers.NewAccessTokensListEnricher(),
+	enrichers.AdminsList:           enrichers.NewAdminsListEnricher(),
+	enrichers.EffectiveMembersList: enrichers.NewEffectiveMembersListEnricher(),
+	enrichers.TagsList:             enrichers.NewTagsListEnricher(),
+	enrichers.AuditEventsList:      enrichers.NewAuditEventsListEnricher(),
 }
 
 func NewEnricherManager() EnricherManager {
@@ -71,6 +96,7 @@ func (e *enricherManager) Enrich(ctx context.Context, analyzedDataChannel <-chan
 				gw.Do(func() {
 					requiredEnrichers := analyzedData.RequiredEnrichers
 					requiredEnrichers = append(requiredEnrichers, DefaultEnrichers...)
+					requiredEnrichers = append(requiredEnrichers, implicitEnrichers(ctx)...)
 
 					enrichments := make(map[string]enrichers.Enrichment)
 					for _, requiredEnricher := range requiredEnrichers {
@@ -7,7 +7,9 @@ import (
 	"github.com/Legit-Labs/legitify/internal/collected"
 
 	githubcollected "github.com/Legit-Labs/legitify/internal/collected/github"
+	"github.com/Legit-Labs/legitify/internal/context_utils"
 	"github.com/Legit-Labs/legitify/internal/enricher"
+	"github.com/Legit-Labs/legitify/internal/enricher/enrichers"
 	"github.com/google/go-github/v53/github"
 
 	"github.com/Legit-Labs/legitify/internal/analyzers"
@@ -108,3 +110,24 @@ func TestEnricher_PolicyWithEnricher_EnrichData(t *testing.T) {
 		require.Equalf(t, len(outgoingMessage.Enrichers), 2, "A policy with no enrichers should enrich data twice (default enrichers)")
 	}
 }
+
+func TestEnricher_TerraformOnlyWhenEnabled(t *testing.T) {
+	enrich := func(ctx context.Context) map[string]enrichers.Enrichment {
+		data := make(chan analyzers.AnalyzedData, 1)
+		data <- analyzers.AnalyzedData{
+			Entity: githubcollected.Repository{
+				Repository: &githubcollected.GitHubQLRepository{Name: "repo", Url: "https://github.com/owner/repo"},
+			},
+			PolicyName:               "token_default_permissions_is_read_write",
+			FullyQualifiedPolicyName: "data.repository.token_default_permissions_is_read_write",
+			Status:                   analyzers.PolicyFailed,
+		}
+		close(data)
+
+		result := <-enricher.NewEnricherManager().Enrich(ctx, data)
+		return result.Enrichers
+	}
+
+	require.NotContains(t, enrich(context.Background()), enrichers.Terraform, "terraform enrichment without the terraform output format")
+	require.Contains(t, enrich(context_utils.NewContextWithTerraform(context.Background(), true)), enrichers.Terraform)
+}
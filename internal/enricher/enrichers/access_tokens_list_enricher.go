@@ -0,0 +1,30 @@
+package enrichers
+
+import (
+	"context"
+	"log"
+
+	"github.com/Legit-Labs/legitify/internal/analyzers"
+)
+
+const AccessTokensList = "accessTokensList"
+
+func NewAccessTokensListEnricher() accessTokensListEnricher {
+	return accessTokensListEnricher{}
+}
+
+type accessTokensListEnricher struct {
+}
+
+func (e accessTokensListEnricher) Enrich(_ context.Context, data analyzers.AnalyzedData) (Enrichment, bool) {
+	result, err := newSortedListEnrichment(data.ExtraData, "type", "name")
+	if err != nil {
+		log.Printf("failed to enrich access tokens list: %v", err)
+		return nil, false
+	}
+	return result, true
+}
+
+func (e accessTokensListEnricher) Parse(data interface{}) (Enrichment, error) {
+	return NewGenericListEnrichmentFromInterface(data)
+}
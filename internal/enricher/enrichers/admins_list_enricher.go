@@ -0,0 +1,30 @@
+package enrichers
+
+import (
+	"context"
+	"log"
+
+	"github.com/Legit-Labs/legitify/internal/analyzers"
+)
+
+const AdminsList = "adminsList"
+
+func NewAdminsListEnricher() adminsListEnricher {
+	return adminsListEnricher{}
+}
+
+type adminsListEnricher struct {
+}
+
+func (e adminsListEnricher) Enrich(_ context.Context, data analyzers.AnalyzedData) (Enrichment, bool) {
+	result, err := newSortedListEnrichment(data.ExtraData, "username")
+	if err != nil {
+		log.Printf("failed to enrich admins list: %v", err)
+		return nil, false
+	}
+	return result, true
+}
+
+func (e adminsListEnricher) Parse(data interface{}) (Enrichment, error) {
+	return NewGenericListEnrichmentFromInterface(data)
+}
@@ -0,0 +1,30 @@
+package enrichers
+
+import (
+	"context"
+	"log"
+
+	"github.com/Legit-Labs/legitify/internal/analyzers"
+)
+
+const AppsList = "appsList"
+
+func NewAppsListEnricher() appsListEnricher {
+	return appsListEnricher{}
+}
+
+type appsListEnricher struct {
+}
+
+func (e appsListEnricher) Enrich(_ context.Context, data analyzers.AnalyzedData) (Enrichment, bool) {
+	result, err := newSortedListEnrichment(data.ExtraData, "app", "permission")
+	if err != nil {
+		log.Printf("failed to enrich apps list: %v", err)
+		return nil, false
+	}
+	return result, true
+}
+
+func (e appsListEnricher) Parse(data interface{}) (Enrichment, error) {
+	return NewGenericListEnrichmentFromInterface(data)
+}
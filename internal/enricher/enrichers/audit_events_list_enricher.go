@@ -0,0 +1,30 @@
+package enrichers
+
+import (
+	"context"
+	"log"
+
+	"github.com/Legit-Labs/legitify/internal/analyzers"
+)
+
+const AuditEventsList = "auditEventsList"
+
+func NewAuditEventsListEnricher() auditEventsListEnricher {
+	return auditEventsListEnricher{}
+}
+
+type auditEventsListEnricher struct {
+}
+
+func (e auditEventsListEnricher) Enrich(_ context.Context, data analyzers.AnalyzedData) (Enrichment, bool) {
+	result, err := newSortedListEnrichment(data.ExtraData, "time", "action")
+	if err != nil {
+		log.Printf("failed to enrich audit events list: %v", err)
+		return nil, false
+	}
+	return result, true
+}
+
+func (e auditEventsListEnricher) Parse(data interface{}) (Enrichment, error) {
+	return NewGenericListEnrichmentFromInterface(data)
+}
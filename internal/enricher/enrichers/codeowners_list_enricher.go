@@ -0,0 +1,30 @@
+package enrichers
+
+import (
+	"context"
+	"log"
+
+	"github.com/Legit-Labs/legitify/internal/analyzers"
+)
+
+const CodeownersList = "codeownersList"
+
+func NewCodeownersListEnricher() codeownersListEnricher {
+	return codeownersListEnricher{}
+}
+
+type codeownersListEnricher struct {
+}
+
+func (e codeownersListEnricher) Enrich(_ context.Context, data analyzers.AnalyzedData) (Enrichment, bool) {
+	result, err := newSortedListEnrichment(data.ExtraData, "path", "owner", "line")
+	if err != nil {
+		log.Printf("failed to enrich CODEOWNERS list: %v", err)
+		return nil, false
+	}
+	return result, true
+}
+
+func (e codeownersListEnricher) Parse(data interface{}) (Enrichment, error) {
+	return NewGenericListEnrichmentFromInterface(data)
+}
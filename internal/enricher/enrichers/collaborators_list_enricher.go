@@ -0,0 +1,30 @@
+package enrichers
+
+import (
+	"context"
+	"log"
+
+	"github.com/Legit-Labs/legitify/internal/analyzers"
+)
+
+const CollaboratorsList = "collaboratorsList"
+
+func NewCollaboratorsListEnricher() collaboratorsListEnricher {
+	return collaboratorsListEnricher{}
+}
+
+type collaboratorsListEnricher struct {
+}
+
+func (e collaboratorsListEnricher) Enrich(_ context.Context, data analyzers.AnalyzedData) (Enrichment, bool) {
+	result, err := newSortedListEnrichment(data.ExtraData, "login", "repository")
+	if err != nil {
+		log.Printf("failed to enrich collaborators list: %v", err)
+		return nil, false
+	}
+	return result, true
+}
+
+func (e collaboratorsListEnricher) Parse(data interface{}) (Enrichment, error) {
+	return NewGenericListEnrichmentFromInterface(data)
+}
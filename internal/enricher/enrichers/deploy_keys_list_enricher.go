@@ -0,0 +1,30 @@
+package enrichers
+
+import (
+	"context"
+	"log"
+
+	"github.com/Legit-Labs/legitify/internal/analyzers"
+)
+
+const DeployKeysList = "deployKeysList"
+
+func NewDeployKeysListEnricher() deployKeysListEnricher {
+	return deployKeysListEnricher{}
+}
+
+type deployKeysListEnricher struct {
+}
+
+func (e deployKeysListEnricher) Enrich(_ context.Context, data analyzers.AnalyzedData) (Enrichment, bool) {
+	result, err := newSortedListEnrichment(data.ExtraData, "title", "creation date")
+	if err != nil {
+		log.Printf("failed to enrich deploy keys list: %v", err)
+		return nil, false
+	}
+	return result, true
+}
+
+func (e deployKeysListEnricher) Parse(data interface{}) (Enrichment, error) {
+	return NewGenericListEnrichmentFromInterface(data)
+}
@@ -0,0 +1,30 @@
+package enrichers
+
+import (
+	"context"
+	"log"
+
+	"github.com/Legit-Labs/legitify/internal/analyzers"
+)
+
+const DeployTokensList = "deployTokensList"
+
+func NewDeployTokensListEnricher() deployTokensListEnricher {
+	return deployTokensListEnricher{}
+}
+
+type deployTokensListEnricher struct {
+}
+
+func (e deployTokensListEnricher) Enrich(_ context.Context, data analyzers.AnalyzedData) (Enrichment, bool) {
+	result, err := newSortedListEnrichment(data.ExtraData, "name")
+	if err != nil {
+		log.Printf("failed to enrich deploy tokens list: %v", err)
+		return nil, false
+	}
+	return result, true
+}
+
+func (e deployTokensListEnricher) Parse(data interface{}) (Enrichment, error) {
+	return NewGenericListEnrichmentFromInterface(data)
+}
@@ -0,0 +1,30 @@
+package enrichers
+
+import (
+	"context"
+	"log"
+
+	"github.com/Legit-Labs/legitify/internal/analyzers"
+)
+
+const EffectiveMembersList = "effectiveMembersList"
+
+func NewEffectiveMembersListEnricher() effectiveMembersListEnricher {
+	return effectiveMembersListEnricher{}
+}
+
+type effectiveMembersListEnricher struct {
+}
+
+func (e effectiveMembersListEnricher) Enrich(_ context.Context, data analyzers.AnalyzedData) (Enrichment, bool) {
+	result, err := newSortedListEnrichment(data.ExtraData, "username")
+	if err != nil {
+		log.Printf("failed to enrich effective members list: %v", err)
+		return nil, false
+	}
+	return result, true
+}
+
+func (e effectiveMembersListEnricher) Parse(data interface{}) (Enrichment, error) {
+	return NewGenericListEnrichmentFromInterface(data)
+}
@@ -0,0 +1,30 @@
+package enrichers
+
+import (
+	"context"
+	"log"
+
+	"github.com/Legit-Labs/legitify/internal/analyzers"
+)
+
+const EnvironmentsList = "environmentsList"
+
+func NewEnvironmentsListEnricher() environmentsListEnricher {
+	return environmentsListEnricher{}
+}
+
+type environmentsListEnricher struct {
+}
+
+func (e environmentsListEnricher) Enrich(_ context.Context, data analyzers.AnalyzedData) (Enrichment, bool) {
+	result, err := newSortedListEnrichment(data.ExtraData, "environment")
+	if err != nil {
+		log.Printf("failed to enrich environments list: %v", err)
+		return nil, false
+	}
+	return result, true
+}
+
+func (e environmentsListEnricher) Parse(data interface{}) (Enrichment, error) {
+	return NewGenericListEnrichmentFromInterface(data)
+}
@@ -1,7 +1,9 @@
 package enrichers
 
 import (
+	"encoding/json"
 	"fmt"
+	"sort"
 
 	"github.com/Legit-Labs/legitify/internal/common/map_utils"
 	"github.com/Legit-Labs/legitify/internal/common/slice_utils"
@@ -38,3 +40,37 @@ func NewGenericListEnrichmentFromInterface(data interface{}) (GenericListEnrichm
 		return GenericListEnrichment(casted), nil
 	}
 }
+
+// newSortedListEnrichment creates a list enrichment from the violations of a rule that yields a set of objects
+// (the keys of extraData are json objects of strings), sorted by the given keys to maintain a deterministic order.
+func newSortedListEnrichment(extraData interface{}, sortKeys ...string) (GenericListEnrichment, error) {
+	asMap, ok := extraData.(map[string]interface{})
+	if !ok {
+		return nil, fmt.Errorf("invalid list extra data")
+	}
+
+	result := []orderedmap.OrderedMap{}
+	for k := range asMap {
+		var entry map[string]string
+		if err := json.Unmarshal([]byte(k), &entry); err != nil {
+			return nil, err
+		}
+
+		result = append(result, *map_utils.ToKeySortedMap(entry))
+	}
+
+	sort.Slice(result, func(i, j int) bool {
+		for _, key := range sortKeys {
+			vi, _ := result[i].Get(key)
+			vj, _ := result[j].Get(key)
+			si, _ := vi.(string)
+			sj, _ := vj.(string)
+			if si != sj {
+				return si < sj
+			}
+		}
+		return false
+	})
+
+	return result, nil
+}
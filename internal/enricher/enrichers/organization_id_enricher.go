@@ -25,6 +25,17 @@ func enrichOrgId(data analyzers.AnalyzedData) (string, bool) {
 		return strconv.FormatInt(*t.Organization.ID, 10), true
 	case githubcollected.RunnerGroup:
 		return strconv.FormatInt(*t.Organization.ID, 10), true
+	case githubcollected.OrganizationApps:
+		return strconv.FormatInt(*t.Organization.ID, 10), true
+	case githubcollected.Team:
+		return strconv.FormatInt(*t.Organization.ID, 10), true
+	case githubcollected.Runner:
+		return strconv.FormatInt(*t.Organization.ID, 10), true
+	case githubcollected.AuditLog:
+		if t.Organization == nil {
+			return "", false
+		}
+		return strconv.FormatInt(*t.Organization.ID, 10), true
 	}
 	return "", false
 }
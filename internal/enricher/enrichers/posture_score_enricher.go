@@ -0,0 +1,82 @@
+package enrichers
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/Legit-Labs/legitify/internal/analyzers"
+)
+
+const PostureScore = "postureScore"
+
+// Score is the posture score (0-100) of an entity, weighted by the severity of its passed and failed policies.
+// Score is nil if none of the policies were evaluated (i.e. all of them were skipped or not applicable).
+type Score struct {
+	Type          string `json:"type"`
+	Name          string `json:"name"`
+	Link          string `json:"link,omitempty"`
+	Score         *int   `json:"score"`
+	Passed        int    `json:"passed"`
+	Failed        int    `json:"failed"`
+	Skipped       int    `json:"skipped"`
+	NotApplicable int    `json:"notApplicable"`
+}
+
+func (s Score) String() string {
+	if s.Score == nil {
+		return "N/A"
+	}
+
+	return fmt.Sprintf("%d/100", *s.Score)
+}
+
+// PostureScoreEnrichment holds the score of the violating entity, and the aggregated scores of its organization and enterprise.
+type PostureScoreEnrichment struct {
+	Entity       Score  `json:"entity"`
+	Organization *Score `json:"organization,omitempty"`
+	Enterprise   *Score `json:"enterprise,omitempty"`
+}
+
+func (e PostureScoreEnrichment) HumanReadable(_ string, _ string) string {
+	var parents []string
+	for _, parent := range []*Score{e.Organization, e.Enterprise} {
+		if parent != nil {
+			parents = append(parents, fmt.Sprintf("%s %s: %s", parent.Type, parent.Name, parent))
+		}
+	}
+
+	if len(parents) == 0 {
+		return e.Entity.String()
+	}
+
+	return fmt.Sprintf("%s (%s)", e.Entity, strings.Join(parents, ", "))
+}
+
+// NewPostureScoreEnricher only parses posture scores: the score depends on all the results of the entity,
+// so it is attached by the enricher.PostureScorer once the analysis is complete.
+func NewPostureScoreEnricher() Enricher {
+	return &postureScoreEnricher{}
+}
+
+type postureScoreEnricher struct {
+}
+
+func (e *postureScoreEnricher) Enrich(_ context.Context, _ analyzers.AnalyzedData) (Enrichment, bool) {
+	return nil, false
+}
+
+func (e *postureScoreEnricher) Parse(data interface{}) (Enrichment, error) {
+	encoded, err := json.Marshal(data)
+	if err != nil {
+		return nil, fmt.Errorf("posture score enricher: %v", err)
+	}
+
+	var result PostureScoreEnrichment
+	if err := json.Unmarshal(encoded, &result); err != nil {
+		return nil, fmt.Errorf("posture score enricher: %v", err)
+	}
+
+	return result, nil
+}
@@ -0,0 +1,30 @@
+package enrichers
+
+import (
+	"context"
+	"log"
+
+	"github.com/Legit-Labs/legitify/internal/analyzers"
+)
+
+const TagsList = "tagsList"
+
+func NewTagsListEnricher() tagsListEnricher {
+	return tagsListEnricher{}
+}
+
+type tagsListEnricher struct {
+}
+
+func (e tagsListEnricher) Enrich(_ context.Context, data analyzers.AnalyzedData) (Enrichment, bool) {
+	result, err := newSortedListEnrichment(data.ExtraData, "tag")
+	if err != nil {
+		log.Printf("failed to enrich tags list: %v", err)
+		return nil, false
+	}
+	return result, true
+}
+
+func (e tagsListEnricher) Parse(data interface{}) (Enrichment, error) {
+	return NewGenericListEnrichmentFromInterface(data)
+}
@@ -0,0 +1,95 @@
+package enrichers
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/Legit-Labs/legitify/internal/analyzers"
+	githubcollected "github.com/Legit-Labs/legitify/internal/collected/github"
+	"github.com/Legit-Labs/legitify/internal/collected/gitlab_collected"
+)
+
+const Terraform = "terraform"
+
+// TerraformResource is the part of a terraform resource that remediates a single failed policy.
+// Resources with the same address are merged by the terraform formatter.
+type TerraformResource struct {
+	Type       string                 `json:"type"`
+	Name       string                 `json:"name"`
+	ImportId   string                 `json:"importId,omitempty"`
+	Attributes map[string]interface{} `json:"attributes"`
+}
+
+func (r TerraformResource) Address() string {
+	return r.Type + "." + r.Name
+}
+
+type TerraformEnrichment []TerraformResource
+
+func (e TerraformEnrichment) HumanReadable(_ string, _ string) string {
+	addresses := make([]string, 0, len(e))
+	for _, r := range e {
+		addresses = append(addresses, r.Address())
+	}
+
+	return strings.Join(addresses, ", ")
+}
+
+func NewTerraformEnricher() Enricher {
+	return &terraformEnricher{}
+}
+
+type terraformEnricher struct {
+}
+
+func (e *terraformEnricher) Enrich(_ context.Context, data analyzers.AnalyzedData) (Enrichment, bool) {
+	if data.Status != analyzers.PolicyFailed {
+		return nil, false
+	}
+
+	var resources []TerraformResource
+	switch t := data.Entity.(type) {
+	case githubcollected.Repository:
+		resources = githubRepositoryTerraform(t, data.PolicyName)
+	case githubcollected.OrganizationActions:
+		resources = githubActionsTerraform(t, data.PolicyName)
+	case gitlab_collected.Repository:
+		resources = gitlabRepositoryTerraform(t, data.PolicyName)
+	}
+
+	if len(resources) == 0 {
+		return nil, false
+	}
+
+	return TerraformEnrichment(resources), true
+}
+
+func (e *terraformEnricher) Parse(data interface{}) (Enrichment, error) {
+	// the parsed json holds ordered maps; re-encoding is the simplest way to restore the typed resources
+	encoded, err := json.Marshal(data)
+	if err != nil {
+		return nil, fmt.Errorf("terraform enricher: %v", err)
+	}
+
+	var result []TerraformResource
+	if err := json.Unmarshal(encoded, &result); err != nil {
+		return nil, fmt.Errorf("terraform enricher: expecting a list of resources: %v", err)
+	}
+
+	return TerraformEnrichment(result), nil
+}
+
+var invalidTerraformNameChars = regexp.MustCompile(`[^a-zA-Z0-9_]`)
+
+// terraformName builds a valid terraform identifier from the given parts
+func terraformName(parts ...string) string {
+	name := invalidTerraformNameChars.ReplaceAllString(strings.Join(parts, "_"), "_")
+	if name == "" || (name[0] >= '0' && name[0] <= '9') {
+		name = "_" + name
+	}
+
+	return strings.ToLower(name)
+}
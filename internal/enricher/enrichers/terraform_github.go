@@ -0,0 +1,109 @@
+package enrichers
+
+import (
+	githubcollected "github.com/Legit-Labs/legitify/internal/collected/github"
+)
+
+// githubBranchProtectionAttributes maps repository policies to the github_branch_protection attributes that fix them
+var githubBranchProtectionAttributes = map[string]map[string]interface{}{
+	"missing_default_branch_protection": {},
+	"code_review_not_required": {
+		"required_pull_request_reviews": map[string]interface{}{"required_approving_review_count": 1},
+	},
+	"code_review_by_two_members_not_required": {
+		"required_pull_request_reviews": map[string]interface{}{"required_approving_review_count": 2},
+	},
+	"code_review_not_limited_to_code_owners": {
+		"required_pull_request_reviews": map[string]interface{}{"require_code_owner_reviews": true},
+	},
+	"dismisses_stale_reviews": {
+		"required_pull_request_reviews": map[string]interface{}{"dismiss_stale_reviews": true},
+	},
+	"review_dismissal_allowed": {
+		"required_pull_request_reviews": map[string]interface{}{"restrict_dismissals": true},
+	},
+	"requires_branches_up_to_date_before_merge": {
+		"required_status_checks": map[string]interface{}{"strict": true},
+	},
+	"pushes_are_not_restricted": {
+		"restrict_pushes": map[string]interface{}{},
+	},
+	"missing_default_branch_protection_deletion":   {"allows_deletions": false},
+	"missing_default_branch_protection_force_push": {"allows_force_pushes": false},
+	"non_linear_history":                           {"required_linear_history": true},
+	"no_signed_commits":                            {"require_signed_commits": true},
+	"no_conversation_resolution":                   {"require_conversation_resolution": true},
+}
+
+// githubWorkflowPermissionsAttributes maps repository policies to the github_workflow_repository_permissions attributes that fix them
+var githubWorkflowPermissionsAttributes = map[string]map[string]interface{}{
+	"token_default_permissions_is_read_write": {"default_workflow_permissions": "read"},
+	"actions_can_approve_pull_requests":       {"can_approve_pull_request_reviews": false},
+}
+
+func githubRepositoryTerraform(repository githubcollected.Repository, policyName string) []TerraformResource {
+	if repository.Repository == nil {
+		return nil
+	}
+	repo := repository.Repository
+	fullName := repo.FullName()
+
+	if attributes, ok := githubBranchProtectionAttributes[policyName]; ok {
+		branch := repo.DefaultBranchRef
+		// an existing rule can't be imported: its status checks, push allowances and bypassers aren't collected,
+		// so applying a resource without them would remove them from the rule
+		if branch == nil || branch.Name == nil || branch.BranchProtectionRule != nil {
+			return nil
+		}
+		return []TerraformResource{{
+			Type: "github_branch_protection",
+			Name: terraformName(fullName, *branch.Name),
+			Attributes: withAttributes(map[string]interface{}{
+				"repository_id": repo.Name,
+				"pattern":       *branch.Name,
+			}, attributes),
+		}}
+	}
+
+	if attributes, ok := githubWorkflowPermissionsAttributes[policyName]; ok {
+		return []TerraformResource{{
+			Type:     "github_workflow_repository_permissions",
+			Name:     terraformName(fullName),
+			ImportId: repo.Name,
+			Attributes: withAttributes(map[string]interface{}{
+				"repository": repo.Name,
+			}, attributes),
+		}}
+	}
+
+	return nil
+}
+
+func githubActionsTerraform(actions githubcollected.OrganizationActions, policyName string) []TerraformResource {
+	if policyName != "all_github_actions_are_allowed" || actions.ActionsPermissions == nil {
+		return nil
+	}
+
+	return []TerraformResource{{
+		Type:     "github_actions_organization_permissions",
+		Name:     terraformName(actions.Name()),
+		ImportId: actions.Name(),
+		Attributes: map[string]interface{}{
+			// required by the provider; kept as is since restricting it might break existing workflows
+			"enabled_repositories": actions.ActionsPermissions.GetEnabledRepositories(),
+			"allowed_actions":      "selected",
+			"allowed_actions_config": map[string]interface{}{
+				"github_owned_allowed": true,
+				"verified_allowed":     true,
+			},
+		},
+	}}
+}
+
+func withAttributes(base map[string]interface{}, attributes map[string]interface{}) map[string]interface{} {
+	for k, v := range attributes {
+		base[k] = v
+	}
+
+	return base
+}
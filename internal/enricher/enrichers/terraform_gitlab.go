@@ -0,0 +1,80 @@
+package enrichers
+
+import (
+	"fmt"
+
+	"github.com/Legit-Labs/legitify/internal/collected/gitlab_collected"
+)
+
+// gitlabBranchProtectionAttributes maps project policies to the gitlab_branch_protection attributes that fix them
+var gitlabBranchProtectionAttributes = map[string]map[string]interface{}{
+	"missing_default_branch_protection":            {},
+	"missing_default_branch_protection_force_push": {"allow_force_push": false},
+	"repository_require_code_owner_reviews_policy": {"code_owner_approval_required": true},
+}
+
+// gitlabApprovalsAttributes maps project policies to the gitlab_project_level_mr_approvals attributes that fix them
+var gitlabApprovalsAttributes = map[string]map[string]interface{}{
+	"repository_allows_review_requester_to_approve_their_own_request": {"merge_requests_author_approval": false},
+	"repository_allows_overriding_approvers":                          {"disable_overriding_approvers_per_merge_request": true},
+	"repository_allows_committer_approvals_policy":                    {"merge_requests_disable_committers_approval": true},
+	"repository_dismiss_stale_reviews":                                {"reset_approvals_on_push": true},
+}
+
+// gitlabApprovalRuleAttributes maps project policies to the gitlab_project_approval_rule attributes that fix them
+var gitlabApprovalRuleAttributes = map[string]map[string]interface{}{
+	"code_review_not_required":                {"approvals_required": 1},
+	"code_review_by_two_members_not_required": {"approvals_required": 2},
+}
+
+func gitlabRepositoryTerraform(repository gitlab_collected.Repository, policyName string) []TerraformResource {
+	project := repository.Project
+	if project == nil {
+		return nil
+	}
+
+	if attributes, ok := gitlabBranchProtectionAttributes[policyName]; ok {
+		if project.DefaultBranch == "" {
+			return nil
+		}
+		// an existing protection isn't imported, since applying a resource with the remediated attributes alone
+		// would reset its access levels to the provider defaults
+		for _, protected := range repository.ProtectedBranches {
+			if protected.Name == project.DefaultBranch {
+				return nil
+			}
+		}
+		return []TerraformResource{{
+			Type: "gitlab_branch_protection",
+			Name: terraformName(project.PathWithNamespace, project.DefaultBranch),
+			Attributes: withAttributes(map[string]interface{}{
+				"project": project.PathWithNamespace,
+				"branch":  project.DefaultBranch,
+			}, attributes),
+		}}
+	}
+
+	if attributes, ok := gitlabApprovalsAttributes[policyName]; ok {
+		return []TerraformResource{{
+			Type:     "gitlab_project_level_mr_approvals",
+			Name:     terraformName(project.PathWithNamespace),
+			ImportId: fmt.Sprintf("%d", project.ID),
+			Attributes: withAttributes(map[string]interface{}{
+				"project": project.PathWithNamespace,
+			}, attributes),
+		}}
+	}
+
+	if attributes, ok := gitlabApprovalRuleAttributes[policyName]; ok {
+		return []TerraformResource{{
+			Type: "gitlab_project_approval_rule",
+			Name: terraformName(project.PathWithNamespace, "code_review"),
+			Attributes: withAttributes(map[string]interface{}{
+				"project": project.PathWithNamespace,
+				"name":    "legitify code review",
+			}, attributes),
+		}}
+	}
+
+	return nil
+}
@@ -0,0 +1,30 @@
+package enrichers
+
+import (
+	"context"
+	"log"
+
+	"github.com/Legit-Labs/legitify/internal/analyzers"
+)
+
+const VariablesList = "variablesList"
+
+func NewVariablesListEnricher() variablesListEnricher {
+	return variablesListEnricher{}
+}
+
+type variablesListEnricher struct {
+}
+
+func (e variablesListEnricher) Enrich(_ context.Context, data analyzers.AnalyzedData) (Enrichment, bool) {
+	result, err := newSortedListEnrichment(data.ExtraData, "variable", "environment")
+	if err != nil {
+		log.Printf("failed to enrich variables list: %v", err)
+		return nil, false
+	}
+	return result, true
+}
+
+func (e variablesListEnricher) Parse(data interface{}) (Enrichment, error) {
+	return NewGenericListEnrichmentFromInterface(data)
+}
@@ -0,0 +1,30 @@
+package enrichers
+
+import (
+	"context"
+	"log"
+
+	"github.com/Legit-Labs/legitify/internal/analyzers"
+)
+
+const WorkflowsList = "workflowsList"
+
+func NewWorkflowsListEnricher() workflowsListEnricher {
+	return workflowsListEnricher{}
+}
+
+type workflowsListEnricher struct {
+}
+
+func (e workflowsListEnricher) Enrich(_ context.Context, data analyzers.AnalyzedData) (Enrichment, bool) {
+	result, err := newSortedListEnrichment(data.ExtraData, "workflow", "job", "step", "action")
+	if err != nil {
+		log.Printf("failed to enrich workflows list: %v", err)
+		return nil, false
+	}
+	return result, true
+}
+
+func (e workflowsListEnricher) Parse(data interface{}) (Enrichment, error) {
+	return NewGenericListEnrichmentFromInterface(data)
+}
@@ -0,0 +1,213 @@
+package enricher
+
+import (
+	"math"
+
+	"github.com/Legit-Labs/legitify/internal/analyzers"
+	"github.com/Legit-Labs/legitify/internal/collected"
+	githubcollected "github.com/Legit-Labs/legitify/internal/collected/github"
+	"github.com/Legit-Labs/legitify/internal/common/namespace"
+	"github.com/Legit-Labs/legitify/internal/common/severity"
+	"github.com/Legit-Labs/legitify/internal/enricher/enrichers"
+)
+
+// SeverityWeights is the weight of a policy in the posture score, by its severity
+var SeverityWeights = map[severity.Severity]int{
+	severity.Critical: 10,
+	severity.High:     5,
+	severity.Medium:   2,
+	severity.Low:      1,
+	severity.Unknown:  1,
+}
+
+// PostureScorer attaches the posture score enrichment to every result.
+// Since the score of an entity depends on all of its results, the results are held until the input is exhausted.
+type PostureScorer interface {
+	Score(inputChannel <-chan EnrichedData) <-chan EnrichedData
+}
+
+func NewPostureScorer() PostureScorer {
+	return &postureScorer{}
+}
+
+type postureScorer struct {
+}
+
+type scoreKey struct {
+	entityType string
+	link       string
+}
+
+type scoreAccumulator struct {
+	score        enrichers.Score
+	passedWeight int
+	failedWeight int
+}
+
+func newScoreAccumulator(entityType string, name string, link string) *scoreAccumulator {
+	return &scoreAccumulator{
+		score: enrichers.Score{
+			Type: entityType,
+			Name: name,
+			Link: link,
+		},
+	}
+}
+
+func (a *scoreAccumulator) add(data EnrichedData) {
+	weight, ok := SeverityWeights[data.Severity]
+	if !ok {
+		weight = SeverityWeights[severity.Unknown]
+	}
+
+	switch data.Status {
+	case analyzers.PolicyPassed:
+		a.score.Passed++
+		a.passedWeight += weight
+	case analyzers.PolicyFailed:
+		a.score.Failed++
+		a.failedWeight += weight
+	case analyzers.PolicySkipped:
+		a.score.Skipped++
+	case analyzers.PolicyNotApplicable:
+		a.score.NotApplicable++
+	}
+}
+
+func (a *scoreAccumulator) result() *enrichers.Score {
+	result := a.score
+	if total := a.passedWeight + a.failedWeight; total > 0 {
+		score := int(math.Round(100 * float64(a.passedWeight) / float64(total)))
+		result.Score = &score
+	}
+
+	return &result
+}
+
+func (s *postureScorer) Score(inputChannel <-chan EnrichedData) <-chan EnrichedData {
+	outputChannel := make(chan EnrichedData)
+
+	go func() {
+		defer close(outputChannel)
+
+		var results []EnrichedData
+		for data := range inputChannel {
+			results = append(results, data)
+		}
+
+		for _, data := range s.scoreAll(results) {
+			outputChannel <- data
+		}
+	}()
+
+	return outputChannel
+}
+
+func (s *postureScorer) scoreAll(results []EnrichedData) []EnrichedData {
+	entities := make(map[scoreKey]*scoreAccumulator)
+	organizations := make(map[string]*scoreAccumulator)
+	enterprises := make(map[string]*scoreAccumulator)
+	// enterpriseOf maps the GitHub organizations to the enterprise they belong to,
+	// while a GitLab instance includes all the analyzed groups
+	enterpriseOf := make(map[string]string)
+	var instance string
+	for _, data := range results {
+		if data.Entity.ViolationEntityType() != namespace.Enterprise {
+			continue
+		}
+		name := data.Entity.Name()
+		if _, ok := enterprises[name]; !ok {
+			enterprises[name] = newScoreAccumulator(namespace.Enterprise, name, data.CanonicalLink)
+		}
+		if ent, ok := data.Entity.(githubcollected.Enterprise); ok {
+			for _, org := range ent.Organizations {
+				enterpriseOf[org] = name
+			}
+		} else {
+			instance = name
+		}
+	}
+	enterpriseNameOf := func(data EnrichedData) string {
+		if data.Entity.ViolationEntityType() == namespace.Enterprise {
+			return data.Entity.Name()
+		}
+		if name, ok := enterpriseOf[collected.OrganizationOf(data.Entity)]; ok {
+			return name
+		}
+		return instance
+	}
+
+	for _, data := range results {
+		key := scoreKey{entityType: data.Entity.ViolationEntityType(), link: data.CanonicalLink}
+		entity, ok := entities[key]
+		if !ok {
+			entity = newScoreAccumulator(key.entityType, scoreName(data.Entity), data.CanonicalLink)
+			entities[key] = entity
+		}
+		entity.add(data)
+
+		if enterprise, ok := enterprises[enterpriseNameOf(data)]; ok {
+			enterprise.add(data)
+		}
+
+		org := collected.OrganizationOf(data.Entity)
+
+		if org != "" && key.entityType != namespace.Enterprise {
+			organization, ok := organizations[org]
+			if !ok {
+				organization = newScoreAccumulator(namespace.Organization, org, "")
+				organizations[org] = organization
+			}
+			if key.entityType == namespace.Organization {
+				organization.score.Link = data.CanonicalLink
+			}
+			organization.add(data)
+		}
+	}
+
+	entityScores := make(map[scoreKey]*enrichers.Score, len(entities))
+	for key, acc := range entities {
+		entityScores[key] = acc.result()
+	}
+	organizationScores := make(map[string]*enrichers.Score, len(organizations))
+	for name, acc := range organizations {
+		organizationScores[name] = acc.result()
+	}
+	enterpriseScores := make(map[string]*enrichers.Score, len(enterprises))
+	for name, acc := range enterprises {
+		enterpriseScores[name] = acc.result()
+	}
+
+	for i, data := range results {
+		key := scoreKey{entityType: data.Entity.ViolationEntityType(), link: data.CanonicalLink}
+		enrichment := enrichers.PostureScoreEnrichment{
+			Entity: *entityScores[key],
+		}
+		if key.entityType != namespace.Organization && key.entityType != namespace.Enterprise {
+			enrichment.Organization = organizationScores[collected.OrganizationOf(data.Entity)]
+		}
+		if key.entityType != namespace.Enterprise {
+			enrichment.Enterprise = enterpriseScores[enterpriseNameOf(data)]
+		}
+
+		if data.Enrichers == nil {
+			results[i].Enrichers = make(map[string]enrichers.Enrichment)
+		}
+		results[i].Enrichers[enrichers.PostureScore] = enrichment
+	}
+
+	return results
+}
+
+// scoreName returns a name that identifies the entity among the entities of the same type
+func scoreName(entity collected.Entity) string {
+	if entityType := entity.ViolationEntityType(); entityType != namespace.Repository && entityType != namespace.Branch {
+		return entity.Name()
+	}
+
+	if org := collected.OrganizationOf(entity); org != "" {
+		return org + "/" + entity.Name()
+	}
+
+	return entity.Name()
+}
@@ -0,0 +1,132 @@
+package enricher_test
+
+import (
+	"testing"
+
+	"github.com/Legit-Labs/legitify/internal/analyzers"
+	"github.com/Legit-Labs/legitify/internal/collected"
+	githubcollected "github.com/Legit-Labs/legitify/internal/collected/github"
+	"github.com/Legit-Labs/legitify/internal/common/namespace"
+	"github.com/Legit-Labs/legitify/internal/common/severity"
+	"github.com/Legit-Labs/legitify/internal/enricher"
+	"github.com/Legit-Labs/legitify/internal/enricher/enrichers"
+	"github.com/google/go-github/v53/github"
+	"github.com/stretchr/testify/require"
+)
+
+func scoredRepository(name string) collected.Entity {
+	return githubcollected.Repository{
+		Repository: &githubcollected.GitHubQLRepository{
+			Name: name,
+			Url:  "https://github.com/org/" + name,
+		},
+	}
+}
+
+func scoredOrganization(login string) collected.Entity {
+	link := "https://github.com/" + login
+	return githubcollected.Organization{
+		Organization: &githubcollected.ExtendedOrg{
+			Organization: github.Organization{Login: &login, HTMLURL: &link},
+		},
+	}
+}
+
+func scoredResult(entity collected.Entity, sev severity.Severity, status analyzers.PolicyStatus) enricher.EnrichedData {
+	return enricher.EnrichedData{
+		Entity:        entity,
+		Severity:      sev,
+		Status:        status,
+		CanonicalLink: entity.CanonicalLink(),
+		Enrichers:     map[string]enrichers.Enrichment{},
+	}
+}
+
+func score(t *testing.T, results ...enricher.EnrichedData) []enricher.EnrichedData {
+	input := make(chan enricher.EnrichedData, len(results))
+	for _, r := range results {
+		input <- r
+	}
+	close(input)
+
+	var output []enricher.EnrichedData
+	for data := range enricher.NewPostureScorer().Score(input) {
+		output = append(output, data)
+	}
+	require.Len(t, output, len(results))
+
+	return output
+}
+
+func postureScore(t *testing.T, data enricher.EnrichedData) enrichers.PostureScoreEnrichment {
+	enrichment, ok := data.Enrichers[enrichers.PostureScore]
+	require.True(t, ok, "expected a posture score for every result")
+	return enrichment.(enrichers.PostureScoreEnrichment)
+}
+
+func TestPostureScorer(t *testing.T) {
+	a := scoredRepository("a")
+	b := scoredRepository("b")
+
+	output := score(t,
+		// a: 10 passed, 5 failed -> 67
+		scoredResult(a, severity.Critical, analyzers.PolicyPassed),
+		scoredResult(a, severity.High, analyzers.PolicyFailed),
+		scoredResult(a, severity.High, analyzers.PolicySkipped),
+		// b: everything skipped or not applicable -> no score
+		scoredResult(b, severity.Low, analyzers.PolicySkipped),
+		scoredResult(b, severity.Critical, analyzers.PolicyNotApplicable),
+		scoredResult(scoredOrganization("org"), severity.Medium, analyzers.PolicyFailed),
+	)
+
+	scoreA := postureScore(t, output[0])
+	require.Equal(t, namespace.Repository, scoreA.Entity.Type)
+	require.Equal(t, "org/a", scoreA.Entity.Name)
+	require.NotNil(t, scoreA.Entity.Score)
+	require.Equal(t, 67, *scoreA.Entity.Score)
+	require.Equal(t, 1, scoreA.Entity.Passed)
+	require.Equal(t, 1, scoreA.Entity.Failed)
+	require.Equal(t, 1, scoreA.Entity.Skipped)
+
+	// the organization aggregates the results of its repositories: 10 passed, 5 + 2 failed -> 59
+	require.NotNil(t, scoreA.Organization)
+	require.Equal(t, "org", scoreA.Organization.Name)
+	require.Equal(t, 59, *scoreA.Organization.Score)
+	require.Nil(t, scoreA.Enterprise, "no enterprise was analyzed")
+
+	scoreB := postureScore(t, output[3])
+	require.Nil(t, scoreB.Entity.Score)
+	require.Equal(t, 1, scoreB.Entity.NotApplicable)
+	require.Equal(t, "N/A", scoreB.HumanReadable("", "")[:3])
+
+	scoreOrg := postureScore(t, output[5])
+	require.Equal(t, namespace.Organization, scoreOrg.Entity.Type)
+	require.Equal(t, 0, *scoreOrg.Entity.Score)
+	require.Nil(t, scoreOrg.Organization, "an organization is not its own parent")
+}
+
+func TestPostureScorer_Enterprises(t *testing.T) {
+	first := githubcollected.Enterprise{EnterpriseName: "first", Url: "https://github.com/enterprises/first", Organizations: []string{"org"}}
+	second := githubcollected.Enterprise{EnterpriseName: "second", Url: "https://github.com/enterprises/second", Organizations: []string{"other"}}
+
+	output := score(t,
+		scoredResult(first, severity.High, analyzers.PolicyPassed),
+		scoredResult(second, severity.High, analyzers.PolicyFailed),
+		scoredResult(scoredRepository("a"), severity.High, analyzers.PolicyFailed),
+		scoredResult(scoredOrganization("unrelated"), severity.High, analyzers.PolicyFailed),
+	)
+
+	scoreFirst := postureScore(t, output[0])
+	require.Nil(t, scoreFirst.Enterprise, "an enterprise is not its own parent")
+	require.Nil(t, scoreFirst.Organization, "an enterprise is not an organization")
+	require.Equal(t, 100, *scoreFirst.Entity.Score)
+
+	// the enterprise aggregates its own results and the results of its organizations: 5 passed, 5 failed -> 50
+	scoreA := postureScore(t, output[2])
+	require.NotNil(t, scoreA.Enterprise)
+	require.Equal(t, "first", scoreA.Enterprise.Name)
+	require.Equal(t, 50, *scoreA.Enterprise.Score)
+	require.Equal(t, "org", scoreA.Organization.Name)
+
+	require.Nil(t, postureScore(t, output[3]).Enterprise, "the organization doesn't belong to an analyzed enterprise")
+}
@@ -0,0 +1,103 @@
+package issues
+
+import (
+	"context"
+
+	ghclient "github.com/Legit-Labs/legitify/internal/clients/github"
+	"github.com/Legit-Labs/legitify/internal/clients/github/pagination"
+	"github.com/Legit-Labs/legitify/internal/collected"
+	ghcollected "github.com/Legit-Labs/legitify/internal/collected/github"
+	"github.com/Legit-Labs/legitify/internal/common/slice_utils"
+	"github.com/Legit-Labs/legitify/internal/common/types"
+	"github.com/google/go-github/v53/github"
+)
+
+type githubClient struct {
+	context context.Context
+	client  *ghclient.Client
+}
+
+func NewGitHubClient(ctx context.Context, client *ghclient.Client) Client {
+	return &githubClient{
+		context: ctx,
+		client:  client,
+	}
+}
+
+func (c *githubClient) RepositoryOf(entity collected.Entity) (types.RepositoryWithOwner, bool) {
+	var repository *ghcollected.GitHubQLRepository
+	switch t := entity.(type) {
+	case ghcollected.Repository:
+		repository = t.Repository
+	case ghcollected.Branch:
+		repository = t.Repository
+	}
+	if repository == nil || repository.Owner() == "" {
+		return types.RepositoryWithOwner{}, false
+	}
+
+	return types.NewRepositoryWithOwner(repository.FullName(), ""), true
+}
+
+func (c *githubClient) OpenIssues(repository types.RepositoryWithOwner) ([]Issue, error) {
+	opts := &github.IssueListByRepoOptions{
+		State:  "open",
+		Labels: []string{Label},
+	}
+	res, err := pagination.New[*github.Issue](c.client.Client().Issues.ListByRepo, opts).Sync(c.context, repository.Owner, repository.Name)
+	if err != nil {
+		return nil, err
+	}
+
+	var result []Issue
+	for _, i := range res.Collected {
+		labels := slice_utils.Map(i.Labels, func(l *github.Label) string {
+			return l.GetName()
+		})
+		fingerprint, ok := FingerprintFromLabels(labels)
+		if !ok {
+			continue
+		}
+		result = append(result, Issue{
+			Number:      i.GetNumber(),
+			Title:       i.GetTitle(),
+			Body:        i.GetBody(),
+			Fingerprint: fingerprint,
+		})
+	}
+
+	return result, nil
+}
+
+func (c *githubClient) Create(repository types.RepositoryWithOwner, issue Issue) error {
+	labels := issue.Labels()
+	_, _, err := c.client.Client().Issues.Create(c.context, repository.Owner, repository.Name, &github.IssueRequest{
+		Title:  &issue.Title,
+		Body:   &issue.Body,
+		Labels: &labels,
+	})
+	return err
+}
+
+func (c *githubClient) Update(repository types.RepositoryWithOwner, issue Issue) error {
+	_, _, err := c.client.Client().Issues.Edit(c.context, repository.Owner, repository.Name, issue.Number, &github.IssueRequest{
+		Title: &issue.Title,
+		Body:  &issue.Body,
+	})
+	return err
+}
+
+func (c *githubClient) Close(repository types.RepositoryWithOwner, issue Issue, comment string) error {
+	_, _, err := c.client.Client().Issues.CreateComment(c.context, repository.Owner, repository.Name, issue.Number, &github.IssueComment{
+		Body: &comment,
+	})
+	if err != nil {
+		return err
+	}
+
+	_, _, err = c.client.Client().Issues.Edit(c.context, repository.Owner, repository.Name, issue.Number, &github.IssueRequest{
+		State:       github.String("closed"),
+		StateReason: github.String("completed"),
+	})
+	return err
+}
@@ -0,0 +1,88 @@
+package issues
+
+import (
+	glclient "github.com/Legit-Labs/legitify/internal/clients/gitlab"
+	"github.com/Legit-Labs/legitify/internal/clients/gitlab/pagination"
+	"github.com/Legit-Labs/legitify/internal/collected"
+	"github.com/Legit-Labs/legitify/internal/collected/gitlab_collected"
+	"github.com/Legit-Labs/legitify/internal/common/types"
+	"github.com/xanzy/go-gitlab"
+)
+
+type gitlabClient struct {
+	client *glclient.Client
+}
+
+func NewGitLabClient(client *glclient.Client) Client {
+	return &gitlabClient{
+		client: client,
+	}
+}
+
+func (c *gitlabClient) RepositoryOf(entity collected.Entity) (types.RepositoryWithOwner, bool) {
+	repo, ok := entity.(gitlab_collected.Repository)
+	if !ok || repo.Project == nil {
+		return types.RepositoryWithOwner{}, false
+	}
+
+	return types.NewRepositoryWithOwner(repo.PathWithNamespace, ""), true
+}
+
+func (c *gitlabClient) OpenIssues(repository types.RepositoryWithOwner) ([]Issue, error) {
+	opts := &gitlab.ListProjectIssuesOptions{
+		State:  gitlab.String("opened"),
+		Labels: &gitlab.Labels{Label},
+	}
+	res, err := pagination.New[*gitlab.Issue](c.client.Client().Issues.ListProjectIssues, opts).Sync(repository.String())
+	if err != nil {
+		return nil, err
+	}
+
+	var result []Issue
+	for _, i := range res.Collected {
+		fingerprint, ok := FingerprintFromLabels(i.Labels)
+		if !ok {
+			continue
+		}
+		result = append(result, Issue{
+			Number:      i.IID,
+			Title:       i.Title,
+			Body:        i.Description,
+			Fingerprint: fingerprint,
+		})
+	}
+
+	return result, nil
+}
+
+func (c *gitlabClient) Create(repository types.RepositoryWithOwner, issue Issue) error {
+	labels := gitlab.Labels(issue.Labels())
+	_, _, err := c.client.Client().Issues.CreateIssue(repository.String(), &gitlab.CreateIssueOptions{
+		Title:       &issue.Title,
+		Description: &issue.Body,
+		Labels:      &labels,
+	})
+	return err
+}
+
+func (c *gitlabClient) Update(repository types.RepositoryWithOwner, issue Issue) error {
+	_, _, err := c.client.Client().Issues.UpdateIssue(repository.String(), issue.Number, &gitlab.UpdateIssueOptions{
+		Title:       &issue.Title,
+		Description: &issue.Body,
+	})
+	return err
+}
+
+func (c *gitlabClient) Close(repository types.RepositoryWithOwner, issue Issue, comment string) error {
+	_, _, err := c.client.Client().Notes.CreateIssueNote(repository.String(), issue.Number, &gitlab.CreateIssueNoteOptions{
+		Body: &comment,
+	})
+	if err != nil {
+		return err
+	}
+
+	_, _, err = c.client.Client().Issues.UpdateIssue(repository.String(), issue.Number, &gitlab.UpdateIssueOptions{
+		StateEvent: gitlab.String("close"),
+	})
+	return err
+}
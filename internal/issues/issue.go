@@ -0,0 +1,127 @@
+package issues
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"strings"
+
+	"github.com/Legit-Labs/legitify/internal/collected"
+	"github.com/Legit-Labs/legitify/internal/common/map_utils"
+	"github.com/Legit-Labs/legitify/internal/common/slice_utils"
+	"github.com/Legit-Labs/legitify/internal/common/types"
+	"github.com/Legit-Labs/legitify/internal/enricher"
+	"github.com/Legit-Labs/legitify/internal/enricher/enrichers"
+)
+
+const (
+	// Label is attached to every issue opened by legitify
+	Label = "legitify"
+	// fingerprintLabelPrefix prefixes the label that identifies the policy & entity of an issue across runs
+	fingerprintLabelPrefix = "legitify:"
+	fingerprintLength      = 16
+
+	resolvedComment      = "legitify: the policy passes for this entity in the latest analysis. Closing the issue."
+	notApplicableComment = "legitify: the policy no longer applies to this entity in the latest analysis. Closing the issue."
+)
+
+type Issue struct {
+	Number      int
+	Title       string
+	Body        string
+	Fingerprint string
+}
+
+func (i Issue) Labels() []string {
+	return []string{Label, FingerprintLabel(i.Fingerprint)}
+}
+
+// Client is implemented by each SCM to manage the issues opened by legitify.
+type Client interface {
+	// RepositoryOf returns the repository in which the issues of the entity should be tracked
+	RepositoryOf(entity collected.Entity) (types.RepositoryWithOwner, bool)
+	// OpenIssues returns the open issues that were created by legitify
+	OpenIssues(repository types.RepositoryWithOwner) ([]Issue, error)
+	Create(repository types.RepositoryWithOwner, issue Issue) error
+	Update(repository types.RepositoryWithOwner, issue Issue) error
+	Close(repository types.RepositoryWithOwner, issue Issue, comment string) error
+}
+
+// Fingerprint identifies a (policy, entity) pair so that reruns find the same issue.
+func Fingerprint(data enricher.EnrichedData) string {
+	sum := sha256.Sum256([]byte(data.FullyQualifiedPolicyName + "|" + data.Entity.CanonicalLink()))
+	return hex.EncodeToString(sum[:])[:fingerprintLength]
+}
+
+func FingerprintLabel(fingerprint string) string {
+	return fingerprintLabelPrefix + fingerprint
+}
+
+// FingerprintFromLabels returns the fingerprint encoded in the issue labels, if any.
+func FingerprintFromLabels(labels []string) (string, bool) {
+	for _, label := range labels {
+		if strings.HasPrefix(label, fingerprintLabelPrefix) {
+			return strings.TrimPrefix(label, fingerprintLabelPrefix), true
+		}
+	}
+
+	return "", false
+}
+
+func newIssue(data enricher.EnrichedData, fingerprint string) Issue {
+	return Issue{
+		Title:       fmt.Sprintf("[legitify] %s (%s)", data.Title, data.Entity.Name()),
+		Body:        issueBody(data),
+		Fingerprint: fingerprint,
+	}
+}
+
+func issueBody(data enricher.EnrichedData) string {
+	var sb strings.Builder
+
+	sb.WriteString(fmt.Sprintf("## %s\n\n", data.Title))
+	sb.WriteString(fmt.Sprintf("%s\n\n", data.Description))
+	sb.WriteString(fmt.Sprintf("**Policy Name:** `%s`  \n", data.PolicyName))
+	sb.WriteString(fmt.Sprintf("**Namespace:** %s  \n", data.Namespace))
+	sb.WriteString(fmt.Sprintf("**Severity:** %s  \n", data.Severity))
+	sb.WriteString(fmt.Sprintf("**Link to %s:** %s\n", data.Entity.ViolationEntityType(), data.CanonicalLink))
+
+	writeList(&sb, "Threat", data.Threat)
+	writeList(&sb, "Remediation Steps", data.RemediationSteps)
+	writeAux(&sb, data.Enrichers)
+
+	sb.WriteString("\n---\n")
+	sb.WriteString("_This issue is managed by legitify and will be closed automatically once the policy passes._\n")
+
+	return sb.String()
+}
+
+func writeList(sb *strings.Builder, title string, list []string) {
+	if len(list) == 0 {
+		return
+	}
+
+	sb.WriteString(fmt.Sprintf("\n### %s\n", title))
+	for _, line := range list {
+		sb.WriteString(fmt.Sprintf("- %s\n", line))
+	}
+}
+
+func writeAux(sb *strings.Builder, aux map[string]enrichers.Enrichment) {
+	if len(aux) == 0 {
+		return
+	}
+
+	var lines []string
+	sorted := map_utils.ToKeySortedMap(aux)
+	for _, k := range sorted.Keys() {
+		// the posture score changes whenever any result of the entity changes, which would update the issue needlessly
+		if slice_utils.Contains(enricher.DefaultEnrichers, k) || k == enrichers.PostureScore {
+			continue
+		}
+		v := map_utils.UnsafeGet[enrichers.Enrichment](sorted, k)
+		lines = append(lines, fmt.Sprintf("%s: %s", k, strings.TrimSpace(v.HumanReadable("  ", "\n"))))
+	}
+
+	writeList(sb, "Auxiliary Info", lines)
+}
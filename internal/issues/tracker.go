@@ -0,0 +1,186 @@
+package issues
+
+import (
+	"fmt"
+	"log"
+	"sync"
+
+	"github.com/Legit-Labs/legitify/internal/analyzers"
+	"github.com/Legit-Labs/legitify/internal/common/types"
+	"github.com/Legit-Labs/legitify/internal/enricher"
+	"github.com/Legit-Labs/legitify/internal/screen"
+)
+
+type Tracker interface {
+	Track(inputChannel <-chan enricher.EnrichedData) <-chan enricher.EnrichedData
+	Sync() error
+}
+
+func NewTracker(client Client, enabled bool, centralRepository *types.RepositoryWithOwner) Tracker {
+	return &tracker{
+		client:            client,
+		enabled:           enabled,
+		centralRepository: centralRepository,
+		targets:           make(map[string]*target),
+	}
+}
+
+type tracker struct {
+	client            Client
+	enabled           bool
+	centralRepository *types.RepositoryWithOwner
+	lock              sync.Mutex
+	targets           map[string]*target
+	untrackedOnce     sync.Once
+}
+
+// target holds the results of a single repository in which issues are tracked.
+// resolved maps the fingerprints of the policies that passed (or no longer apply) to the comment that closes their issues.
+type target struct {
+	repository types.RepositoryWithOwner
+	failed     map[string]Issue
+	resolved   map[string]string
+}
+
+type syncSummary struct {
+	created int
+	updated int
+	closed  int
+}
+
+// Track records the policy results that pass through the channel, without altering them,
+// so they can later be synced to the issue tracker.
+func (t *tracker) Track(inputChannel <-chan enricher.EnrichedData) <-chan enricher.EnrichedData {
+	if !t.enabled {
+		return inputChannel
+	}
+
+	outputChannel := make(chan enricher.EnrichedData)
+	go func() {
+		defer close(outputChannel)
+		for data := range inputChannel {
+			t.record(data)
+			outputChannel <- data
+		}
+	}()
+
+	return outputChannel
+}
+
+func (t *tracker) record(data enricher.EnrichedData) {
+	// a policy that no longer applies to the entity (see the appliesTo annotation) closes its issue just like a passed one
+	if data.Status != analyzers.PolicyFailed && data.Status != analyzers.PolicyPassed && data.Status != analyzers.PolicyNotApplicable {
+		return
+	}
+
+	repository, ok := t.resolveRepository(data)
+	if !ok {
+		t.untrackedOnce.Do(func() {
+			log.Printf("only repository-level policies are tracked (e.g. not %s of %s); use --issues-repo to set a central repository",
+				data.PolicyName, data.Entity.Name())
+		})
+		return
+	}
+
+	t.lock.Lock()
+	defer t.lock.Unlock()
+
+	key := repository.String()
+	current, ok := t.targets[key]
+	if !ok {
+		current = &target{
+			repository: repository,
+			failed:     make(map[string]Issue),
+			resolved:   make(map[string]string),
+		}
+		t.targets[key] = current
+	}
+
+	fingerprint := Fingerprint(data)
+	switch data.Status {
+	case analyzers.PolicyFailed:
+		current.failed[fingerprint] = newIssue(data, fingerprint)
+	case analyzers.PolicyNotApplicable:
+		current.resolved[fingerprint] = notApplicableComment
+	default:
+		current.resolved[fingerprint] = resolvedComment
+	}
+}
+
+func (t *tracker) resolveRepository(data enricher.EnrichedData) (types.RepositoryWithOwner, bool) {
+	if t.centralRepository != nil {
+		return *t.centralRepository, true
+	}
+
+	return t.client.RepositoryOf(data.Entity)
+}
+
+// Sync opens an issue for each newly failed policy, updates the issues of policies that are still failing
+// and closes the issues of policies that passed.
+func (t *tracker) Sync() error {
+	if !t.enabled {
+		return nil
+	}
+
+	t.lock.Lock()
+	defer t.lock.Unlock()
+
+	var summary syncSummary
+	var failedTargets int
+	for _, current := range t.targets {
+		if err := t.syncTarget(current, &summary); err != nil {
+			log.Printf("failed to sync issues for %s: %v", current.repository.String(), err)
+			failedTargets++
+		}
+	}
+
+	screen.Printf("Issues: %d created, %d updated, %d closed\n", summary.created, summary.updated, summary.closed)
+
+	if failedTargets > 0 {
+		return fmt.Errorf("failed to sync issues for %d out of %d repositories", failedTargets, len(t.targets))
+	}
+
+	return nil
+}
+
+func (t *tracker) syncTarget(current *target, summary *syncSummary) error {
+	open, err := t.client.OpenIssues(current.repository)
+	if err != nil {
+		return err
+	}
+
+	existing := make(map[string]Issue, len(open))
+	for _, issue := range open {
+		existing[issue.Fingerprint] = issue
+	}
+
+	for fingerprint, issue := range current.failed {
+		prev, ok := existing[fingerprint]
+		switch {
+		case !ok:
+			if err := t.client.Create(current.repository, issue); err != nil {
+				return err
+			}
+			summary.created++
+		case prev.Title != issue.Title || prev.Body != issue.Body:
+			issue.Number = prev.Number
+			if err := t.client.Update(current.repository, issue); err != nil {
+				return err
+			}
+			summary.updated++
+		}
+	}
+
+	for fingerprint, comment := range current.resolved {
+		prev, ok := existing[fingerprint]
+		if !ok {
+			continue
+		}
+		if err := t.client.Close(current.repository, prev, comment); err != nil {
+			return err
+		}
+		summary.closed++
+	}
+
+	return nil
+}
@@ -0,0 +1,164 @@
+package issues
+
+import (
+	"testing"
+
+	"github.com/Legit-Labs/legitify/internal/analyzers"
+	"github.com/Legit-Labs/legitify/internal/collected"
+	githubcollected "github.com/Legit-Labs/legitify/internal/collected/github"
+	"github.com/Legit-Labs/legitify/internal/common/types"
+	"github.com/Legit-Labs/legitify/internal/enricher"
+	"github.com/stretchr/testify/require"
+)
+
+type clientMock struct {
+	open    []Issue
+	created []Issue
+	updated []Issue
+	closed  []Issue
+	// comments of the closed issues, by their number
+	comments map[int]string
+}
+
+func (m *clientMock) RepositoryOf(entity collected.Entity) (types.RepositoryWithOwner, bool) {
+	if _, ok := entity.(githubcollected.Repository); !ok {
+		return types.RepositoryWithOwner{}, false
+	}
+	return types.NewRepositoryWithOwner("org/"+entity.Name(), ""), true
+}
+
+func (m *clientMock) OpenIssues(_ types.RepositoryWithOwner) ([]Issue, error) {
+	return m.open, nil
+}
+
+func (m *clientMock) Create(_ types.RepositoryWithOwner, issue Issue) error {
+	m.created = append(m.created, issue)
+	return nil
+}
+
+func (m *clientMock) Update(_ types.RepositoryWithOwner, issue Issue) error {
+	m.updated = append(m.updated, issue)
+	return nil
+}
+
+func (m *clientMock) Close(_ types.RepositoryWithOwner, issue Issue, comment string) error {
+	m.closed = append(m.closed, issue)
+	if m.comments == nil {
+		m.comments = make(map[int]string)
+	}
+	m.comments[issue.Number] = comment
+	return nil
+}
+
+func repositoryEntity(name string) collected.Entity {
+	return githubcollected.Repository{
+		Repository: &githubcollected.GitHubQLRepository{
+			Name: name,
+			Url:  "https://github.com/org/" + name,
+		},
+	}
+}
+
+func enrichedData(entity collected.Entity, policyName string, status analyzers.PolicyStatus) enricher.EnrichedData {
+	return enricher.EnrichedData{
+		Entity:                   entity,
+		PolicyName:               policyName,
+		FullyQualifiedPolicyName: "data.repository." + policyName,
+		Title:                    policyName,
+		CanonicalLink:            entity.CanonicalLink(),
+		Status:                   status,
+	}
+}
+
+func track(t *testing.T, tr Tracker, data ...enricher.EnrichedData) {
+	input := make(chan enricher.EnrichedData, len(data))
+	for _, d := range data {
+		input <- d
+	}
+	close(input)
+
+	count := 0
+	for range tr.Track(input) {
+		count++
+	}
+	require.Equal(t, len(data), count, "tracking should pass all the data through")
+}
+
+func TestTracker_Disabled(t *testing.T) {
+	client := &clientMock{}
+	tr := NewTracker(client, false, nil)
+
+	track(t, tr, enrichedData(repositoryEntity("repo"), "policy", analyzers.PolicyFailed))
+
+	require.Nil(t, tr.Sync())
+	require.Empty(t, client.created)
+}
+
+func TestTracker_Sync(t *testing.T) {
+	repo := repositoryEntity("repo")
+	failedNew := enrichedData(repo, "new_failure", analyzers.PolicyFailed)
+	failedExisting := enrichedData(repo, "existing_failure", analyzers.PolicyFailed)
+	failedUnchanged := enrichedData(repo, "unchanged_failure", analyzers.PolicyFailed)
+	fixed := enrichedData(repo, "fixed", analyzers.PolicyPassed)
+	passed := enrichedData(repo, "always_passed", analyzers.PolicyPassed)
+	skipped := enrichedData(repo, "skipped", analyzers.PolicySkipped)
+	notApplicable := enrichedData(repo, "not_applicable", analyzers.PolicyNotApplicable)
+
+	unchanged := newIssue(failedUnchanged, Fingerprint(failedUnchanged))
+	unchanged.Number = 3
+	client := &clientMock{
+		open: []Issue{
+			{Number: 1, Title: "outdated", Fingerprint: Fingerprint(failedExisting)},
+			{Number: 2, Title: "fixed", Fingerprint: Fingerprint(fixed)},
+			{Number: 4, Title: "no longer applicable", Fingerprint: Fingerprint(notApplicable)},
+			unchanged,
+		},
+	}
+	tr := NewTracker(client, true, nil)
+
+	track(t, tr, failedNew, failedExisting, failedUnchanged, fixed, passed, skipped, notApplicable)
+	require.Nil(t, tr.Sync())
+
+	require.Len(t, client.created, 1)
+	require.Equal(t, Fingerprint(failedNew), client.created[0].Fingerprint)
+
+	require.Len(t, client.updated, 1)
+	require.Equal(t, 1, client.updated[0].Number)
+
+	require.Len(t, client.closed, 2)
+	require.ElementsMatch(t, []int{2, 4}, []int{client.closed[0].Number, client.closed[1].Number})
+	require.Equal(t, resolvedComment, client.comments[2])
+	require.Equal(t, notApplicableComment, client.comments[4])
+}
+
+func TestTracker_CentralRepository(t *testing.T) {
+	org := githubcollected.Organization{
+		Organization: &githubcollected.ExtendedOrg{},
+	}
+	org.Organization.Login = new(string)
+	org.Organization.HTMLURL = new(string)
+
+	client := &clientMock{}
+	withoutCentral := NewTracker(client, true, nil)
+	track(t, withoutCentral, enrichedData(org, "org_policy", analyzers.PolicyFailed))
+	require.Nil(t, withoutCentral.Sync())
+	require.Empty(t, client.created, "non-repository entities have no issue repository by default")
+
+	central := types.NewRepositoryWithOwner("org/security", "")
+	withCentral := NewTracker(client, true, &central)
+	track(t, withCentral, enrichedData(org, "org_policy", analyzers.PolicyFailed))
+	require.Nil(t, withCentral.Sync())
+	require.Len(t, client.created, 1)
+}
+
+func TestFingerprint_Stable(t *testing.T) {
+	a := enrichedData(repositoryEntity("a"), "policy", analyzers.PolicyFailed)
+	b := enrichedData(repositoryEntity("b"), "policy", analyzers.PolicyFailed)
+
+	require.Equal(t, Fingerprint(a), Fingerprint(a))
+	require.NotEqual(t, Fingerprint(a), Fingerprint(b))
+
+	fingerprint, ok := FingerprintFromLabels([]string{"bug", FingerprintLabel(Fingerprint(a))})
+	require.True(t, ok)
+	require.Equal(t, Fingerprint(a), fingerprint)
+}
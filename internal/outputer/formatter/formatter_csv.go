@@ -66,7 +66,7 @@ func (f *CsvFormatter) csvFailedPolicies(output *scheme.Flattened, csvwriter *cs
 
 
 func (f *CsvFormatter) formatSummary(output *scheme.Flattened, csvwriter *csv.Writer) bool {
-	headers := []string{"#", "Namespace", "Policy", "Severity", "Passed", "Failed", "Skipped"}
+	headers := []string{"#", "Namespace", "Policy", "Severity", "Passed", "Failed", "Skipped", "Not Applicable"}
 	err := csvwriter.Write(headers)
 
 	for i, policyName := range output.AsOrderedMap().Keys() {
@@ -77,7 +77,7 @@ func (f *CsvFormatter) formatSummary(output *scheme.Flattened, csvwriter *csv.Wr
 		severity := policyInfo.Severity
 		namespace := policyInfo.Namespace
 
-		var passed, failed, skipped int
+		var passed, failed, skipped, notApplicable int
 		for _, violation := range data.Violations {
 			switch violation.Status {
 			case analyzers.PolicyPassed:
@@ -86,11 +86,13 @@ func (f *CsvFormatter) formatSummary(output *scheme.Flattened, csvwriter *csv.Wr
 				failed++
 			case analyzers.PolicySkipped:
 				skipped++
+			case analyzers.PolicyNotApplicable:
+				notApplicable++
 			}
 		}
 
 
-		row := []string{strconv.Itoa(rowNum), namespace, title, severity, strconv.Itoa(passed), strconv.Itoa(failed), strconv.Itoa(skipped)}
+		row := []string{strconv.Itoa(rowNum), namespace, title, severity, strconv.Itoa(passed), strconv.Itoa(failed), strconv.Itoa(skipped), strconv.Itoa(notApplicable)}
 		err := csvwriter.Write(row)
 		if err != nil {
 			panic(err)
@@ -104,6 +106,40 @@ func (f *CsvFormatter) formatSummary(output *scheme.Flattened, csvwriter *csv.Wr
 	return true
 }
 
+func (f *CsvFormatter) formatLeaderboard(output *scheme.Flattened, csvwriter *csv.Writer) bool {
+	scores := postureScores(output)
+	if len(scores) == 0 {
+		return false
+	}
+
+	headers := []string{"#", "Type", "Name", "Posture Score", "Passed", "Failed", "Skipped", "Not Applicable"}
+	if err := csvwriter.Write(headers); err != nil {
+		panic(err)
+	}
+
+	rank := 0
+	for i, score := range scores {
+		if i == 0 || scores[i-1].Type != score.Type {
+			rank = 0
+		}
+		rank++
+
+		var scoreStr string
+		if score.Score != nil {
+			scoreStr = strconv.Itoa(*score.Score)
+		}
+		row := []string{strconv.Itoa(rank), score.Type, score.Name, scoreStr, strconv.Itoa(score.Passed), strconv.Itoa(score.Failed), strconv.Itoa(score.Skipped), strconv.Itoa(score.NotApplicable)}
+		if err := csvwriter.Write(row); err != nil {
+			panic(err)
+		}
+	}
+	if err := csvwriter.Write([]string{"\n"}); err != nil {
+		panic(err)
+	}
+
+	return true
+}
+
 func (f *CsvFormatter) Format(output scheme.Scheme, failedOnly bool) ([]byte, error) {
 	var csvBuffer bytes.Buffer
 	csvWriter := csv.NewWriter(&csvBuffer)
@@ -115,6 +151,7 @@ func (f *CsvFormatter) Format(output scheme.Scheme, failedOnly bool) ([]byte, er
 
 	if !failedOnly {
 		f.formatSummary(typedOutput, csvWriter)
+		f.formatLeaderboard(typedOutput, csvWriter)
 	}
 	f.csvFailedPolicies(typedOutput, csvWriter)
 	csvWriter.Flush()
@@ -28,6 +28,12 @@ func (f *HumanFormatter) formatSummaryTable(output *scheme.Flattened) []byte {
 	return tw.FormatSummary(output)
 }
 
+func (f *HumanFormatter) formatLeaderboard(output *scheme.Flattened) []byte {
+	tf := newHumanTableWriter()
+	tw := newTableContent(tf, f.colorizer)
+	return tw.FormatLeaderboard(output)
+}
+
 func (f *HumanFormatter) formatFailedPolicies(output *scheme.Flattened) []byte {
 	failedPolicies := output.OnlyFailedViolations()
 	pf := newHumanPolicyFormatter()
@@ -36,7 +42,7 @@ func (f *HumanFormatter) formatFailedPolicies(output *scheme.Flattened) []byte {
 }
 
 func (f *HumanFormatter) Format(output scheme.Scheme, failedOnly bool) ([]byte, error) {
-	var summary, failedPolicies []byte
+	var summary, leaderboard, failedPolicies []byte
 
 	typedOutput, ok := output.(*scheme.Flattened)
 	if !ok {
@@ -45,11 +51,12 @@ func (f *HumanFormatter) Format(output scheme.Scheme, failedOnly bool) ([]byte,
 
 	if !failedOnly {
 		summary = f.formatSummaryTable(typedOutput)
+		leaderboard = f.formatLeaderboard(typedOutput)
 	}
 
 	failedPolicies = f.formatFailedPolicies(typedOutput)
 
-	return append(failedPolicies, summary...), nil
+	return append(append(failedPolicies, summary...), leaderboard...), nil
 }
 
 func (f *HumanFormatter) IsSchemeSupported(schemeType string) bool {
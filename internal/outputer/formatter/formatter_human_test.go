@@ -1,9 +1,16 @@
 package formatter_test
 
 import (
+	"strings"
 	"testing"
 
+	"github.com/Legit-Labs/legitify/internal/analyzers"
+	"github.com/Legit-Labs/legitify/internal/common/map_utils"
+	"github.com/Legit-Labs/legitify/internal/common/namespace"
+	"github.com/Legit-Labs/legitify/internal/common/severity"
+	"github.com/Legit-Labs/legitify/internal/enricher/enrichers"
 	"github.com/Legit-Labs/legitify/internal/outputer/formatter"
+	"github.com/Legit-Labs/legitify/internal/outputer/scheme"
 	"github.com/Legit-Labs/legitify/internal/outputer/scheme/scheme_test"
 	"github.com/stretchr/testify/require"
 )
@@ -18,3 +25,69 @@ func TestFormatHuman(t *testing.T) {
 		require.NotEmpty(t, bytes, "Error formatting markdown")
 	}
 }
+
+func scoredSample() *scheme.Flattened {
+	score := func(v int) *int { return &v }
+	org := &enrichers.Score{Type: namespace.Organization, Name: "org", Score: score(60), Passed: 3, Failed: 2}
+	repos := []enrichers.Score{
+		{Type: namespace.Repository, Name: "org/worse", Link: "https://github.com/org/worse", Score: score(40), Passed: 1, Failed: 2},
+		{Type: namespace.Repository, Name: "org/better", Link: "https://github.com/org/better", Score: score(90), Passed: 2},
+	}
+
+	sample := scheme.NewFlattenedScheme()
+	var violations []scheme.Violation
+	for _, repo := range repos {
+		aux := map[string]enrichers.Enrichment{
+			enrichers.PostureScore: enrichers.PostureScoreEnrichment{Entity: repo, Organization: org},
+		}
+		violations = append(violations, scheme.Violation{
+			ViolationEntityType: namespace.Repository,
+			CanonicalLink:       repo.Link,
+			Aux:                 map_utils.ToKeySortedMap(aux),
+			Status:              analyzers.PolicyFailed,
+		})
+	}
+	sample.AsOrderedMap().Set("data.repository.policy", scheme.OutputData{
+		PolicyInfo: scheme.PolicyInfo{
+			PolicyName:               "policy",
+			FullyQualifiedPolicyName: "data.repository.policy",
+			Title:                    "policy",
+			Description:              "policy",
+			Threat:                   []string{"threat"},
+			RemediationSteps:         []string{"remediation"},
+			Namespace:                namespace.Repository,
+			Severity:                 severity.High,
+		},
+		Violations: violations,
+	})
+
+	return sample
+}
+
+func TestFormatHuman_Leaderboard(t *testing.T) {
+	output, err := formatter.Format(formatter.Human, formatter.DefaultOutputIndent, scoredSample(), false)
+	require.Nil(t, err)
+
+	text := string(output)
+	require.Contains(t, text, "Posture Score Leaderboard")
+	require.Contains(t, text, "60/100")
+	better := strings.Index(text, "| org/better")
+	worse := strings.Index(text, "| org/worse")
+	require.True(t, better > 0 && worse > better, "repositories should be ranked by their score")
+
+	output, err = formatter.Format(formatter.Human, formatter.DefaultOutputIndent, scoredSample(), true)
+	require.Nil(t, err)
+	require.NotContains(t, string(output), "Posture Score Leaderboard")
+}
+
+func TestFormatJson_PostureScore(t *testing.T) {
+	asJson, err := formatter.Format(formatter.Json, formatter.DefaultOutputIndent, scoredSample(), false)
+	require.Nil(t, err)
+	parsed, err := scheme.Unmarshal(asJson)
+	require.Nil(t, err)
+
+	violation := parsed.GetPolicyData("data.repository.policy").Violations[0]
+	enrichment, ok := violation.Aux.Get(enrichers.PostureScore)
+	require.True(t, ok)
+	require.Equal(t, 40, *enrichment.(enrichers.PostureScoreEnrichment).Entity.Score)
+}
@@ -22,7 +22,7 @@ func newMarkdownFormatter() OutputFormatter {
 }
 
 func (m *markdownFormatter) Format(output scheme.Scheme, failedOnly bool) ([]byte, error) {
-	var summary, failedPolicies []byte
+	var summary, leaderboard, failedPolicies []byte
 	var typedOutput *scheme.Flattened
 
 	typedOutput, ok := output.(*scheme.Flattened)
@@ -32,11 +32,12 @@ func (m *markdownFormatter) Format(output scheme.Scheme, failedOnly bool) ([]byt
 
 	if !failedOnly {
 		summary = m.formatSummaryTable(typedOutput)
+		leaderboard = m.formatLeaderboard(typedOutput)
 	}
 
 	failedPolicies = m.formatFailedPolicies(typedOutput)
 
-	return append(summary, failedPolicies...), nil
+	return append(append(summary, leaderboard...), failedPolicies...), nil
 }
 
 func (m *markdownFormatter) IsSchemeSupported(schemeType string) bool {
@@ -49,6 +50,12 @@ func (m *markdownFormatter) formatSummaryTable(output *scheme.Flattened) []byte
 	return tw.FormatSummary(output)
 }
 
+func (m *markdownFormatter) formatLeaderboard(output *scheme.Flattened) []byte {
+	tf := newMarkdownTableFormatter()
+	tw := newTableContent(tf, m.colorizer)
+	return tw.FormatLeaderboard(output)
+}
+
 func (m *markdownFormatter) formatFailedPolicies(output *scheme.Flattened) []byte {
 	failedPolicies := output.OnlyFailedViolations()
 	pf := newMarkdownPolicyFormatter()
@@ -79,19 +79,24 @@ func (f *sarifFormatter) Format(s scheme.Scheme, failedOnly bool) ([]byte, error
 
 			base, uri := f.URIFromLink(violation.CanonicalLink)
 			run.AddDistinctArtifact(violation.ViolationEntityType)
-			run.CreateResultForRule(policyInfo.FullyQualifiedPolicyName).
+			result := run.CreateResultForRule(policyInfo.FullyQualifiedPolicyName).
 				WithLevel(sarifSeverity(policyInfo.Severity)).
 				WithMessage(sarif.NewTextMessage(getViolationMessage(&violation, &policyInfo))).
-				WithHostedViewerUri(violation.CanonicalLink).
-				AddLocation(
-					sarif.NewLocationWithPhysicalLocation(
-						sarif.NewPhysicalLocation().
-							WithArtifactLocation(
-								sarif.NewArtifactLocation().
-									WithUri(uri).WithUriBaseId(base),
-							),
-					),
-				)
+				WithHostedViewerUri(violation.CanonicalLink)
+			if score, ok := violationPostureScore(&violation); ok && score.Entity.Score != nil {
+				pb := sarif.NewPropertyBag()
+				pb.AddInteger("posture-score", *score.Entity.Score)
+				result.AttachPropertyBag(pb)
+			}
+			result.AddLocation(
+				sarif.NewLocationWithPhysicalLocation(
+					sarif.NewPhysicalLocation().
+						WithArtifactLocation(
+							sarif.NewArtifactLocation().
+								WithUri(uri).WithUriBaseId(base),
+						),
+				),
+			)
 		}
 	}
 
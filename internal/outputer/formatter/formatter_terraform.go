@@ -0,0 +1,230 @@
+package formatter
+
+import (
+	"bytes"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/Legit-Labs/legitify/internal/common/map_utils"
+	"github.com/Legit-Labs/legitify/internal/enricher/enrichers"
+	"github.com/Legit-Labs/legitify/internal/outputer/scheme"
+)
+
+const terraformHeader = `# Generated by legitify: terraform remediation of the failed policies.
+# Settings that already exist have an import block (requires terraform >= 1.5).
+# Repositories are referenced by name; configure the github provider owner accordingly.
+# Review the plan before applying.
+`
+
+type terraformFormatter struct {
+}
+
+func newTerraformFormatter() OutputFormatter {
+	return &terraformFormatter{}
+}
+
+// terraformBlock is a resource that is merged from the resources of all the policies failed by an entity
+type terraformBlock struct {
+	resource enrichers.TerraformResource
+	policies []string
+}
+
+func (f *terraformFormatter) Format(output scheme.Scheme, _ bool) ([]byte, error) {
+	typedOutput, ok := output.(*scheme.Flattened)
+	if !ok {
+		return nil, UnsupportedScheme{output}
+	}
+
+	var blocks []*terraformBlock
+	byAddress := make(map[string]*terraformBlock)
+	var unsupported []string
+
+	failedPolicies := typedOutput.OnlyFailedViolations()
+	for _, policyName := range failedPolicies.AsOrderedMap().Keys() {
+		policyData := failedPolicies.GetPolicyData(policyName)
+		for _, violation := range policyData.Violations {
+			enrichment, ok := terraformEnrichment(violation)
+			if !ok {
+				unsupported = append(unsupported, fmt.Sprintf("%s (%s)", policyData.PolicyInfo.Title, violation.CanonicalLink))
+				continue
+			}
+
+			for _, resource := range enrichment {
+				block, ok := byAddress[resource.Address()]
+				if !ok {
+					block = &terraformBlock{
+						resource: enrichers.TerraformResource{
+							Type:       resource.Type,
+							Name:       resource.Name,
+							Attributes: map[string]interface{}{},
+						},
+					}
+					byAddress[resource.Address()] = block
+					blocks = append(blocks, block)
+				}
+				if resource.ImportId != "" {
+					block.resource.ImportId = resource.ImportId
+				}
+				mergeTerraformAttributes(block.resource.Attributes, resource.Attributes)
+				block.policies = append(block.policies, policyData.PolicyInfo.Title)
+			}
+		}
+	}
+
+	sort.SliceStable(blocks, func(i, j int) bool {
+		return blocks[i].resource.Address() < blocks[j].resource.Address()
+	})
+
+	var buf bytes.Buffer
+	buf.WriteString(terraformHeader)
+	for _, block := range blocks {
+		buf.WriteString("\n")
+		writeTerraformBlock(&buf, block)
+	}
+
+	if len(unsupported) > 0 {
+		buf.WriteString("\n# Failed policies without a terraform remediation:\n")
+		for _, u := range unsupported {
+			buf.WriteString(fmt.Sprintf("#   - %s\n", u))
+		}
+	}
+
+	return buf.Bytes(), nil
+}
+
+func (f *terraformFormatter) IsSchemeSupported(schemeType string) bool {
+	return schemeType == scheme.TypeFlattened
+}
+
+func terraformEnrichment(violation scheme.Violation) (enrichers.TerraformEnrichment, bool) {
+	if violation.Aux == nil {
+		return nil, false
+	}
+	if _, ok := violation.Aux.Get(enrichers.Terraform); !ok {
+		return nil, false
+	}
+
+	enrichment, ok := map_utils.UnsafeGetUntyped(violation.Aux, enrichers.Terraform).(enrichers.TerraformEnrichment)
+	return enrichment, ok && len(enrichment) > 0
+}
+
+// mergeTerraformAttributes merges src into dst (without modifying src):
+// nested blocks are merged recursively and numbers keep the highest value (e.g. the number of required reviewers).
+func mergeTerraformAttributes(dst map[string]interface{}, src map[string]interface{}) {
+	for k, v := range src {
+		switch typed := v.(type) {
+		case map[string]interface{}:
+			nested, ok := dst[k].(map[string]interface{})
+			if !ok {
+				nested = map[string]interface{}{}
+				dst[k] = nested
+			}
+			mergeTerraformAttributes(nested, typed)
+		default:
+			if current, ok := terraformNumber(dst[k]); ok {
+				if candidate, ok := terraformNumber(v); ok && candidate < current {
+					continue
+				}
+			}
+			dst[k] = v
+		}
+	}
+}
+
+func terraformNumber(v interface{}) (float64, bool) {
+	switch n := v.(type) {
+	case int:
+		return float64(n), true
+	case float64:
+		return n, true
+	}
+
+	return 0, false
+}
+
+func writeTerraformBlock(buf *bytes.Buffer, block *terraformBlock) {
+	resource := block.resource
+	for _, policy := range uniqueSorted(block.policies) {
+		buf.WriteString(fmt.Sprintf("# %s\n", policy))
+	}
+
+	if resource.ImportId != "" {
+		buf.WriteString("import {\n")
+		buf.WriteString(fmt.Sprintf("%sto = %s\n", amplifyIndent(1), resource.Address()))
+		buf.WriteString(fmt.Sprintf("%sid = %s\n", amplifyIndent(1), terraformValue(resource.ImportId)))
+		buf.WriteString("}\n\n")
+	}
+
+	buf.WriteString(fmt.Sprintf("resource %q %q {\n", resource.Type, resource.Name))
+	writeTerraformAttributes(buf, resource.Attributes, 1)
+	buf.WriteString("}\n")
+}
+
+func writeTerraformAttributes(buf *bytes.Buffer, attributes map[string]interface{}, depth int) {
+	indent := amplifyIndent(depth)
+	keys := make([]string, 0, len(attributes))
+	for k := range attributes {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	// attributes precede nested blocks, as in terraform fmt
+	var nestedBlocks []string
+	for _, k := range keys {
+		if _, ok := attributes[k].(map[string]interface{}); ok {
+			nestedBlocks = append(nestedBlocks, k)
+			continue
+		}
+		buf.WriteString(fmt.Sprintf("%s%s = %s\n", indent, k, terraformValue(attributes[k])))
+	}
+
+	for _, k := range nestedBlocks {
+		nested := attributes[k].(map[string]interface{})
+		if len(nested) == 0 {
+			buf.WriteString(fmt.Sprintf("%s%s {}\n", indent, k))
+			continue
+		}
+		buf.WriteString(fmt.Sprintf("%s%s {\n", indent, k))
+		writeTerraformAttributes(buf, nested, depth+1)
+		buf.WriteString(fmt.Sprintf("%s}\n", indent))
+	}
+}
+
+func terraformValue(v interface{}) string {
+	switch typed := v.(type) {
+	case string:
+		// escape terraform interpolation sequences
+		escaped := strings.NewReplacer("${", "$${", "%{", "%%{").Replace(typed)
+		return strconv.Quote(escaped)
+	case bool:
+		return strconv.FormatBool(typed)
+	case int:
+		return strconv.Itoa(typed)
+	case float64:
+		return strconv.FormatFloat(typed, 'f', -1, 64)
+	case []interface{}:
+		values := make([]string, 0, len(typed))
+		for _, e := range typed {
+			values = append(values, terraformValue(e))
+		}
+		return "[" + strings.Join(values, ", ") + "]"
+	default:
+		return strconv.Quote(fmt.Sprintf("%v", typed))
+	}
+}
+
+func uniqueSorted(list []string) []string {
+	seen := make(map[string]bool)
+	var result []string
+	for _, e := range list {
+		if !seen[e] {
+			seen[e] = true
+			result = append(result, e)
+		}
+	}
+	sort.Strings(result)
+
+	return result
+}
@@ -0,0 +1,154 @@
+package formatter_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/Legit-Labs/legitify/internal/analyzers"
+	"github.com/Legit-Labs/legitify/internal/collected"
+	githubcollected "github.com/Legit-Labs/legitify/internal/collected/github"
+	"github.com/Legit-Labs/legitify/internal/common/map_utils"
+	"github.com/Legit-Labs/legitify/internal/common/namespace"
+	"github.com/Legit-Labs/legitify/internal/enricher/enrichers"
+	"github.com/Legit-Labs/legitify/internal/outputer/formatter"
+	"github.com/Legit-Labs/legitify/internal/outputer/scheme"
+	"github.com/stretchr/testify/require"
+)
+
+func terraformRepository(protected bool) collected.Entity {
+	branch := "main"
+	var rule *githubcollected.GitHubQLBranchProtectionRule
+	if protected {
+		rule = &githubcollected.GitHubQLBranchProtectionRule{}
+	}
+
+	return githubcollected.Repository{
+		Repository: &githubcollected.GitHubQLRepository{
+			Name: "repo",
+			Url:  "https://github.com/org/repo",
+			DefaultBranchRef: &githubcollected.GitHubQLBranch{
+				Name:                 &branch,
+				BranchProtectionRule: rule,
+			},
+		},
+	}
+}
+
+func terraformViolation(entity collected.Entity, policyName string) scheme.Violation {
+	enrichment, ok := enrichers.NewTerraformEnricher().Enrich(context.Background(), analyzers.AnalyzedData{
+		Entity:     entity,
+		Namespace:  namespace.Repository,
+		PolicyName: policyName,
+		Status:     analyzers.PolicyFailed,
+	})
+
+	aux := map[string]enrichers.Enrichment{}
+	if ok {
+		aux[enrichers.Terraform] = enrichment
+	}
+
+	return scheme.Violation{
+		ViolationEntityType: entity.ViolationEntityType(),
+		CanonicalLink:       entity.CanonicalLink(),
+		Aux:                 map_utils.ToKeySortedMap(aux),
+		Status:              analyzers.PolicyFailed,
+	}
+}
+
+func terraformSample(protected bool, policies ...string) *scheme.Flattened {
+	sample := scheme.NewFlattenedScheme()
+	for _, p := range policies {
+		sample.AsOrderedMap().Set("data.repository."+p, scheme.OutputData{
+			PolicyInfo: scheme.PolicyInfo{
+				PolicyName:               p,
+				FullyQualifiedPolicyName: "data.repository." + p,
+				Title:                    p,
+				Description:              p,
+				Threat:                   []string{"threat"},
+				RemediationSteps:         []string{"remediation"},
+				Namespace:                namespace.Repository,
+			},
+			Violations: []scheme.Violation{terraformViolation(terraformRepository(protected), p)},
+		})
+	}
+
+	return sample
+}
+
+func TestFormatTerraform(t *testing.T) {
+	sample := terraformSample(false,
+		"code_review_not_required",
+		"code_review_by_two_members_not_required",
+		"dismisses_stale_reviews",
+		"missing_default_branch_protection_force_push",
+		"token_default_permissions_is_read_write",
+		"repository_not_maintained",
+	)
+
+	output, err := formatter.Format(formatter.Terraform, formatter.DefaultOutputIndent, sample, true)
+	require.Nil(t, err)
+
+	expected := `
+# code_review_by_two_members_not_required
+# code_review_not_required
+# dismisses_stale_reviews
+# missing_default_branch_protection_force_push
+resource "github_branch_protection" "org_repo_main" {
+  allows_force_pushes = false
+  pattern = "main"
+  repository_id = "repo"
+  required_pull_request_reviews {
+    dismiss_stale_reviews = true
+    required_approving_review_count = 2
+  }
+}
+
+# token_default_permissions_is_read_write
+import {
+  to = github_workflow_repository_permissions.org_repo
+  id = "repo"
+}
+
+resource "github_workflow_repository_permissions" "org_repo" {
+  default_workflow_permissions = "read"
+  repository = "repo"
+}
+
+# Failed policies without a terraform remediation:
+#   - repository_not_maintained (https://github.com/org/repo)
+`
+	require.Contains(t, string(output), expected)
+}
+
+func TestFormatTerraform_MissingProtection(t *testing.T) {
+	sample := terraformSample(false, "missing_default_branch_protection", "no_signed_commits")
+
+	output, err := formatter.Format(formatter.Terraform, formatter.DefaultOutputIndent, sample, true)
+	require.Nil(t, err)
+	require.NotContains(t, string(output), "import {", "a missing protection rule should be created, not imported")
+	require.Contains(t, string(output), "require_signed_commits = true")
+}
+
+func TestFormatTerraform_ExistingProtection(t *testing.T) {
+	sample := terraformSample(true, "no_signed_commits")
+
+	output, err := formatter.Format(formatter.Terraform, formatter.DefaultOutputIndent, sample, true)
+	require.Nil(t, err)
+	require.NotContains(t, string(output), "github_branch_protection", "an existing protection rule should not be overwritten")
+	require.Contains(t, string(output), "#   - no_signed_commits (https://github.com/org/repo)")
+}
+
+func TestFormatTerraform_FromJson(t *testing.T) {
+	sample := terraformSample(false, "no_signed_commits")
+
+	asJson, err := formatter.Format(formatter.Json, formatter.DefaultOutputIndent, sample, true)
+	require.Nil(t, err)
+	parsed, err := scheme.Unmarshal(asJson)
+	require.Nil(t, err)
+
+	expected, err := formatter.Format(formatter.Terraform, formatter.DefaultOutputIndent, sample, true)
+	require.Nil(t, err)
+	converted, err := formatter.Format(formatter.Terraform, formatter.DefaultOutputIndent, parsed, true)
+	require.Nil(t, err)
+	require.Equal(t, string(expected), string(converted))
+}
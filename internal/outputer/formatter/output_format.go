@@ -9,11 +9,12 @@ import (
 type FormatName = string
 
 const (
-	Human    FormatName = "human"
-	Json     FormatName = "json"
-	Sarif    FormatName = "sarif"
-	Markdown FormatName = "markdown"
-	Csv		 FormatName = "csv"
+	Human     FormatName = "human"
+	Json      FormatName = "json"
+	Sarif     FormatName = "sarif"
+	Markdown  FormatName = "markdown"
+	Csv       FormatName = "csv"
+	Terraform FormatName = "terraform"
 )
 
 type OutputFormatter interface {
@@ -24,11 +25,12 @@ type OutputFormatter interface {
 type NewFormatFunc func() OutputFormatter
 
 var outputFormatters = map[FormatName]NewFormatFunc{
-	Human:    newHumanFormatter,
-	Json:     NewJsonFormatter,
-	Markdown: newMarkdownFormatter,
-	Sarif:    newSarifFormatter,
-	Csv:	  newCSVFormatter,
+	Human:     newHumanFormatter,
+	Json:      NewJsonFormatter,
+	Markdown:  newMarkdownFormatter,
+	Sarif:     newSarifFormatter,
+	Csv:       newCSVFormatter,
+	Terraform: newTerraformFormatter,
 }
 
 func ValidateOutputFormat(outputFormat FormatName, schemeType scheme.SchemeType) error {
@@ -37,6 +37,9 @@ func TestOutputFormats(t *testing.T) {
 		case formatter.Csv:
 			// csv has dedicated tests
 			continue
+		case formatter.Terraform:
+			// terraform has dedicated tests
+			continue
 
 		default:
 			t.Fatalf("unexpected format: %s", name)
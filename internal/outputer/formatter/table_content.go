@@ -1,7 +1,13 @@
 package formatter
 
 import (
+	"sort"
+	"strconv"
+
 	"github.com/Legit-Labs/legitify/internal/analyzers"
+	"github.com/Legit-Labs/legitify/internal/common/namespace"
+	"github.com/Legit-Labs/legitify/internal/common/slice_utils"
+	"github.com/Legit-Labs/legitify/internal/enricher/enrichers"
 	"github.com/Legit-Labs/legitify/internal/outputer/scheme"
 )
 
@@ -37,7 +43,7 @@ func (tc *tableContent) FormatSummary(output *scheme.Flattened) []byte {
 
 	tc.tf.SetTitle(tc.colorizer.colorize(themeColorBold, "Legitify Findings Summary"))
 
-	headers := []string{"#", "Namespace", "Policy", "Severity", "Passed", "Failed", "Skipped"}
+	headers := []string{"#", "Namespace", "Policy", "Severity", "Passed", "Failed", "Skipped", "Not Applicable"}
 	for i, h := range headers {
 		headers[i] = tc.colorizer.colorize(themeColorBold, h)
 	}
@@ -51,7 +57,7 @@ func (tc *tableContent) FormatSummary(output *scheme.Flattened) []byte {
 		severity := tc.colorizer.colorize(severityToThemeColor(policyInfo.Severity), policyInfo.Severity)
 		namespace := policyInfo.Namespace
 
-		var passed, failed, skipped int
+		var passed, failed, skipped, notApplicable int
 		for _, violation := range data.Violations {
 			switch violation.Status {
 			case analyzers.PolicyPassed:
@@ -60,14 +66,139 @@ func (tc *tableContent) FormatSummary(output *scheme.Flattened) []byte {
 				failed++
 			case analyzers.PolicySkipped:
 				skipped++
+			case analyzers.PolicyNotApplicable:
+				notApplicable++
 			}
 		}
 
 		passedStr := tc.countColorize(passed, themeColorSuccess)
 		failedStr := tc.countColorize(failed, themeColorFailure)
 		skippedStr := tc.countColorize(skipped, themeColorInteresting)
+		notApplicableStr := tc.countColorize(notApplicable, themeColorInteresting)
+
+		tc.tf.WriteRow([]string{rowNum, namespace, title, severity, passedStr, failedStr, skippedStr, notApplicableStr})
+	}
+
+	return tc.tf.Render()
+}
+
+// leaderboardTypes are the entity types ranked by the leaderboard, in the order they are displayed
+var leaderboardTypes = []string{
+	namespace.Enterprise,
+	namespace.Organization,
+	namespace.Repository,
+}
+
+func violationPostureScore(violation *scheme.Violation) (enrichers.PostureScoreEnrichment, bool) {
+	if violation.Aux == nil {
+		return enrichers.PostureScoreEnrichment{}, false
+	}
+
+	enrichment, ok := violation.Aux.Get(enrichers.PostureScore)
+	if !ok {
+		return enrichers.PostureScoreEnrichment{}, false
+	}
+	score, ok := enrichment.(enrichers.PostureScoreEnrichment)
+
+	return score, ok
+}
+
+// postureScores returns the distinct scores of the ranked entity types, ordered for the leaderboard
+func postureScores(output *scheme.Flattened) []enrichers.Score {
+	type scoreKey struct {
+		entityType string
+		name       string
+	}
+	seen := make(map[scoreKey]bool)
+	var scores []enrichers.Score
+	add := func(score *enrichers.Score) {
+		if score == nil || !slice_utils.Contains(leaderboardTypes, score.Type) {
+			return
+		}
+		key := scoreKey{entityType: score.Type, name: score.Name}
+		if seen[key] {
+			return
+		}
+		seen[key] = true
+		scores = append(scores, *score)
+	}
+
+	for _, policyName := range output.AsOrderedMap().Keys() {
+		for _, violation := range output.GetPolicyData(policyName).Violations {
+			score, ok := violationPostureScore(&violation)
+			if !ok {
+				continue
+			}
+			add(&score.Entity)
+			add(score.Organization)
+			add(score.Enterprise)
+		}
+	}
+
+	typeOrder := func(t string) int {
+		for i, lt := range leaderboardTypes {
+			if lt == t {
+				return i
+			}
+		}
+		return len(leaderboardTypes)
+	}
+	sort.Slice(scores, func(i, j int) bool {
+		a, b := scores[i], scores[j]
+		if a.Type != b.Type {
+			return typeOrder(a.Type) < typeOrder(b.Type)
+		}
+		if (a.Score == nil) != (b.Score == nil) {
+			return b.Score == nil
+		}
+		if a.Score != nil && *a.Score != *b.Score {
+			return *a.Score > *b.Score
+		}
+		return a.Name < b.Name
+	})
+
+	return scores
+}
+
+func scoreThemeColor(score *int) themeColor {
+	switch {
+	case score == nil:
+		return themeColorNone
+	case *score >= 80:
+		return themeColorSuccess
+	case *score >= 50:
+		return themeColorAlert
+	default:
+		return themeColorFailure
+	}
+}
+
+// FormatLeaderboard ranks the entities of each type by their posture score; returns nil if there are no scores.
+func (tc *tableContent) FormatLeaderboard(output *scheme.Flattened) []byte {
+	scores := postureScores(output)
+	if len(scores) == 0 {
+		return nil
+	}
+
+	tc.tf.SetTitle(tc.colorizer.colorize(themeColorBold, "Posture Score Leaderboard"))
+
+	headers := []string{"#", "Type", "Name", "Score", "Passed", "Failed", "Skipped", "Not Applicable"}
+	for i, h := range headers {
+		headers[i] = tc.colorizer.colorize(themeColorBold, h)
+	}
+	tc.tf.SetHeaders(headers)
+
+	rank := 0
+	for i, score := range scores {
+		if i == 0 || scores[i-1].Type != score.Type {
+			rank = 0
+		}
+		rank++
 
-		tc.tf.WriteRow([]string{rowNum, namespace, title, severity, passedStr, failedStr, skippedStr})
+		rowNum := tc.colorizer.colorize(themeColorBold, rank)
+		scoreStr := tc.colorizer.colorize(scoreThemeColor(score.Score), score.String())
+		tc.tf.WriteRow([]string{rowNum, score.Type, score.Name, scoreStr,
+			strconv.Itoa(score.Passed), strconv.Itoa(score.Failed), strconv.Itoa(score.Skipped), strconv.Itoa(score.NotApplicable)})
 	}
 
 	return tc.tf.Render()
@@ -28,7 +28,12 @@ func policiesSortByNamespaceLess(i, j *orderedmap.Pair) bool {
 		namespace.Actions:      1,
 		namespace.Member:       2,
 		namespace.Repository:   3,
-		namespace.RunnerGroup:  4,
+		namespace.Branch:       4,
+		namespace.RunnerGroup:  5,
+		namespace.Runner:       6,
+		namespace.Apps:         7,
+		namespace.Team:         8,
+		namespace.Audit:        9,
 	}
 
 	iNamespace := i.Value().(OutputData).PolicyInfo.Namespace
@@ -0,0 +1,154 @@
+package remediation
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+
+	ghclient "github.com/Legit-Labs/legitify/internal/clients/github"
+	"github.com/Legit-Labs/legitify/internal/collected"
+	githubcollected "github.com/Legit-Labs/legitify/internal/collected/github"
+	"github.com/Legit-Labs/legitify/internal/common/namespace"
+)
+
+var GitHubRemediations = []Remediation{
+	{
+		Namespace:   namespace.Actions,
+		PolicyName:  "token_default_permissions_is_read_write",
+		Description: "set the organization default workflow token permission to read",
+		Calls: orgActionsWorkflowPermissions(map[string]interface{}{
+			"default_workflow_permissions": "read",
+		}),
+	},
+	{
+		Namespace:   namespace.Actions,
+		PolicyName:  "actions_can_approve_pull_requests",
+		Description: "disallow organization workflows from creating and approving pull requests",
+		Calls: orgActionsWorkflowPermissions(map[string]interface{}{
+			"can_approve_pull_request_reviews": false,
+		}),
+	},
+	{
+		Namespace:   namespace.Repository,
+		PolicyName:  "token_default_permissions_is_read_write",
+		Description: "set the repository default workflow token permission to read",
+		Calls: repositoryCalls(func(repo string, _ githubcollected.Repository) ([]Call, error) {
+			return []Call{{
+				Method: http.MethodPut,
+				Path:   fmt.Sprintf("repos/%s/actions/permissions/workflow", repo),
+				Body:   map[string]interface{}{"default_workflow_permissions": "read"},
+			}}, nil
+		}),
+	},
+	{
+		Namespace:   namespace.Repository,
+		PolicyName:  "actions_can_approve_pull_requests",
+		Description: "disallow repository workflows from creating and approving pull requests",
+		Calls: repositoryCalls(func(repo string, _ githubcollected.Repository) ([]Call, error) {
+			return []Call{{
+				Method: http.MethodPut,
+				Path:   fmt.Sprintf("repos/%s/actions/permissions/workflow", repo),
+				Body:   map[string]interface{}{"can_approve_pull_request_reviews": false},
+			}}, nil
+		}),
+	},
+	{
+		Namespace:   namespace.Repository,
+		PolicyName:  "vulnerability_alerts_not_enabled",
+		Description: "enable Dependabot vulnerability alerts",
+		Calls: repositoryCalls(func(repo string, _ githubcollected.Repository) ([]Call, error) {
+			return []Call{{
+				Method: http.MethodPut,
+				Path:   fmt.Sprintf("repos/%s/vulnerability-alerts", repo),
+			}}, nil
+		}),
+	},
+	{
+		Namespace:   namespace.Repository,
+		PolicyName:  "secret_scanning_not_enabled",
+		Description: "enable secret scanning",
+		Calls: repositoryCalls(func(repo string, _ githubcollected.Repository) ([]Call, error) {
+			return []Call{{
+				Method: http.MethodPatch,
+				Path:   fmt.Sprintf("repos/%s", repo),
+				Body: map[string]interface{}{
+					"security_and_analysis": map[string]interface{}{
+						"secret_scanning": map[string]string{"status": "enabled"},
+					},
+				},
+			}}, nil
+		}),
+	},
+	{
+		Namespace:   namespace.Repository,
+		PolicyName:  "no_signed_commits",
+		Description: "require signed commits on the default branch protection rule",
+		Calls: repositoryCalls(func(repo string, r githubcollected.Repository) ([]Call, error) {
+			branch := r.Repository.DefaultBranchRef
+			if branch == nil || branch.Name == nil {
+				return nil, fmt.Errorf("repository has no default branch")
+			}
+			// the required signatures endpoint only amends an existing protection rule
+			if branch.BranchProtectionRule == nil {
+				return nil, fmt.Errorf("default branch %s has no branch protection rule", *branch.Name)
+			}
+			return []Call{{
+				Method: http.MethodPost,
+				Path:   fmt.Sprintf("repos/%s/branches/%s/protection/required_signatures", repo, url.PathEscape(*branch.Name)),
+			}}, nil
+		}),
+	},
+}
+
+func orgActionsWorkflowPermissions(body map[string]interface{}) func(entity collected.Entity) ([]Call, error) {
+	return func(entity collected.Entity) ([]Call, error) {
+		actions, ok := entity.(githubcollected.OrganizationActions)
+		if !ok {
+			return nil, fmt.Errorf("unexpected entity type %T", entity)
+		}
+
+		return []Call{{
+			Method: http.MethodPut,
+			Path:   fmt.Sprintf("orgs/%s/actions/permissions/workflow", actions.Name()),
+			Body:   body,
+		}}, nil
+	}
+}
+
+func repositoryCalls(fn func(repo string, r githubcollected.Repository) ([]Call, error)) func(entity collected.Entity) ([]Call, error) {
+	return func(entity collected.Entity) ([]Call, error) {
+		r, ok := entity.(githubcollected.Repository)
+		if !ok || r.Repository == nil {
+			return nil, fmt.Errorf("unexpected entity type %T", entity)
+		}
+
+		if r.Repository.Owner() == "" {
+			return nil, fmt.Errorf("unknown owner of repository %s", r.Repository.Url)
+		}
+
+		return fn(r.Repository.FullName(), r)
+	}
+}
+
+type githubExecutor struct {
+	context context.Context
+	client  *ghclient.Client
+}
+
+func NewGitHubExecutor(ctx context.Context, client *ghclient.Client) Executor {
+	return &githubExecutor{
+		context: ctx,
+		client:  client,
+	}
+}
+
+func (e *githubExecutor) Execute(call Call) error {
+	req, err := e.client.Client().NewRequest(call.Method, call.Path, call.Body)
+	if err != nil {
+		return err
+	}
+
+	_, err = e.client.Client().Do(e.context, req, nil)
+	return err
+}
@@ -0,0 +1,57 @@
+package remediation
+
+import (
+	"fmt"
+	"net/http"
+
+	glclient "github.com/Legit-Labs/legitify/internal/clients/gitlab"
+	"github.com/Legit-Labs/legitify/internal/collected"
+	"github.com/Legit-Labs/legitify/internal/collected/gitlab_collected"
+	"github.com/Legit-Labs/legitify/internal/common/namespace"
+)
+
+var GitLabRemediations = []Remediation{
+	{
+		Namespace:   namespace.Repository,
+		PolicyName:  "no_signed_commits",
+		Description: "reject unsigned commits using the project push rules",
+		Calls: func(entity collected.Entity) ([]Call, error) {
+			r, ok := entity.(gitlab_collected.Repository)
+			if !ok || r.Project == nil {
+				return nil, fmt.Errorf("unexpected entity type %T", entity)
+			}
+
+			// push rules must be created before they can be edited
+			method := http.MethodPut
+			if r.PushRules == nil {
+				method = http.MethodPost
+			}
+
+			return []Call{{
+				Method: method,
+				Path:   fmt.Sprintf("projects/%d/push_rule", r.Project.ID),
+				Body:   map[string]interface{}{"reject_unsigned_commits": true},
+			}}, nil
+		},
+	},
+}
+
+type gitlabExecutor struct {
+	client *glclient.Client
+}
+
+func NewGitLabExecutor(client *glclient.Client) Executor {
+	return &gitlabExecutor{
+		client: client,
+	}
+}
+
+func (e *gitlabExecutor) Execute(call Call) error {
+	req, err := e.client.Client().NewRequest(call.Method, call.Path, call.Body, nil)
+	if err != nil {
+		return err
+	}
+
+	_, err = e.client.Client().Do(req, nil)
+	return err
+}
@@ -0,0 +1,186 @@
+package remediation
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/Legit-Labs/legitify/internal/analyzers"
+	"github.com/Legit-Labs/legitify/internal/collected"
+	"github.com/Legit-Labs/legitify/internal/common/namespace"
+	"github.com/Legit-Labs/legitify/internal/common/scm_type"
+	"github.com/Legit-Labs/legitify/internal/common/slice_utils"
+)
+
+// Call is a single API call that is required to remediate a policy.
+type Call struct {
+	Method string
+	Path   string
+	Body   interface{}
+}
+
+func (c Call) String() string {
+	if c.Body == nil {
+		return fmt.Sprintf("%s %s", c.Method, c.Path)
+	}
+
+	body, err := json.Marshal(c.Body)
+	if err != nil {
+		return fmt.Sprintf("%s %s <invalid body: %v>", c.Method, c.Path, err)
+	}
+
+	return fmt.Sprintf("%s %s %s", c.Method, c.Path, body)
+}
+
+// Remediation describes how to fix a failed policy of a single namespace using the SCM API.
+// Only settings that are safe to change automatically should have a remediation.
+type Remediation struct {
+	Namespace   namespace.Namespace
+	PolicyName  string
+	Description string
+	Calls       func(entity collected.Entity) ([]Call, error)
+}
+
+func (r Remediation) matches(data analyzers.AnalyzedData) bool {
+	return r.Namespace == data.Namespace && r.PolicyName == data.PolicyName
+}
+
+// Executor performs the API calls against the SCM.
+type Executor interface {
+	Execute(call Call) error
+}
+
+type Result struct {
+	Data        analyzers.AnalyzedData
+	Remediation Remediation
+	Calls       []Call
+	Err         error
+}
+
+// Remediator applies the remediations of the failed policies that were opted in.
+type Remediator interface {
+	Remediate(dataChannel <-chan analyzers.AnalyzedData) <-chan Result
+	DryRun() bool
+}
+
+func NewRemediator(executor Executor, remediations []Remediation, policies []string, dryRun bool) Remediator {
+	var selected []Remediation
+	for _, r := range remediations {
+		if slice_utils.Contains(policies, r.PolicyName) {
+			selected = append(selected, r)
+		}
+	}
+
+	return &remediator{
+		executor:     executor,
+		remediations: selected,
+		dryRun:       dryRun,
+	}
+}
+
+type remediator struct {
+	executor     Executor
+	remediations []Remediation
+	dryRun       bool
+}
+
+func (r *remediator) DryRun() bool {
+	return r.dryRun
+}
+
+func (r *remediator) Remediate(dataChannel <-chan analyzers.AnalyzedData) <-chan Result {
+	outputChannel := make(chan Result)
+
+	go func() {
+		defer close(outputChannel)
+		// calls are executed sequentially to keep the changes (and their log) in order
+		for data := range dataChannel {
+			if data.Status != analyzers.PolicyFailed {
+				continue
+			}
+			remediation, ok := r.find(data)
+			if !ok {
+				continue
+			}
+			outputChannel <- r.remediate(data, remediation)
+		}
+	}()
+
+	return outputChannel
+}
+
+func (r *remediator) find(data analyzers.AnalyzedData) (Remediation, bool) {
+	for _, remediation := range r.remediations {
+		if remediation.matches(data) {
+			return remediation, true
+		}
+	}
+
+	return Remediation{}, false
+}
+
+func (r *remediator) remediate(data analyzers.AnalyzedData, remediation Remediation) Result {
+	result := Result{
+		Data:        data,
+		Remediation: remediation,
+	}
+
+	calls, err := remediation.Calls(data.Entity)
+	if err != nil {
+		result.Err = err
+		return result
+	}
+	result.Calls = calls
+
+	if r.dryRun {
+		return result
+	}
+
+	for _, call := range calls {
+		if err := r.executor.Execute(call); err != nil {
+			result.Err = fmt.Errorf("%s: %v", call.String(), err)
+			return result
+		}
+	}
+
+	return result
+}
+
+// Supported returns the remediations available for the scm type.
+func Supported(scmType scm_type.ScmType) []Remediation {
+	switch scmType {
+	case scm_type.GitHub:
+		return GitHubRemediations
+	case scm_type.GitLab:
+		return GitLabRemediations
+	default:
+		return nil
+	}
+}
+
+// PolicyNames returns the unique names of the policies that can be remediated.
+func PolicyNames(remediations []Remediation) []string {
+	var result []string
+	seen := make(map[string]bool)
+	for _, r := range remediations {
+		if !seen[r.PolicyName] {
+			seen[r.PolicyName] = true
+			result = append(result, r.PolicyName)
+		}
+	}
+
+	return result
+}
+
+// Namespaces returns the unique namespaces of the remediations of the given policies.
+func Namespaces(remediations []Remediation, policies []string) []namespace.Namespace {
+	var result []namespace.Namespace
+	seen := make(map[namespace.Namespace]bool)
+	for _, r := range remediations {
+		if slice_utils.Contains(policies, r.PolicyName) && !seen[r.Namespace] {
+			seen[r.Namespace] = true
+			result = append(result, r.Namespace)
+		}
+	}
+
+	return result
+}
@@ -0,0 +1,123 @@
+package remediation
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/Legit-Labs/legitify/internal/analyzers"
+	githubcollected "github.com/Legit-Labs/legitify/internal/collected/github"
+	"github.com/Legit-Labs/legitify/internal/common/namespace"
+	"github.com/stretchr/testify/require"
+)
+
+type executorMock struct {
+	executed []Call
+	err      error
+}
+
+func (e *executorMock) Execute(call Call) error {
+	e.executed = append(e.executed, call)
+	return e.err
+}
+
+func repository(name string) githubcollected.Repository {
+	branch := "main"
+	return githubcollected.Repository{
+		Repository: &githubcollected.GitHubQLRepository{
+			Name: name,
+			Url:  "https://github.com/org/" + name,
+			DefaultBranchRef: &githubcollected.GitHubQLBranch{
+				Name:                 &branch,
+				BranchProtectionRule: &githubcollected.GitHubQLBranchProtectionRule{},
+			},
+		},
+	}
+}
+
+func analyzedData(policyName string, status analyzers.PolicyStatus) analyzers.AnalyzedData {
+	return analyzers.AnalyzedData{
+		Entity:     repository("repo"),
+		Namespace:  namespace.Repository,
+		PolicyName: policyName,
+		Status:     status,
+	}
+}
+
+func remediate(r Remediator, data ...analyzers.AnalyzedData) []Result {
+	input := make(chan analyzers.AnalyzedData, len(data))
+	for _, d := range data {
+		input <- d
+	}
+	close(input)
+
+	var results []Result
+	for res := range r.Remediate(input) {
+		results = append(results, res)
+	}
+
+	return results
+}
+
+func TestRemediator_OnlyOptedInFailedPolicies(t *testing.T) {
+	executor := &executorMock{}
+	r := NewRemediator(executor, GitHubRemediations, []string{"vulnerability_alerts_not_enabled"}, false)
+
+	results := remediate(r,
+		analyzedData("vulnerability_alerts_not_enabled", analyzers.PolicyFailed),
+		analyzedData("vulnerability_alerts_not_enabled", analyzers.PolicyPassed),
+		analyzedData("secret_scanning_not_enabled", analyzers.PolicyFailed),
+		analyzedData("code_review_not_required", analyzers.PolicyFailed),
+	)
+
+	require.Len(t, results, 1)
+	require.Nil(t, results[0].Err)
+	require.Equal(t, []Call{{Method: "PUT", Path: "repos/org/repo/vulnerability-alerts"}}, executor.executed)
+}
+
+func TestRemediator_DryRun(t *testing.T) {
+	executor := &executorMock{}
+	r := NewRemediator(executor, GitHubRemediations, []string{"no_signed_commits", "secret_scanning_not_enabled"}, true)
+
+	results := remediate(r,
+		analyzedData("no_signed_commits", analyzers.PolicyFailed),
+		analyzedData("secret_scanning_not_enabled", analyzers.PolicyFailed),
+	)
+
+	require.Len(t, results, 2)
+	require.Empty(t, executor.executed, "dry run should not execute any call")
+	require.Equal(t, "POST repos/org/repo/branches/main/protection/required_signatures", results[0].Calls[0].String())
+	require.Equal(t, `PATCH repos/org/repo {"security_and_analysis":{"secret_scanning":{"status":"enabled"}}}`, results[1].Calls[0].String())
+}
+
+func TestRemediator_ExecutionError(t *testing.T) {
+	executor := &executorMock{err: fmt.Errorf("forbidden")}
+	r := NewRemediator(executor, GitHubRemediations, []string{"vulnerability_alerts_not_enabled"}, false)
+
+	results := remediate(r, analyzedData("vulnerability_alerts_not_enabled", analyzers.PolicyFailed))
+
+	require.Len(t, results, 1)
+	require.ErrorContains(t, results[0].Err, "forbidden")
+}
+
+func TestRemediator_NoBranchProtection(t *testing.T) {
+	executor := &executorMock{}
+	r := NewRemediator(executor, GitHubRemediations, []string{"no_signed_commits"}, false)
+
+	data := analyzedData("no_signed_commits", analyzers.PolicyFailed)
+	repo := repository("repo")
+	repo.Repository.DefaultBranchRef.BranchProtectionRule = nil
+	data.Entity = repo
+
+	results := remediate(r, data)
+
+	require.Len(t, results, 1)
+	require.Error(t, results[0].Err)
+	require.Empty(t, executor.executed)
+}
+
+func TestNamespaces(t *testing.T) {
+	require.Equal(t, []namespace.Namespace{namespace.Actions, namespace.Repository},
+		Namespaces(GitHubRemediations, []string{"token_default_permissions_is_read_write"}))
+	require.Equal(t, []namespace.Namespace{namespace.Repository},
+		Namespaces(GitLabRemediations, []string{"no_signed_commits"}))
+}
@@ -0,0 +1,87 @@
+package trends
+
+import (
+	"sync"
+	"time"
+
+	"github.com/Legit-Labs/legitify/internal/collected"
+	"github.com/Legit-Labs/legitify/internal/enricher"
+)
+
+// Recorder persists the results of the analysis to the trends db.
+type Recorder interface {
+	Record(inputChannel <-chan enricher.EnrichedData) <-chan enricher.EnrichedData
+	Save() error
+}
+
+// NewRecorder returns a recorder that saves the run to the db in path; an empty path disables the recording.
+func NewRecorder(path string, scmType string) Recorder {
+	return &recorder{
+		path: path,
+		run: Run{
+			Timestamp: time.Now(),
+			ScmType:   scmType,
+		},
+	}
+}
+
+type recorder struct {
+	path string
+	lock sync.Mutex
+	run  Run
+}
+
+func (r *recorder) enabled() bool {
+	return r.path != ""
+}
+
+// Record collects the policy results that pass through the channel, without altering them.
+func (r *recorder) Record(inputChannel <-chan enricher.EnrichedData) <-chan enricher.EnrichedData {
+	if !r.enabled() {
+		return inputChannel
+	}
+
+	outputChannel := make(chan enricher.EnrichedData)
+	go func() {
+		defer close(outputChannel)
+		for data := range inputChannel {
+			r.record(data)
+			outputChannel <- data
+		}
+	}()
+
+	return outputChannel
+}
+
+func (r *recorder) record(data enricher.EnrichedData) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	r.run.Results = append(r.run.Results, Result{
+		PolicyName:   data.FullyQualifiedPolicyName,
+		PolicyTitle:  data.Title,
+		Namespace:    data.Namespace,
+		Severity:     data.Severity,
+		Organization: collected.OrganizationOf(data.Entity),
+		Entity:       data.CanonicalLink,
+		EntityName:   data.Entity.Name(),
+		Status:       data.Status,
+	})
+}
+
+func (r *recorder) Save() error {
+	if !r.enabled() {
+		return nil
+	}
+
+	store, err := Open(r.path)
+	if err != nil {
+		return err
+	}
+	defer store.Close()
+
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	return store.Save(r.run)
+}
@@ -0,0 +1,416 @@
+package trends
+
+import (
+	"bytes"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/Legit-Labs/legitify/internal/analyzers"
+	"github.com/Legit-Labs/legitify/internal/common/severity"
+	tw "github.com/olekukonko/tablewriter"
+)
+
+type Period = string
+
+const (
+	PeriodRun     Period = "run"
+	PeriodDay     Period = "day"
+	PeriodWeek    Period = "week"
+	PeriodMonth   Period = "month"
+	PeriodQuarter Period = "quarter"
+)
+
+var Periods = []Period{
+	PeriodRun,
+	PeriodDay,
+	PeriodWeek,
+	PeriodMonth,
+	PeriodQuarter,
+}
+
+func ValidatePeriod(period Period) error {
+	for _, p := range Periods {
+		if p == period {
+			return nil
+		}
+	}
+
+	return fmt.Errorf("invalid period %s", period)
+}
+
+var reportedSeverities = []severity.Severity{
+	severity.Critical,
+	severity.High,
+	severity.Medium,
+	severity.Low,
+}
+
+// Snapshot is the posture at the end of a period (i.e. the last run of the period).
+type Snapshot struct {
+	Period           string         `json:"period"`
+	Timestamp        time.Time      `json:"timestamp"`
+	Passed           int            `json:"passed"`
+	Failed           int            `json:"failed"`
+	Skipped          int            `json:"skipped"`
+	NotApplicable    int            `json:"notApplicable"`
+	FailedBySeverity map[string]int `json:"failedBySeverity"`
+}
+
+// PolicyTrend compares the number of entities failing a policy in the first and last periods.
+type PolicyTrend struct {
+	PolicyName  string `json:"policyName"`
+	PolicyTitle string `json:"policyTitle"`
+	Severity    string `json:"severity"`
+	First       int    `json:"first"`
+	Last        int    `json:"last"`
+}
+
+func (p PolicyTrend) Change() int {
+	return p.Last - p.First
+}
+
+// TimeToFix is the average time it took to fix a failed policy (from its first failure until it passed).
+type TimeToFix struct {
+	PolicyName  string        `json:"policyName"`
+	PolicyTitle string        `json:"policyTitle"`
+	Fixes       int           `json:"fixes"`
+	Average     time.Duration `json:"average"`
+}
+
+// Regression is a policy that failed for an entity after it passed in a previous run.
+type Regression struct {
+	PolicyName  string    `json:"policyName"`
+	PolicyTitle string    `json:"policyTitle"`
+	EntityName  string    `json:"entityName"`
+	Entity      string    `json:"entity"`
+	Timestamp   time.Time `json:"timestamp"`
+}
+
+type Report struct {
+	Snapshots   []Snapshot    `json:"snapshots"`
+	Policies    []PolicyTrend `json:"policies"`
+	TimeToFix   []TimeToFix   `json:"timeToFix"`
+	Regressions []Regression  `json:"regressions"`
+}
+
+// Filter selects the results that are reported. Empty fields select everything.
+type Filter struct {
+	// Policy is the full or short name of the policy
+	Policy        string
+	Organizations []string
+	ScmType       string
+}
+
+func (f Filter) matchesRun(run Run) bool {
+	return f.ScmType == "" || run.ScmType == f.ScmType
+}
+
+func (f Filter) matchesResult(r Result) bool {
+	if f.Policy != "" && r.PolicyName != f.Policy && !strings.HasSuffix(r.PolicyName, "."+f.Policy) {
+		return false
+	}
+	if len(f.Organizations) == 0 {
+		return true
+	}
+	for _, org := range f.Organizations {
+		if strings.EqualFold(r.Organization, org) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// ScmTypes returns the SCMs of the runs, sorted
+func ScmTypes(runs []Run) []string {
+	set := make(map[string]bool)
+	for _, run := range runs {
+		set[run.ScmType] = true
+	}
+
+	var result []string
+	for scmType := range set {
+		result = append(result, scmType)
+	}
+	sort.Strings(result)
+
+	return result
+}
+
+// BuildReport summarizes the runs (ordered by their timestamp) by the period.
+// Only the runs and the results that match the filter are considered.
+func BuildReport(runs []Run, period Period, filter Filter) Report {
+	runs = filterRuns(runs, filter)
+
+	var report Report
+	var snapshotRuns []Run
+	for i, run := range runs {
+		label := periodLabel(run, period)
+		isLastOfPeriod := i == len(runs)-1 || periodLabel(runs[i+1], period) != label
+		if isLastOfPeriod {
+			report.Snapshots = append(report.Snapshots, newSnapshot(run, label))
+			snapshotRuns = append(snapshotRuns, run)
+		}
+	}
+
+	if len(snapshotRuns) > 0 {
+		report.Policies = policyTrends(snapshotRuns[0], snapshotRuns[len(snapshotRuns)-1])
+	}
+	report.TimeToFix, report.Regressions = transitions(runs)
+
+	return report
+}
+
+func filterRuns(runs []Run, filter Filter) []Run {
+	var result []Run
+	for _, run := range runs {
+		if !filter.matchesRun(run) {
+			continue
+		}
+		filtered := run
+		filtered.Results = nil
+		for _, r := range run.Results {
+			if filter.matchesResult(r) {
+				filtered.Results = append(filtered.Results, r)
+			}
+		}
+		if len(filtered.Results) > 0 {
+			result = append(result, filtered)
+		}
+	}
+
+	return result
+}
+
+func periodLabel(run Run, period Period) string {
+	t := run.Timestamp.UTC()
+	switch period {
+	case PeriodDay:
+		return t.Format("2006-01-02")
+	case PeriodWeek:
+		year, week := t.ISOWeek()
+		return fmt.Sprintf("%d-W%02d", year, week)
+	case PeriodMonth:
+		return t.Format("2006-01")
+	case PeriodQuarter:
+		return fmt.Sprintf("%d-Q%d", t.Year(), (int(t.Month())-1)/3+1)
+	default:
+		return t.Format("2006-01-02 15:04:05")
+	}
+}
+
+func newSnapshot(run Run, label string) Snapshot {
+	snapshot := Snapshot{
+		Period:           label,
+		Timestamp:        run.Timestamp,
+		FailedBySeverity: make(map[string]int),
+	}
+
+	for _, r := range run.Results {
+		switch r.Status {
+		case analyzers.PolicyPassed:
+			snapshot.Passed++
+		case analyzers.PolicyFailed:
+			snapshot.Failed++
+			snapshot.FailedBySeverity[r.Severity]++
+		case analyzers.PolicySkipped:
+			snapshot.Skipped++
+		case analyzers.PolicyNotApplicable:
+			snapshot.NotApplicable++
+		}
+	}
+
+	return snapshot
+}
+
+func failedByPolicy(run Run) map[string]int {
+	result := make(map[string]int)
+	for _, r := range run.Results {
+		if r.Status == analyzers.PolicyFailed {
+			result[r.PolicyName]++
+		}
+	}
+
+	return result
+}
+
+func policyTrends(first Run, last Run) []PolicyTrend {
+	firstFailed := failedByPolicy(first)
+	lastFailed := failedByPolicy(last)
+
+	trends := make(map[string]*PolicyTrend)
+	for _, run := range []Run{first, last} {
+		for _, r := range run.Results {
+			if _, ok := trends[r.PolicyName]; !ok {
+				trends[r.PolicyName] = &PolicyTrend{
+					PolicyName:  r.PolicyName,
+					PolicyTitle: r.PolicyTitle,
+					Severity:    r.Severity,
+					First:       firstFailed[r.PolicyName],
+					Last:        lastFailed[r.PolicyName],
+				}
+			}
+		}
+	}
+
+	var result []PolicyTrend
+	for _, t := range trends {
+		if t.First == 0 && t.Last == 0 {
+			continue
+		}
+		result = append(result, *t)
+	}
+	sort.Slice(result, func(i, j int) bool {
+		if result[i].Change() != result[j].Change() {
+			return result[i].Change() < result[j].Change()
+		}
+		return result[i].PolicyName < result[j].PolicyName
+	})
+
+	return result
+}
+
+type resultKey struct {
+	policyName string
+	entity     string
+}
+
+type resultState struct {
+	status      analyzers.PolicyStatus
+	failedSince time.Time
+}
+
+// transitions follows each (policy, entity) across the runs to find fixes and regressions
+func transitions(runs []Run) ([]TimeToFix, []Regression) {
+	states := make(map[resultKey]*resultState)
+	fixes := make(map[string]*TimeToFix)
+	total := make(map[string]time.Duration)
+	var regressions []Regression
+
+	for _, run := range runs {
+		for _, r := range run.Results {
+			key := resultKey{policyName: r.PolicyName, entity: r.Entity}
+			state, ok := states[key]
+			if !ok {
+				state = &resultState{}
+				states[key] = state
+			}
+
+			switch r.Status {
+			case analyzers.PolicyFailed:
+				if state.status == analyzers.PolicyPassed {
+					regressions = append(regressions, Regression{
+						PolicyName:  r.PolicyName,
+						PolicyTitle: r.PolicyTitle,
+						EntityName:  r.EntityName,
+						Entity:      r.Entity,
+						Timestamp:   run.Timestamp,
+					})
+				}
+				if state.status != analyzers.PolicyFailed {
+					state.failedSince = run.Timestamp
+				}
+			case analyzers.PolicyPassed:
+				if state.status == analyzers.PolicyFailed {
+					fix, ok := fixes[r.PolicyName]
+					if !ok {
+						fix = &TimeToFix{PolicyName: r.PolicyName, PolicyTitle: r.PolicyTitle}
+						fixes[r.PolicyName] = fix
+					}
+					fix.Fixes++
+					total[r.PolicyName] += run.Timestamp.Sub(state.failedSince)
+				}
+			default:
+				// skipped and not applicable results say nothing about the state of the entity
+				continue
+			}
+			state.status = r.Status
+		}
+	}
+
+	var timeToFix []TimeToFix
+	for name, fix := range fixes {
+		fix.Average = total[name] / time.Duration(fix.Fixes)
+		timeToFix = append(timeToFix, *fix)
+	}
+	sort.Slice(timeToFix, func(i, j int) bool {
+		return timeToFix[i].PolicyName < timeToFix[j].PolicyName
+	})
+	sort.SliceStable(regressions, func(i, j int) bool {
+		return regressions[i].Timestamp.After(regressions[j].Timestamp)
+	})
+
+	return timeToFix, regressions
+}
+
+// Human renders the report as text tables
+func (r Report) Human() []byte {
+	var buf bytes.Buffer
+
+	if len(r.Snapshots) == 0 {
+		buf.WriteString("No runs were recorded in the selected time frame\n")
+		return buf.Bytes()
+	}
+
+	headers := []string{"Period", "Passed", "Failed", "Skipped", "Not Applicable"}
+	for _, s := range reportedSeverities {
+		headers = append(headers, "Failed "+s)
+	}
+	var rows [][]string
+	for _, s := range r.Snapshots {
+		row := []string{s.Period, strconv.Itoa(s.Passed), strconv.Itoa(s.Failed), strconv.Itoa(s.Skipped), strconv.Itoa(s.NotApplicable)}
+		for _, sev := range reportedSeverities {
+			row = append(row, strconv.Itoa(s.FailedBySeverity[sev]))
+		}
+		rows = append(rows, row)
+	}
+	writeTable(&buf, "Posture Over Time", headers, rows)
+
+	rows = nil
+	for _, p := range r.Policies {
+		rows = append(rows, []string{p.PolicyTitle, p.Severity, strconv.Itoa(p.First), strconv.Itoa(p.Last), fmt.Sprintf("%+d", p.Change())})
+	}
+	writeTable(&buf, "Failed Entities Per Policy (First vs. Last Period)",
+		[]string{"Policy", "Severity", "First", "Last", "Change"}, rows)
+
+	rows = nil
+	for _, t := range r.TimeToFix {
+		rows = append(rows, []string{t.PolicyTitle, strconv.Itoa(t.Fixes), formatDuration(t.Average)})
+	}
+	writeTable(&buf, "Time To Fix", []string{"Policy", "Fixes", "Average Time To Fix"}, rows)
+
+	rows = nil
+	for _, reg := range r.Regressions {
+		rows = append(rows, []string{reg.Timestamp.UTC().Format("2006-01-02 15:04:05"), reg.PolicyTitle, reg.EntityName, reg.Entity})
+	}
+	writeTable(&buf, "Regressions", []string{"Date", "Policy", "Entity", "Link"}, rows)
+
+	return buf.Bytes()
+}
+
+func writeTable(buf *bytes.Buffer, title string, headers []string, rows [][]string) {
+	buf.WriteString(fmt.Sprintf("\n%s:\n", title))
+	if len(rows) == 0 {
+		buf.WriteString("None\n")
+		return
+	}
+
+	table := tw.NewWriter(buf)
+	table.SetHeader(headers)
+	table.SetAutoFormatHeaders(false)
+	table.SetAutoWrapText(false)
+	table.AppendBulk(rows)
+	table.Render()
+}
+
+func formatDuration(d time.Duration) string {
+	days := int(d.Hours() / 24)
+	if days > 0 {
+		return fmt.Sprintf("%dd %dh", days, int(d.Hours())%24)
+	}
+
+	return d.Round(time.Minute).String()
+}
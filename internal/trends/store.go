@@ -0,0 +1,147 @@
+package trends
+
+import (
+	"database/sql"
+	"fmt"
+	"time"
+
+	// registers the pure-go sqlite driver (legitify is built without cgo)
+	_ "modernc.org/sqlite"
+)
+
+const schema = `
+CREATE TABLE IF NOT EXISTS runs (
+	id        INTEGER PRIMARY KEY AUTOINCREMENT,
+	timestamp INTEGER NOT NULL,
+	scm       TEXT    NOT NULL
+);
+CREATE TABLE IF NOT EXISTS results (
+	run_id       INTEGER NOT NULL REFERENCES runs(id),
+	policy_name  TEXT    NOT NULL,
+	policy_title TEXT    NOT NULL,
+	namespace    TEXT    NOT NULL,
+	severity     TEXT    NOT NULL,
+	organization TEXT    NOT NULL,
+	entity       TEXT    NOT NULL,
+	entity_name  TEXT    NOT NULL,
+	status       TEXT    NOT NULL
+);
+CREATE INDEX IF NOT EXISTS results_run_id ON results(run_id);
+`
+
+// Run is the result of a single analysis.
+type Run struct {
+	ID        int64
+	Timestamp time.Time
+	ScmType   string
+	Results   []Result
+}
+
+// Result is the status of a single policy for a single entity.
+type Result struct {
+	PolicyName   string
+	PolicyTitle  string
+	Namespace    string
+	Severity     string
+	Organization string
+	// Entity is the canonical link of the entity, which is stable across runs
+	Entity     string
+	EntityName string
+	Status     string
+}
+
+type Store struct {
+	db *sql.DB
+}
+
+func Open(path string) (*Store, error) {
+	db, err := sql.Open("sqlite", path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open trends db %s: %v", path, err)
+	}
+
+	if _, err := db.Exec(schema); err != nil {
+		db.Close()
+		return nil, fmt.Errorf("failed to initialize trends db %s: %v", path, err)
+	}
+
+	return &Store{
+		db: db,
+	}, nil
+}
+
+func (s *Store) Close() error {
+	return s.db.Close()
+}
+
+func (s *Store) Save(run Run) (err error) {
+	tx, err := s.db.Begin()
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if err != nil {
+			_ = tx.Rollback()
+		}
+	}()
+
+	res, err := tx.Exec("INSERT INTO runs (timestamp, scm) VALUES (?, ?)", run.Timestamp.Unix(), run.ScmType)
+	if err != nil {
+		return err
+	}
+	runID, err := res.LastInsertId()
+	if err != nil {
+		return err
+	}
+
+	stmt, err := tx.Prepare(`INSERT INTO results
+		(run_id, policy_name, policy_title, namespace, severity, organization, entity, entity_name, status)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?)`)
+	if err != nil {
+		return err
+	}
+	defer stmt.Close()
+
+	for _, r := range run.Results {
+		_, err = stmt.Exec(runID, r.PolicyName, r.PolicyTitle, r.Namespace, r.Severity, r.Organization, r.Entity, r.EntityName, r.Status)
+		if err != nil {
+			return err
+		}
+	}
+
+	return tx.Commit()
+}
+
+// Load returns the runs since the given time (inclusive), ordered by their timestamp.
+func (s *Store) Load(since time.Time) ([]Run, error) {
+	rows, err := s.db.Query(`SELECT r.id, r.timestamp, r.scm,
+		res.policy_name, res.policy_title, res.namespace, res.severity, res.organization, res.entity, res.entity_name, res.status
+		FROM runs r JOIN results res ON res.run_id = r.id
+		WHERE r.timestamp >= ?
+		ORDER BY r.timestamp, r.id`, since.Unix())
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var runs []Run
+	for rows.Next() {
+		var run Run
+		var timestamp int64
+		var r Result
+		err := rows.Scan(&run.ID, &timestamp, &run.ScmType,
+			&r.PolicyName, &r.PolicyTitle, &r.Namespace, &r.Severity, &r.Organization, &r.Entity, &r.EntityName, &r.Status)
+		if err != nil {
+			return nil, err
+		}
+
+		if len(runs) == 0 || runs[len(runs)-1].ID != run.ID {
+			run.Timestamp = time.Unix(timestamp, 0)
+			runs = append(runs, run)
+		}
+		last := &runs[len(runs)-1]
+		last.Results = append(last.Results, r)
+	}
+
+	return runs, rows.Err()
+}
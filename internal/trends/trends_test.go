@@ -0,0 +1,149 @@
+package trends_test
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/Legit-Labs/legitify/internal/analyzers"
+	"github.com/Legit-Labs/legitify/internal/common/severity"
+	"github.com/Legit-Labs/legitify/internal/trends"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	protectionPolicy = "data.repository.missing_default_branch_protection"
+	signedPolicy     = "data.repository.no_signed_commits"
+)
+
+func result(policy string, entity string, status analyzers.PolicyStatus) trends.Result {
+	return trends.Result{
+		PolicyName:   policy,
+		PolicyTitle:  policy,
+		Namespace:    "repository",
+		Severity:     severity.High,
+		Organization: "org",
+		Entity:       "https://github.com/org/" + entity,
+		EntityName:   entity,
+		Status:       status,
+	}
+}
+
+func sampleRuns() []trends.Run {
+	jan := time.Date(2023, time.January, 10, 0, 0, 0, 0, time.UTC)
+	feb := time.Date(2023, time.February, 10, 0, 0, 0, 0, time.UTC)
+	apr := time.Date(2023, time.April, 10, 0, 0, 0, 0, time.UTC)
+	may := time.Date(2023, time.May, 10, 0, 0, 0, 0, time.UTC)
+
+	return []trends.Run{
+		{Timestamp: jan, ScmType: "github", Results: []trends.Result{
+			result(protectionPolicy, "a", analyzers.PolicyFailed),
+			result(protectionPolicy, "b", analyzers.PolicyFailed),
+			result(signedPolicy, "a", analyzers.PolicyPassed),
+		}},
+		{Timestamp: feb, ScmType: "github", Results: []trends.Result{
+			result(protectionPolicy, "a", analyzers.PolicyPassed),
+			result(protectionPolicy, "b", analyzers.PolicyFailed),
+			result(signedPolicy, "a", analyzers.PolicySkipped),
+		}},
+		{Timestamp: apr, ScmType: "github", Results: []trends.Result{
+			result(protectionPolicy, "a", analyzers.PolicyPassed),
+			result(protectionPolicy, "b", analyzers.PolicyPassed),
+			result(signedPolicy, "a", analyzers.PolicyFailed),
+		}},
+		{Timestamp: may, ScmType: "github", Results: []trends.Result{
+			result(protectionPolicy, "a", analyzers.PolicyPassed),
+			result(protectionPolicy, "b", analyzers.PolicyPassed),
+			result(signedPolicy, "a", analyzers.PolicyFailed),
+			result(signedPolicy, "b", analyzers.PolicyNotApplicable),
+		}},
+	}
+}
+
+func TestStore(t *testing.T) {
+	store, err := trends.Open(filepath.Join(t.TempDir(), "trends.db"))
+	require.Nil(t, err)
+	defer store.Close()
+
+	runs := sampleRuns()
+	for _, run := range runs {
+		require.Nil(t, store.Save(run))
+	}
+
+	loaded, err := store.Load(time.Time{})
+	require.Nil(t, err)
+	require.Len(t, loaded, len(runs))
+	for i := range runs {
+		require.True(t, runs[i].Timestamp.Equal(loaded[i].Timestamp))
+		require.Equal(t, runs[i].Results, loaded[i].Results)
+	}
+
+	loaded, err = store.Load(runs[2].Timestamp)
+	require.Nil(t, err)
+	require.Len(t, loaded, 2)
+}
+
+func TestBuildReport(t *testing.T) {
+	report := trends.BuildReport(sampleRuns(), trends.PeriodQuarter, trends.Filter{})
+
+	require.Len(t, report.Snapshots, 2)
+	require.Equal(t, "2023-Q1", report.Snapshots[0].Period)
+	require.Equal(t, 1, report.Snapshots[0].Failed)
+	require.Equal(t, 1, report.Snapshots[0].Skipped)
+	require.Equal(t, "2023-Q2", report.Snapshots[1].Period)
+	require.Equal(t, 2, report.Snapshots[1].Passed)
+	require.Equal(t, 1, report.Snapshots[1].FailedBySeverity[severity.High])
+	require.Equal(t, 1, report.Snapshots[1].NotApplicable)
+
+	require.Len(t, report.Policies, 2)
+	require.Equal(t, protectionPolicy, report.Policies[0].PolicyName)
+	require.Equal(t, -1, report.Policies[0].Change())
+	require.Equal(t, signedPolicy, report.Policies[1].PolicyName)
+	require.Equal(t, 1, report.Policies[1].Change())
+
+	require.Len(t, report.TimeToFix, 1)
+	require.Equal(t, 2, report.TimeToFix[0].Fixes)
+	jan := sampleRuns()[0].Timestamp
+	expected := (sampleRuns()[1].Timestamp.Sub(jan) + sampleRuns()[2].Timestamp.Sub(jan)) / 2
+	require.Equal(t, expected, report.TimeToFix[0].Average)
+
+	// skipped results do not break the passed -> failed transition
+	require.Len(t, report.Regressions, 1)
+	require.Equal(t, signedPolicy, report.Regressions[0].PolicyName)
+	require.Equal(t, "a", report.Regressions[0].EntityName)
+}
+
+func TestBuildReport_PolicyFilter(t *testing.T) {
+	report := trends.BuildReport(sampleRuns(), trends.PeriodRun, trends.Filter{Policy: "missing_default_branch_protection"})
+
+	require.Len(t, report.Snapshots, 4)
+	for _, s := range report.Snapshots {
+		require.Equal(t, 2, s.Passed+s.Failed)
+	}
+	require.Empty(t, report.Regressions)
+}
+
+func TestBuildReport_OrganizationAndScmFilter(t *testing.T) {
+	other := result(protectionPolicy, "c", analyzers.PolicyFailed)
+	other.Organization = "other"
+	runs := sampleRuns()
+	runs[0].Results = append(runs[0].Results, other)
+	gitlab := trends.Run{Timestamp: runs[1].Timestamp.Add(time.Hour), ScmType: "gitlab", Results: []trends.Result{
+		result(protectionPolicy, "a", analyzers.PolicyFailed),
+	}}
+	runs = append(runs[:2], append([]trends.Run{gitlab}, runs[2:]...)...)
+
+	require.Equal(t, []string{"github", "gitlab"}, trends.ScmTypes(runs))
+
+	report := trends.BuildReport(runs, trends.PeriodRun, trends.Filter{Organizations: []string{"ORG"}, ScmType: "github"})
+	require.Len(t, report.Snapshots, 4)
+	require.Equal(t, 2, report.Snapshots[0].Failed)
+	// the failure in the gitlab run is neither a regression nor a new failure to fix
+	require.Len(t, report.Regressions, 1)
+	require.Len(t, report.TimeToFix, 1)
+	require.Equal(t, 2, report.TimeToFix[0].Fixes)
+
+	report = trends.BuildReport(runs, trends.PeriodRun, trends.Filter{Organizations: []string{"other"}})
+	require.Len(t, report.Snapshots, 1)
+	require.Equal(t, 1, report.Snapshots[0].Failed)
+}
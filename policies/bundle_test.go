@@ -2,9 +2,12 @@ package policies
 
 import (
 	"embed"
+	"io/fs"
 	"path"
+	"strings"
 	"testing"
 
+	"github.com/open-policy-agent/opa/ast"
 	"github.com/stretchr/testify/require"
 )
 
@@ -47,5 +50,36 @@ func TestPoliciesBundle(t *testing.T) {
 	count, err := countBundles()
 
 	require.Nilf(t, err, "counting files: %v", err)
-	require.Equal(t, count, 9, "Expecting 9 files in bundle")
+	require.Equal(t, count, 15, "Expecting 15 files in bundle")
+}
+
+// Every rule of a namespace package is returned by the namespace query and analyzed as a policy,
+// so helpers must be functions or live in the common packages.
+func TestPoliciesHaveMetadata(t *testing.T) {
+	for _, bundle := range []embed.FS{GitHubBundle, GitLabBundle} {
+		err := fs.WalkDir(bundle, ".", func(p string, d fs.DirEntry, err error) error {
+			if err != nil || d.IsDir() || strings.Contains(p, "/common/") {
+				return err
+			}
+
+			content, err := bundle.ReadFile(p)
+			require.Nilf(t, err, "reading %s: %v", p, err)
+			module, err := ast.ParseModuleWithOpts(p, string(content), ast.ParserOptions{ProcessAnnotation: true})
+			require.Nilf(t, err, "parsing %s: %v", p, err)
+
+			annotated := make(map[string]bool)
+			for _, annotation := range module.Annotations {
+				if annotation.Scope == "rule" {
+					annotated[annotation.GetTargetPath().String()] = true
+				}
+			}
+			for _, rule := range module.Rules {
+				if len(rule.Head.Args) == 0 {
+					require.Truef(t, annotated[rule.Path().String()], "%s:%d: %s has no METADATA", p, rule.Location.Row, rule.Path())
+				}
+			}
+			return nil
+		})
+		require.Nil(t, err)
+	}
 }
@@ -0,0 +1,102 @@
+package apps
+
+# METADATA
+# scope: rule
+# title: GitHub Apps Should Not Have Write Access To All Repositories
+# description: Some of the GitHub Apps installed on the organization can write to all of its repositories, including the repositories that are created after their installation. Third-party apps should only be granted access to the repositories they need.
+# custom:
+#   severity: HIGH
+#   requiredEnrichers: [organizationId, appsList]
+#   requiredScopes: [admin:org]
+#   remediationSteps:
+#     - 1. Go to the organization settings page
+#     - 2. Under 'Third-party Access', press 'GitHub Apps'
+#     - 3. Press 'Configure' next to the violating app
+#     - 4. Under 'Repository access', choose 'Only select repositories' and select the repositories the app needs
+#   threat: A compromised or malicious app, or a leak of its private key, allows an attacker to push code to every repository of the organization.
+app_has_write_access_to_all_repositories[violated] := true {
+    some index
+    installation := input.installations[index]
+    not installation.suspended
+    installation.repository_selection == "all"
+    some permission
+    is_write_access(installation.permissions[permission])
+    is_repository_permission(permission)
+    violated := {
+        "app": installation.app_slug,
+        "permission": permission,
+    }
+}
+
+# METADATA
+# scope: rule
+# title: GitHub Apps Should Not Have Administrative Permissions
+# description: Some of the GitHub Apps installed on the organization can administer the organization or its repositories, e.g. manage members and teams, change settings or remove branch protection. Administrative permissions should be reserved for apps that are owned and reviewed by the organization.
+# custom:
+#   severity: HIGH
+#   requiredEnrichers: [organizationId, appsList]
+#   requiredScopes: [admin:org]
+#   remediationSteps:
+#     - 1. Go to the organization settings page
+#     - 2. Under 'Third-party Access', press 'GitHub Apps'
+#     - 3. Review the permissions of the violating app, and uninstall it if its administrative permissions are not required
+#   threat: An attacker who compromises an app with administrative permissions can take over the organization, e.g. add members, grant themselves access to repositories or disable security settings.
+app_has_admin_permissions[violated] := true {
+    some index
+    installation := input.installations[index]
+    not installation.suspended
+    some permission
+    is_admin_permission(permission, installation.permissions[permission])
+    violated := {
+        "app": installation.app_slug,
+        "permission": permission,
+    }
+}
+
+# METADATA
+# scope: rule
+# title: OAuth App Access Restrictions Should Be Enabled
+# description: The organization allows any OAuth app that its members authorize to access its resources. OAuth app access restrictions require an owner to approve each app before it can access the organization. The state of the restrictions is read from the audit log, which is only available in GitHub Enterprise Cloud, and the policy is skipped when the audit log has no record of it.
+# custom:
+#   severity: HIGH
+#   requiredEnrichers: [organizationId]
+#   requiredScopes: [admin:org, read:audit_log]
+#   prerequisites: [oauth_app_restrictions_known]
+#   remediationSteps:
+#     - 1. Go to the organization settings page
+#     - 2. Under 'Third-party Access', press 'OAuth application policy'
+#     - 3. Press 'Restrict third-party application access'
+#   threat: A member may authorize a malicious OAuth app, e.g. through phishing, which can then access the private repositories of the organization on their behalf without being reviewed.
+default oauth_app_restrictions_disabled := false
+
+oauth_app_restrictions_disabled := true {
+    input.oauth_app_restrictions == false
+}
+
+is_write_access(level) {
+    level == "write"
+}
+
+is_write_access(level) {
+    level == "admin"
+}
+
+# permissions that apply to the organization itself rather than to its repositories
+is_organization_permission(permission) {
+    {"members", "organization_administration", "organization_custom_roles", "organization_hooks", "organization_plan",
+        "organization_pre_receive_hooks", "organization_projects", "organization_secrets", "organization_self_hosted_runners",
+        "organization_user_blocking", "team_discussions", "organization_packages"}[permission]
+}
+
+is_repository_permission(permission) {
+    not is_organization_permission(permission)
+}
+
+is_admin_permission(permission, level) {
+    level == "admin"
+}
+
+is_admin_permission(permission, level) {
+    {"administration", "organization_administration", "members"}[permission]
+    level == "write"
+}
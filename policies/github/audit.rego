@@ -0,0 +1,167 @@
+package audit
+
+event_time(event) := object.get(event, "@timestamp", "")
+
+event_actor(event) := object.get(event, "actor", "")
+
+# METADATA
+# scope: rule
+# title: Branch Protection Should Not Be Disabled
+# description: A branch protection rule or a ruleset was deleted, or a protected branch was pushed to by bypassing its protection, during the analyzed time window. Even if the protection was restored afterwards, the changes that were pushed while it was disabled were not reviewed.
+# custom:
+#   severity: HIGH
+#   requiredEnrichers: [entityId, auditEventsList]
+#   remediationSteps:
+#     - 1. Go to the organization (or enterprise) settings page
+#     - 2. Under 'Archives', press 'Audit log'
+#     - 3. Review the violating events with their actors, and make sure the protection was restored
+#     - 4. Review the commits that were pushed to the branch while it was unprotected
+#   threat: An attacker with admin permission can temporarily remove the protection of a branch, push malicious code without a review and restore the protection, leaving the current configuration compliant.
+branch_protection_disabled[violated] := true {
+	some index
+	event := input.events[index]
+	{"protected_branch.destroy", "protected_branch.policy_override", "repository_ruleset.destroy"}[event.action]
+	violated := {
+		"action": event.action,
+		"actor": event_actor(event),
+		"repository": object.get(event, "repo", ""),
+		"name": object.get(event, "name", ""),
+		"time": event_time(event),
+	}
+}
+
+# METADATA
+# scope: rule
+# title: Repositories Should Not Be Made Public
+# description: The visibility of a repository was changed to public during the analyzed time window. Making a repository public exposes its code, history and workflow logs to everyone, and cannot be undone once its content was copied.
+# custom:
+#   severity: HIGH
+#   requiredEnrichers: [entityId, auditEventsList]
+#   remediationSteps:
+#     - 1. Go to the organization (or enterprise) settings page
+#     - 2. Under 'Archives', press 'Audit log'
+#     - 3. Review the violating events, and make sure the repositories were meant to be public
+#     - 4. Otherwise, change the visibility of the repositories back to private and rotate the secrets that were exposed
+#   threat: Source code, credentials committed to the history and internal information become available to anyone, including attackers looking for vulnerabilities and secrets.
+repository_made_public[violated] := true {
+	some index
+	event := input.events[index]
+	event.action == "repo.access"
+	event.visibility == "public"
+	violated := {
+		"actor": event_actor(event),
+		"repository": object.get(event, "repo", ""),
+		"previous visibility": object.get(event, "previous_visibility", ""),
+		"time": event_time(event),
+	}
+}
+
+is_admin_granted(event) {
+	{"org.add_member", "org.update_member"}[event.action]
+	event.permission == "admin"
+}
+
+is_admin_granted(event) {
+	event.action == "business.add_admin"
+}
+
+# METADATA
+# scope: rule
+# title: New Admins Should Be Reviewed
+# description: A user was granted the owner role of the organization, or was added as an owner of the enterprise, during the analyzed time window. Administrative access should be granted rarely, and every grant should be reviewed.
+# custom:
+#   severity: MEDIUM
+#   requiredEnrichers: [entityId, auditEventsList]
+#   remediationSteps:
+#     - 1. Go to the organization (or enterprise) settings page
+#     - 2. Under 'Archives', press 'Audit log'
+#     - 3. Review the violating events, and make sure the users should be admins
+#     - 4. Otherwise, change the role of the users back to member
+#   threat: An attacker that compromised an admin account can grant admin access to another account they control, to keep their access after the compromised account is recovered.
+admin_added[violated] := true {
+	some index
+	event := input.events[index]
+	is_admin_granted(event)
+	violated := {
+		"action": event.action,
+		"actor": event_actor(event),
+		"user": object.get(event, "user", ""),
+		"time": event_time(event),
+	}
+}
+
+# METADATA
+# scope: rule
+# title: Deploy Keys With Write Access Should Be Reviewed
+# description: A deploy key with write access was added to a repository during the analyzed time window. A deploy key with write access can push to the repository without being tied to a user account, and is not subject to the SSO and two-factor authentication requirements.
+# custom:
+#   severity: MEDIUM
+#   requiredEnrichers: [entityId, auditEventsList]
+#   remediationSteps:
+#     - 1. Go to the organization (or enterprise) settings page
+#     - 2. Under 'Archives', press 'Audit log'
+#     - 3. Review the violating events, and make sure the deploy keys need write access
+#     - 4. Otherwise, go to the repository settings page, press 'Deploy keys' and remove the deploy key
+#   threat: An attacker that gained admin access to a repository can add a deploy key to keep pushing to it after their access is revoked.
+deploy_key_with_write_access_added[violated] := true {
+	some index
+	event := input.events[index]
+	event.action == "public_key.create"
+	event.repo
+	event.read_only == "false"
+	violated := {
+		"actor": event_actor(event),
+		"repository": event.repo,
+		"fingerprint": object.get(event, "fingerprint", ""),
+		"time": event_time(event),
+	}
+}
+
+# METADATA
+# scope: rule
+# title: Secret Scanning Should Not Be Disabled
+# description: Secret scanning or its push protection was disabled for a repository, for the organization or for the enterprise during the analyzed time window. Secrets that were pushed while it was disabled were not detected nor blocked.
+# custom:
+#   severity: HIGH
+#   requiredEnrichers: [entityId, auditEventsList]
+#   remediationSteps:
+#     - 1. Go to the organization (or enterprise) settings page
+#     - 2. Under 'Archives', press 'Audit log'
+#     - 3. Review the violating events, and enable secret scanning and push protection again
+#     - 4. Scan the commits that were pushed while secret scanning was disabled for secrets
+#   threat: An attacker or a careless developer can disable secret scanning to push credentials that would otherwise be blocked, exposing them to everyone with read access.
+secret_scanning_disabled[violated] := true {
+	some index
+	event := input.events[index]
+	{"secret_scanning.disable", "repository_secret_scanning.disable", "repository_secret_scanning_push_protection.disable", "business_secret_scanning.disable", "business_secret_scanning_push_protection.disable"}[event.action]
+	violated := {
+		"action": event.action,
+		"actor": event_actor(event),
+		"repository": object.get(event, "repo", ""),
+		"time": event_time(event),
+	}
+}
+
+# METADATA
+# scope: rule
+# title: IP Allow List Changes Should Be Reviewed
+# description: The IP allow list was disabled, or its entries were changed, during the analyzed time window. The IP allow list restricts the networks from which the organization resources can be accessed, and every change to it widens or shifts that boundary.
+# custom:
+#   severity: MEDIUM
+#   requiredEnrichers: [entityId, auditEventsList]
+#   remediationSteps:
+#     - 1. Go to the organization (or enterprise) settings page
+#     - 2. Under 'Archives', press 'Audit log'
+#     - 3. Review the violating events, and make sure the changes were approved
+#     - 4. Under 'Authentication security', review the IP allow list and its entries
+#   threat: An attacker with admin access can add their own network to the IP allow list, or disable it, to access the organization resources with stolen credentials from outside the company network.
+ip_allow_list_changed[violated] := true {
+	some index
+	event := input.events[index]
+	{"ip_allow_list.disable", "ip_allow_list.disable_for_installed_apps", "ip_allow_list_entry.create", "ip_allow_list_entry.update", "ip_allow_list_entry.destroy"}[event.action]
+	violated := {
+		"action": event.action,
+		"actor": event_actor(event),
+		"time": event_time(event),
+	}
+}
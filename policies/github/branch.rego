@@ -0,0 +1,373 @@
+package branch
+
+import data.common.protections as protectionUtils
+
+# METADATA
+# scope: rule
+# title: Branch Should Be Protected
+# description: Branch protection is not enabled for a branch that matches the configured branch patterns (e.g. release branches). Protecting branches ensures new code changes must go through a controlled merge process and allows enforcement of code review as well as other security tests.
+# custom:
+#   remediationSteps:
+#     - 1. Make sure you have admin permissions
+#     - 2. Go to the repo's settings page
+#     - 3. Enter 'Branches' tab
+#     - 4. Under 'Branch protection rules'
+#     - 5. Click 'Add rule'
+#     - 6. Set 'Branch name pattern' to match the branch (e.g. 'release/*')
+#     - 7. Set desired protections
+#     - 8. Click 'Create' and save the rule
+#   severity: MEDIUM
+#   requiredScopes: [repo]
+#   prerequisites: [has_branch_protection_permission, not_archived]
+#   threat: Any contributor with write access may push potentially dangerous code to this branch, which is released from, making it easier to compromise and difficult to audit.
+default missing_branch_protection := true
+
+missing_branch_protection := false {
+	protectionUtils.is_protected(input)
+}
+
+# METADATA
+# scope: rule
+# title: Branch Deletion Protection Should Be Enabled
+# description: The branch is not protected against deletion.
+# custom:
+#   remediationSteps:
+#     - "Note: The remediation steps apply to legacy branch protections, rules set-based protection should be updated from the rules set page"
+#     - 1. Make sure you have admin permissions
+#     - 2. Go to the repo's settings page
+#     - 3. Enter 'Branches' tab
+#     - 4. Under 'Branch protection rules'
+#     - 5. Click 'Edit' on the rule that matches the branch
+#     - 6. Uncheck 'Allow deletions', Click 'Save changes'
+#   severity: MEDIUM
+#   requiredScopes: [repo]
+#   prerequisites: [has_branch_protection_permission, not_archived]
+#   threat: Rewriting project history can make it difficult to trace back when bugs or security issues were introduced, making them more difficult to remediate.
+default missing_branch_protection_deletion := true
+
+missing_branch_protection_deletion := false {
+	protectionUtils.prevents_deletion(input)
+}
+
+# METADATA
+# scope: rule
+# title: Branch Should Not Allow Force Pushes
+# description: The history of the branch is not protected against changes. Protecting branch history ensures every change that was made to code can be retained and later examined. This issue is raised if the branch history can be modified using force push.
+# custom:
+#   remediationSteps:
+#     - "Note: The remediation steps apply to legacy branch protections, rules set-based protection should be updated from the rules set page"
+#     - 1. Make sure you have admin permissions
+#     - 2. Go to the repo's settings page
+#     - 3. Enter 'Branches' tab
+#     - 4. Under 'Branch protection rules'
+#     - 5. Click 'Edit' on the rule that matches the branch
+#     - 6. Uncheck 'Allow force pushes'
+#     - 7. Click 'Save changes'
+#   severity: MEDIUM
+#   requiredScopes: [repo]
+#   prerequisites: [has_branch_protection_permission, not_archived]
+#   threat: Rewriting project history can make it difficult to trace back when bugs or security issues were introduced, making them more difficult to remediate.
+default missing_branch_protection_force_push := true
+
+missing_branch_protection_force_push := false {
+	protectionUtils.prevents_force_push(input)
+}
+
+# METADATA
+# scope: rule
+# title: Branch Should Require All Checks To Pass Before Merge
+# description: The checks that validate the quality and security of the code are not required to pass before submitting new changes to the branch. It is advised to turn this control on to ensure any existing or future check will be required to pass.
+# custom:
+#   remediationSteps:
+#     - "Note: The remediation steps apply to legacy branch protections, rules set-based protection should be updated from the rules set page"
+#     - 1. Make sure you have admin permissions
+#     - 2. Go to the repo's settings page
+#     - 3. Enter 'Branches' tab
+#     - 4. Under 'Branch protection rules'
+#     - 5. Click 'Edit' on the rule that matches the branch
+#     - 6. Check 'Require status checks to pass before merging'
+#     - 7. Add the required checks that must pass before merging (tests, lint, etc...)
+#     - 8. Click 'Save changes'
+#   severity: MEDIUM
+#   requiredScopes: [repo]
+#   prerequisites: [has_branch_protection_permission, not_archived]
+#   threat: Not defining a set of required status checks can make it easy for contributors to introduce buggy or insecure code as manual review, whether mandated or optional, is the only line of defense.
+default requires_status_checks := true
+
+requires_status_checks := false {
+	protectionUtils.requires_status_checks(input)
+}
+
+# METADATA
+# scope: rule
+# title: Branch Should Require Branches To Be Up To Date Before Merge
+# description: Status checks are required, but branches that are not up to date can be merged. This can result in previously remediated issues being merged in over fixes.
+# custom:
+#   remediationSteps:
+#     - "Note: The remediation steps apply to legacy branch protections, rules set-based protection should be updated from the rules set page"
+#     - 1. Make sure you have admin permissions
+#     - 2. Go to the repo's settings page
+#     - 3. Enter 'Branches' tab
+#     - 4. Under 'Branch protection rules'
+#     - 5. Click 'Edit' on the rule that matches the branch
+#     - 6. Check 'Require status checks to pass before merging'
+#     - 7. Check 'Require branches to be up to date before merging'
+#     - 8. Click 'Save changes'
+#   severity: MEDIUM
+#   requiredScopes: [repo]
+#   prerequisites: [has_branch_protection_permission, not_archived]
+#   threat: Required status checks may be failing on the latest version after passing on an earlier version of the code, making it easy to commit buggy or otherwise insecure code.
+default requires_branches_up_to_date_before_merge := true
+
+requires_branches_up_to_date_before_merge := false {
+	protectionUtils.requires_branches_up_to_date(input)
+}
+
+# METADATA
+# scope: rule
+# title: Branch Should Require New Code Changes After Approval To Be Re-Approved
+# description: This security control prevents merging code that was approved but later on changed. Turning it on ensures any new changes must be reviewed again. If turned off - a developer can change the code after approval, and push code that is different from the one that was previously allowed.
+# custom:
+#   remediationSteps:
+#     - "Note: The remediation steps apply to legacy branch protections, rules set-based protection should be updated from the rules set page"
+#     - 1. Make sure you have admin permissions
+#     - 2. Go to the repo's settings page
+#     - 3. Enter 'Branches' tab
+#     - 4. Under 'Branch protection rules'
+#     - 5. Click 'Edit' on the rule that matches the branch
+#     - 6. Check 'Require a pull request before merging'
+#     - 7. Check 'Dismiss stale pull request approvals when new commits are pushed'
+#     - 8. Click 'Save changes'
+#   severity: LOW
+#   requiredScopes: [repo]
+#   prerequisites: [has_branch_protection_permission, not_archived]
+#   threat: Buggy or insecure code may be committed after approval and will reach the branch without review. Alternatively, an attacker can attempt a just-in-time attack to introduce dangerous code just before merge.
+default dismisses_stale_reviews := true
+
+dismisses_stale_reviews := false {
+	protectionUtils.dismisses_stale_reviews(input)
+}
+
+# METADATA
+# scope: rule
+# title: Branch Should Require Code Review
+# description: In order to comply with separation of duties principle and enforce secure code practices, a code review should be mandatory before merging to the branch using the source-code-management system's built-in enforcement.
+# custom:
+#   remediationSteps:
+#     - "Note: The remediation steps apply to legacy branch protections, rules set-based protection should be updated from the rules set page"
+#     - 1. Make sure you have admin permissions
+#     - 2. Go to the repo's settings page
+#     - 3. Enter 'Branches' tab
+#     - 4. Under 'Branch protection rules'
+#     - 5. Click 'Edit' on the rule that matches the branch
+#     - 6. Check 'Require a pull request before merging'
+#     - 7. Check 'Require approvals'
+#     - 8. Set 'Required number of approvals before merging' to 1 or more
+#     - 9. Click 'Save changes'
+#   severity: HIGH
+#   requiredScopes: [repo]
+#   prerequisites: [has_branch_protection_permission, not_archived]
+#   threat: Users can merge code without being reviewed, which can lead to insecure code reaching the released branches and production.
+default code_review_not_required := true
+
+code_review_not_required := false {
+	protectionUtils.requires_approvals(input, 1)
+}
+
+# METADATA
+# scope: rule
+# title: Branch Should Require Code Review By At Least Two Reviewers
+# description: In order to comply with separation of duties principle and enforce secure code practices, a code review by at least two reviewers should be mandatory before merging to the branch.
+# custom:
+#   remediationSteps:
+#     - "Note: The remediation steps apply to legacy branch protections, rules set-based protection should be updated from the rules set page"
+#     - 1. Make sure you have admin permissions
+#     - 2. Go to the repo's settings page
+#     - 3. Enter 'Branches' tab
+#     - 4. Under 'Branch protection rules'
+#     - 5. Click 'Edit' on the rule that matches the branch
+#     - 6. Check 'Require a pull request before merging'
+#     - 7. Check 'Require approvals'
+#     - 8. Set 'Required number of approvals before merging' to 2 or more
+#     - 9. Click 'Save changes'
+#   severity: MEDIUM
+#   requiredScopes: [repo]
+#   prerequisites: [has_branch_protection_permission, not_archived]
+#   threat:
+#     - Users can merge code without being reviewed, which can lead to insecure code reaching the released branches and production.
+#     - Requiring code review by at least two reviewers further decreases the risk of an insider threat (as merging code requires compromising at least 2 identities with write permissions), and decreases the likelihood of human error in the review process.
+default code_review_by_two_members_not_required := true
+
+code_review_by_two_members_not_required := false {
+	protectionUtils.requires_approvals(input, 2)
+}
+
+# METADATA
+# scope: rule
+# title: Branch Should Limit Code Review to Code-Owners
+# description: It is recommended to require code review only from designated individuals specified in CODEOWNERS file. Turning this option on enforces that only the allowed owners can approve a code change to the branch.
+# custom:
+#   remediationSteps:
+#     - "Note: The remediation steps apply to legacy branch protections, rules set-based protection should be updated from the rules set page"
+#     - 1. Make sure you have admin permissions
+#     - 2. Go to the repo's settings page
+#     - 3. Enter 'Branches' tab
+#     - 4. Under 'Branch protection rules'
+#     - 5. Click 'Edit' on the rule that matches the branch
+#     - 6. Check 'Require a pull request before merging'
+#     - 7. Check 'Require review from Code Owners'
+#     - 8. Click 'Save changes'
+#   severity: LOW
+#   requiredScopes: [repo]
+#   prerequisites: [has_branch_protection_permission, not_archived]
+#   threat: A pull request may be approved by any contributor with write access. Specifying specific code owners can ensure review is only done by individuals with the correct expertise required for the review of the changed files, potentially preventing bugs and security risks.
+default code_review_not_limited_to_code_owners := true
+
+code_review_not_limited_to_code_owners := false {
+	protectionUtils.requires_code_owner_reviews(input)
+}
+
+# METADATA
+# scope: rule
+# title: Branch Should Require Linear History
+# description: Prevent merge commits from being pushed to the branch.
+# custom:
+#    remediationSteps:
+#      - "Note: The remediation steps apply to legacy branch protections, rules set-based protection should be updated from the rules set page"
+#      - 1. Make sure you have admin permissions
+#      - 2. Go to the repo's settings page
+#      - 3. Enter 'Branches' tab
+#      - 4. Under 'Branch protection rules'
+#      - 5. Click 'Edit' on the rule that matches the branch
+#      - 6. Check 'Require linear history'
+#      - 7. Click 'Save changes'
+#    severity: MEDIUM
+#    requiredScopes: [repo]
+#    prerequisites: [has_branch_protection_permission, not_archived]
+#    threat: Having a non-linear history makes it harder to reverse changes, making recovery from bugs and security risks slower and more difficult.
+default non_linear_history := true
+
+non_linear_history := false {
+	protectionUtils.requires_linear_history(input)
+}
+
+# METADATA
+# scope: rule
+# title: Branch Should Require All Conversations To Be Resolved Before Merge
+# description: Require all Pull Request conversations to be resolved before merging to the branch. Check this to avoid bypassing/missing a Pull Request comment.
+# custom:
+#    remediationSteps:
+#      - "Note: The remediation steps apply to legacy branch protections, rules set-based protection should be updated from the rules set page"
+#      - 1. Make sure you have admin permissions
+#      - 2. Go to the repo's settings page
+#      - 3. Enter 'Branches' tab
+#      - 4. Under 'Branch protection rules'
+#      - 5. Click 'Edit' on the rule that matches the branch
+#      - 6. Check 'Require conversation resolution before merging'
+#      - 7. Click 'Save changes'
+#    severity: LOW
+#    requiredScopes: [repo]
+#    prerequisites: [has_branch_protection_permission, not_archived]
+#    threat: Allowing the merging of code without resolving all conversations can promote poor and vulnerable code, as important comments may be forgotten or deliberately ignored when the code is merged.
+default no_conversation_resolution := true
+
+no_conversation_resolution := false {
+	protectionUtils.requires_conversation_resolution(input)
+}
+
+# METADATA
+# scope: rule
+# title: Branch Should Require All Commits To Be Signed
+# description: Require all commits to the branch to be signed and verified
+# custom:
+#    remediationSteps:
+#      - "Note: The remediation steps apply to legacy branch protections, rules set-based protection should be updated from the rules set page"
+#      - 1. Make sure you have admin permissions
+#      - 2. Go to the repo's settings page
+#      - 3. Enter 'Branches' tab
+#      - 4. Under 'Branch protection rules'
+#      - 5. Click 'Edit' on the rule that matches the branch
+#      - 6. Check 'Require signed commits'
+#      - 7. Click 'Save changes'
+#    severity: LOW
+#    requiredScopes: [repo]
+#    prerequisites: [has_branch_protection_permission, not_archived]
+#    threat: A commit containing malicious code may be crafted by a malicious actor that has acquired write access to the repository to initiate a supply chain attack. Commit signing provides another layer of defense that can prevent this type of compromise.
+default no_signed_commits := true
+
+no_signed_commits := false {
+	protectionUtils.requires_signed_commits(input)
+}
+
+# METADATA
+# scope: rule
+# title: Branch Should Restrict Who Can Dismiss Reviews
+# description: Any user with write access to the repository can dismiss pull-request reviews of the branch. Dismissing a review might cause a loss of essential information and should be restricted to a limited number of users.
+# custom:
+#    remediationSteps:
+#      - "Note: The remediation steps apply to legacy branch protections, rules set-based protection should be updated from the rules set page"
+#      - 1. Make sure you have admin permissions
+#      - 2. Go to the repo's settings page
+#      - 3. Enter 'Branches' tab
+#      - 4. Under 'Branch protection rules'
+#      - 5. Click 'Edit' on the rule that matches the branch
+#      - 6. Check 'Restrict who can dismiss pull request reviews'
+#      - 7. Click 'Save changes'
+#    severity: LOW
+#    requiredScopes: [repo]
+#    prerequisites: [has_branch_protection_permission, not_archived]
+#    threat: Allowing the dismissal of reviews can promote poor and vulnerable code, as important comments may be forgotten and ignored during the review process.
+default review_dismissal_allowed := true
+
+review_dismissal_allowed := false {
+	input.branch.branch_protection_rule.restricts_review_dismissals
+}
+
+# METADATA
+# scope: rule
+# title: Branch Should Restrict Who Can Push To It
+# description: By default, commits can be pushed directly to protected branches without going through a Pull Request. Restrict who can push commits to the branch so that commits can be added only via merges, which require Pull Request.
+# custom:
+#    remediationSteps:
+#      - "Note: The remediation steps apply to legacy branch protections, rules set-based protection should be updated from the rules set page"
+#      - 1. Make sure you have admin permissions
+#      - 2. Go to the repo's settings page
+#      - 3. Enter 'Branches' tab
+#      - 4. Under 'Branch protection rules'
+#      - 5. Click 'Edit' on the rule that matches the branch
+#      - 6. Check 'Restrict who can push to matching branches'
+#      - 7. Choose who should be allowed to push
+#      - 8. Click 'Save changes'
+#    severity: LOW
+#    requiredScopes: [repo]
+#    prerequisites: [has_branch_protection_permission, not_archived]
+#    threat: An attacker with write credentials may introduce vulnerabilities to your code without your knowledge. Alternatively, contributors may commit unsafe code that is buggy or easy to exploit that could have been caught using a review process.
+default pushes_are_not_restricted := true
+
+pushes_are_not_restricted := false {
+	not code_review_not_required
+}
+
+pushes_are_not_restricted := false {
+	input.branch.branch_protection_rule.restricts_pushes
+}
+
+# METADATA
+# scope: rule
+# title: Users Are Allowed To Bypass The Branch Ruleset Rules
+# description: Rulesets rules that apply to the branch are not enforced for some users. When defining rulesets it is recommended to make sure that no one is allowed to bypass these rules in order to avoid inadvertent or intentional alterations to critical code which can lead to potential errors or vulnerabilities in the software.
+# custom:
+#   remediationSteps:
+#     - 1. Go to the repository settings page
+#     - 2. Under 'Code and automation', select 'Rules -> Rulesets'
+#     - 3. Find the relevant ruleset
+#     - 4. Empty the 'Bypass list'
+#     - 5. Press 'Save Changes'
+#   severity: MEDIUM
+#   requiredScopes: [repo]
+#   threat: Attackers that gain access to a user that can bypass the ruleset rules can compromise the codebase without anyone noticing, introducing malicious code that would go straight ahead to production.
+default users_allowed_to_bypass_ruleset := true
+
+users_allowed_to_bypass_ruleset := false {
+	protectionUtils.bypass_not_allowed(input)
+}
@@ -0,0 +1,135 @@
+package common.protections
+
+# The functions check the effective protection of a branch of the branch namespace:
+# its branch protection rule (null when the branch is not protected by one) and the rules of the rulesets that apply to it.
+
+is_protected(entity) {
+    not is_null(entity.branch.branch_protection_rule)
+}
+
+is_protected(entity) {
+    some index
+    rule := entity.rules_set[index]
+    rule.type == "pull_request"
+}
+
+prevents_deletion(entity) {
+    not is_null(entity.branch.branch_protection_rule)
+    not entity.branch.branch_protection_rule.allows_deletions
+}
+
+prevents_deletion(entity) {
+    some index
+    rule := entity.rules_set[index]
+    rule.type == "deletion"
+}
+
+prevents_force_push(entity) {
+    not is_null(entity.branch.branch_protection_rule)
+    not entity.branch.branch_protection_rule.allows_force_pushes
+}
+
+prevents_force_push(entity) {
+    some index
+    rule := entity.rules_set[index]
+    rule.type == "non_fast_forward"
+}
+
+requires_status_checks(entity) {
+    entity.branch.branch_protection_rule.requires_status_checks
+}
+
+requires_status_checks(entity) {
+    some index
+    rule := entity.rules_set[index]
+    rule.type == "required_status_checks"
+    count(rule.parameters.required_status_checks) > 0
+}
+
+requires_branches_up_to_date(entity) {
+    entity.branch.branch_protection_rule.requires_status_checks
+    entity.branch.branch_protection_rule.requires_strict_status_checks
+}
+
+requires_branches_up_to_date(entity) {
+    some index
+    rule := entity.rules_set[index]
+    rule.type == "required_status_checks"
+    count(rule.parameters.required_status_checks) > 0
+    rule.parameters.strict_required_status_checks_policy
+}
+
+dismisses_stale_reviews(entity) {
+    entity.branch.branch_protection_rule.dismisses_stale_reviews
+}
+
+dismisses_stale_reviews(entity) {
+    some index
+    rule := entity.rules_set[index]
+    rule.type == "pull_request"
+    rule.parameters.dismiss_stale_reviews_on_push
+}
+
+requires_approvals(entity, approvals) {
+    entity.branch.branch_protection_rule.required_approving_review_count >= approvals
+}
+
+requires_approvals(entity, approvals) {
+    some index
+    rule := entity.rules_set[index]
+    rule.type == "pull_request"
+    rule.parameters.required_approving_review_count >= approvals
+}
+
+requires_code_owner_reviews(entity) {
+    entity.branch.branch_protection_rule.requires_code_owner_reviews
+}
+
+requires_code_owner_reviews(entity) {
+    some index
+    rule := entity.rules_set[index]
+    rule.type == "pull_request"
+    rule.parameters.require_code_owner_review
+}
+
+requires_linear_history(entity) {
+    entity.branch.branch_protection_rule.requires_linear_history
+}
+
+requires_linear_history(entity) {
+    some index
+    rule := entity.rules_set[index]
+    rule.type == "required_linear_history"
+}
+
+requires_conversation_resolution(entity) {
+    entity.branch.branch_protection_rule.requires_conversation_resolution
+}
+
+requires_conversation_resolution(entity) {
+    some index
+    rule := entity.rules_set[index]
+    rule.type == "pull_request"
+    rule.parameters.required_review_thread_resolution
+}
+
+requires_signed_commits(entity) {
+    entity.branch.branch_protection_rule.requires_commit_signatures
+}
+
+requires_signed_commits(entity) {
+    some index
+    rule := entity.rules_set[index]
+    rule.type == "required_signatures"
+}
+
+# bypass_not_allowed holds when there are no rulesets, or when one of them cannot be bypassed
+bypass_not_allowed(entity) {
+    count(entity.rules_set) == 0
+}
+
+bypass_not_allowed(entity) {
+    some index
+    rule := entity.rules_set[index]
+    count(rule.ruleset.bypass_actors) == 0
+}
@@ -70,4 +70,180 @@ stale_admin_found[mem] := true {
 	mem.is_admin == true
 	mem.last_active != -1
 	memberUtils.isStale(mem.last_active, 6)
-}
\ No newline at end of file
+}
+
+# METADATA
+# scope: rule
+# title: Outside Collaborators Should Not Have Admin Or Maintain Permissions
+# description: Some outside collaborators have the admin or maintain role on repositories of the organization. Outside collaborators aren't members of the organization, so they aren't subject to its membership requirements and are less likely to be reviewed. Administrative access to repositories should be reserved for organization members.
+# custom:
+#   requiredEnrichers: [entityId, collaboratorsList]
+#   remediationSteps:
+#     - 1. Make sure you have admin permissions
+#     - 2. Go to the org's People page and choose 'Outside collaborators'
+#     - 3. Select the violating collaborator and press 'Manage access'
+#     - 4. Change their role on the repository to 'Write' or lower, or remove their access
+#   severity: HIGH
+#   requiredScopes: [admin:org]
+#   threat: An attacker who compromises the account of an outside collaborator can change the settings of the repository, disable its protection and push malicious code to it, while the account is not protected by the organization's policies such as SSO and two-factor authentication.
+outside_collaborator_with_admin_permissions[violated] := true {
+	some collaborator, repository
+	outsider := input.outside_collaborators[collaborator]
+	repo := outsider.repositories[repository]
+	is_admin_role(repo.permission)
+	violated := {
+		"login": outsider.user.login,
+		"repository": repo.name,
+		"permission": repo.permission,
+	}
+}
+
+is_admin_role(role) {
+	role == "admin"
+}
+
+is_admin_role(role) {
+	role == "maintain"
+}
+
+# METADATA
+# scope: rule
+# title: Outside Collaborators Should Have Activity In The Last 6 Months
+# description: An outside collaborator did not perform any action in the last 6 months, but still has access to repositories of the organization. Access that is not used should be removed.
+# custom:
+#   requiredEnrichers: [entityId, collaboratorsList]
+#   remediationSteps:
+#     - 1. Make sure you have admin permissions
+#     - 2. Go to the org's People page and choose 'Outside collaborators'
+#     - 3. Select the stale collaborators
+#     - 4. Press 'Remove from all repositories'
+#   severity: MEDIUM
+#   requiredScopes: [admin:org]
+#   prerequisites: [premium]
+#   threat: Stale outside collaborators are most likely not managed and monitored, increasing the possibility of being compromised.
+stale_outside_collaborator[violated] := true {
+	some collaborator
+	outsider := input.outside_collaborators[collaborator]
+	count(outsider.repositories) > 0
+	outsider.last_active != -1
+	memberUtils.isStale(outsider.last_active, 6)
+	violated := {
+		"login": outsider.user.login,
+		"repository": concat(", ", [repo.name | repo := outsider.repositories[_]]),
+		"last active": time.format(outsider.last_active),
+	}
+}
+
+# METADATA
+# scope: rule
+# title: Expired Invitations Should Be Removed
+# description: Some invitations to the organization or to its repositories were not accepted within 7 days and have expired. They should be cancelled, and resent if the access is still required.
+# custom:
+#   requiredEnrichers: [entityId, collaboratorsList]
+#   remediationSteps:
+#     - 1. Make sure you have admin permissions
+#     - 2. For organization invitations, go to the org's People page, choose 'Failed invitations' and cancel the expired invitations
+#     - 3. For repository invitations, go to the repository settings, choose 'Collaborators and teams' and cancel the expired invitations
+#   severity: LOW
+#   requiredScopes: [admin:org]
+#   threat: Expired invitations clutter the review of the pending access requests, and may be resent without checking whether the access is still required.
+expired_pending_invitation[violated] := true {
+	some index
+	invitation := input.pending_invitations[index]
+	invitation.expired
+	violated := {
+		"login": invitee(invitation),
+		"repository": object.get(invitation, "repository", ""),
+		"invitation date": time.format(invitation.created_at),
+	}
+}
+
+invitee(invitation) := invitation.login {
+	invitation.login != ""
+} else := object.get(invitation, "email", "")
+
+member_role(member) := "admin" {
+	member.is_admin
+} else := "member"
+
+# METADATA
+# scope: rule
+# title: Organization Members Should Have Two-Factor Authentication Enabled
+# description: Some members of the organization did not enable two-factor authentication, and the organization does not enforce it. Their accounts are protected by a password alone.
+# custom:
+#   requiredEnrichers: [entityId, collaboratorsList]
+#   remediationSteps:
+#     - 1. Ask the violating members to enable two-factor authentication in their account security settings
+#     - 2. Once all the members enabled it, go to the organization settings page
+#     - 3. Enter 'Authentication security' tab
+#     - 4. Toggle on 'Require two-factor authentication for everyone in the <ORG> organization'
+#   severity: HIGH
+#   requiredScopes: [admin:org]
+#   prerequisites: [two_factor_status_known]
+#   threat:
+#     - 1. An attacker obtains the password of a member through phishing or a leak from another service
+#     - 2. Without a second factor, the attacker can sign in as the member and access the repositories of the organization
+member_without_two_factor_authentication[violated] := true {
+	not input.organization.two_factor_requirement_enabled
+	some index
+	member := input.members[index]
+	member.two_factor_disabled == true
+	violated := {
+		"login": member.user.login,
+		"role": member_role(member),
+	}
+}
+
+# METADATA
+# scope: rule
+# title: Organization Members Should Have A Linked SSO Identity
+# description: The organization uses SAML single sign-on, but some members did not link an identity of the identity provider to their account. Their access is not managed by the identity provider, so it is not revoked when they leave the company and is not subject to the policies of the identity provider.
+# custom:
+#   requiredEnrichers: [entityId, collaboratorsList]
+#   remediationSteps:
+#     - 1. Make sure you have admin permissions
+#     - 2. Go to the organization settings page
+#     - 3. Enter 'Authentication security' tab
+#     - 4. Toggle on 'Require SAML SSO authentication for all members of the <ORG> organization', after the violating members authenticated through SSO
+#     - 5. Alternatively, remove the violating members from the organization
+#   severity: MEDIUM
+#   requiredScopes: [admin:org]
+#   prerequisites: [external_identities_known]
+#   threat: A member without a linked identity keeps accessing the organization after their account in the identity provider is disabled, for example when they leave the company.
+member_without_linked_sso_identity[violated] := true {
+	input.has_saml_sso == true
+	some index
+	member := input.members[index]
+	is_null(member.external_identity)
+	violated := {
+		"login": member.user.login,
+		"role": member_role(member),
+	}
+}
+
+# METADATA
+# scope: rule
+# title: Users Deprovisioned By SCIM Should Not Remain Organization Members
+# description: Some members of the organization were deprovisioned by the identity provider through SCIM, but are still members of the organization. Deprovisioned users are expected to lose their access to the organization.
+# custom:
+#   requiredEnrichers: [entityId, collaboratorsList]
+#   remediationSteps:
+#     - 1. Make sure you have admin permissions
+#     - 2. Go to the org's People page
+#     - 3. Select the violating members
+#     - 4. Using the 'X members selected' - remove members from organization
+#     - 5. Check the SCIM integration of the identity provider, to find out why the members were not removed
+#   severity: HIGH
+#   requiredScopes: [admin:org]
+#   prerequisites: [external_identities_known]
+#   threat: A user that left the company, and was deprovisioned by the identity provider, can still access the repositories of the organization.
+deprovisioned_member_still_present[violated] := true {
+	some index
+	member := input.members[index]
+	member.external_identity.scim_active == false
+	violated := {
+		"login": member.user.login,
+		"role": member_role(member),
+		"scim username": member.external_identity.scim_username,
+	}
+}
@@ -10,6 +10,7 @@ import data.common.secrets as secretUtils
 # description: A project which is not actively maintained may not be patched against security issues within its code and dependencies, and is therefore at higher risk of including known vulnerabilities.
 # custom:
 #   severity: HIGH
+#   prerequisites: [not_archived]
 #   remediationSteps:
 #     - 1. Make sure you have admin permissions
 #     - 2. Either Delete or Archive the repository
@@ -152,7 +153,7 @@ forking_allowed_for_repository := false {
 #     - 8. Click 'Create' and save the rule
 #   severity: MEDIUM
 #   requiredScopes: [repo]
-#   prerequisites: [has_branch_protection_permission]
+#   prerequisites: [has_branch_protection_permission, not_archived]
 #   threat: Any contributor with write access may push potentially dangerous code to this repository, making it easier to compromise and difficult to audit.
 default missing_default_branch_protection := true
 
@@ -181,7 +182,7 @@ missing_default_branch_protection := false {
 #     - 6. Uncheck 'Allow deletions', Click 'Save changes'
 #   severity: MEDIUM
 #   requiredScopes: [repo]
-#   prerequisites: [has_branch_protection_permission]
+#   prerequisites: [has_branch_protection_permission, not_archived]
 #   threat: Rewriting project history can make it difficult to trace back when bugs or security issues were introduced, making them more difficult to remediate.
 default missing_default_branch_protection_deletion := true
 
@@ -211,7 +212,7 @@ missing_default_branch_protection_deletion := false {
 #     - 7. Click 'Save changes'
 #   severity: MEDIUM
 #   requiredScopes: [repo]
-#   prerequisites: [has_branch_protection_permission]
+#   prerequisites: [has_branch_protection_permission, not_archived]
 #   threat: Rewriting project history can make it difficult to trace back when bugs or security issues were introduced, making them more difficult to remediate.
 default missing_default_branch_protection_force_push := true
 
@@ -242,7 +243,7 @@ missing_default_branch_protection_force_push := false {
 #     - 8. Click 'Save changes'
 #   severity: MEDIUM
 #   requiredScopes: [repo]
-#   prerequisites: [has_branch_protection_permission]
+#   prerequisites: [has_branch_protection_permission, not_archived]
 #   threat: Not defining a set of required status checks can make it easy for contributors to introduce buggy or insecure code as manual review, whether mandated or optional, is the only line of defense.
 default requires_status_checks := true
 
@@ -274,7 +275,7 @@ requires_status_checks := false {
 #     - 8. Click 'Save changes'
 #   severity: MEDIUM
 #   requiredScopes: [repo]
-#   prerequisites: [has_branch_protection_permission]
+#   prerequisites: [has_branch_protection_permission, not_archived]
 #   threat: Required status checks may be failing on the latest version after passing on an earlier version of the code, making it easy to commit buggy or otherwise insecure code.
 default requires_branches_up_to_date_before_merge := true
 
@@ -308,7 +309,7 @@ requires_branches_up_to_date_before_merge := false {
 #     - 8. Click 'Save changes'
 #   severity: LOW
 #   requiredScopes: [repo]
-#   prerequisites: [has_branch_protection_permission]
+#   prerequisites: [has_branch_protection_permission, not_archived]
 #   threat: Buggy or insecure code may be committed after approval and will reach the main branch without review. Alternatively, an attacker can attempt a just-in-time attack to introduce dangerous code just before merge.
 default dismisses_stale_reviews := true
 
@@ -341,7 +342,7 @@ dismisses_stale_reviews := false {
 #     - 9. Click 'Save changes'
 #   severity: HIGH
 #   requiredScopes: [repo]
-#   prerequisites: [has_branch_protection_permission]
+#   prerequisites: [has_branch_protection_permission, not_archived]
 #   threat: Users can merge code without being reviewed, which can lead to insecure code reaching the main branch and production.
 default code_review_not_required := true
 
@@ -375,7 +376,7 @@ code_review_not_required := false {
 #     - 9. Click 'Save changes'
 #   severity: MEDIUM
 #   requiredScopes: [repo]
-#   prerequisites: [has_branch_protection_permission]
+#   prerequisites: [has_branch_protection_permission, not_archived]
 #   threat:
 #     - Users can merge code without being reviewed, which can lead to insecure code reaching the main branch and production.
 #     - Requiring code review by at least two reviewers further decreases the risk of an insider threat (as merging code requires compromising at least 2 identities with write permissions), and decreases the likelihood of human error in the review process.
@@ -409,7 +410,7 @@ code_review_by_two_members_not_required := false {
 #     - 8. Click 'Save changes'
 #   severity: LOW
 #   requiredScopes: [repo]
-#   prerequisites: [has_branch_protection_permission]
+#   prerequisites: [has_branch_protection_permission, not_archived]
 #   threat: A pull request may be approved by any contributor with write access. Specifying specific code owners can ensure review is only done by individuals with the correct expertise required for the review of the changed files, potentially preventing bugs and security risks.
 default code_review_not_limited_to_code_owners := true
 
@@ -440,7 +441,7 @@ code_review_not_limited_to_code_owners := false {
 #      - 7. Click 'Save changes'
 #    severity: MEDIUM
 #    requiredScopes: [repo]
-#    prerequisites: [has_branch_protection_permission]
+#    prerequisites: [has_branch_protection_permission, not_archived]
 #    threat: Having a non-linear history makes it harder to reverse changes, making recovery from bugs and security risks slower and more difficult.
 default non_linear_history := true
 
@@ -470,7 +471,7 @@ non_linear_history := false {
 #      - 7. Click 'Save changes'
 #    severity: LOW
 #    requiredScopes: [repo]
-#    prerequisites: [has_branch_protection_permission]
+#    prerequisites: [has_branch_protection_permission, not_archived]
 #    threat: Allowing the merging of code without resolving all conversations can promote poor and vulnerable code, as important comments may be forgotten or deliberately ignored when the code is merged.
 default no_conversation_resolution := true
 
@@ -501,7 +502,7 @@ no_conversation_resolution := false {
 #      - 7. Click 'Save changes'
 #    severity: LOW
 #    requiredScopes: [repo]
-#    prerequisites: [has_branch_protection_permission]
+#    prerequisites: [has_branch_protection_permission, not_archived]
 #    threat: A commit containing malicious code may be crafted by a malicious actor that has acquired write access to the repository to initiate a supply chain attack. Commit signing provides another layer of defense that can prevent this type of compromise.
 default no_signed_commits := true
 
@@ -531,7 +532,7 @@ no_signed_commits := false {
 #      - 7. Click 'Save changes'
 #    severity: LOW
 #    requiredScopes: [repo]
-#    prerequisites: [has_branch_protection_permission]
+#    prerequisites: [has_branch_protection_permission, not_archived]
 #    threat: Allowing the dismissal of reviews can promote poor and vulnerable code, as important comments may be forgotten and ignored during the review process.
 default review_dismissal_allowed := true
 
@@ -556,7 +557,7 @@ review_dismissal_allowed := false {
 #      - 8. Click 'Save changes'
 #    severity: LOW
 #    requiredScopes: [repo]
-#    prerequisites: [has_branch_protection_permission]
+#    prerequisites: [has_branch_protection_permission, not_archived]
 #    threat: An attacker with write credentials may introduce vulnerabilities to your code without your knowledge. Alternatively, contributors may commit unsafe code that is buggy or easy to exploit that could have been caught using a review process.
 default pushes_are_not_restricted := true
 
@@ -579,6 +580,7 @@ pushes_are_not_restricted := false {
 #     - 3. Enter 'Code security and analysis' tab
 #     - 4. Set 'Dependabot alerts' as Enabled
 #   severity: MEDIUM
+#   prerequisites: [not_archived]
 #   requiredScopes: [repo]
 #   threat: An open source vulnerability may be affecting your code without your knowledge, making it vulnerable to exploitation.
 default vulnerability_alerts_not_enabled := true
@@ -599,6 +601,7 @@ vulnerability_alerts_not_enabled := false {
 #      - 3. Enter 'Code security and analysis' tab
 #      - 4. Set 'Dependency graph' as Enabled
 #    severity: MEDIUM
+#    prerequisites: [not_archived]
 #    requiredScopes: [repo]
 #    threat: A contributor may add vulnerable third-party dependencies to the repository, introducing vulnerabilities to your application that will only be detected after merge.
 default ghas_dependency_review_not_enabled := true
@@ -620,7 +623,7 @@ ghas_dependency_review_not_enabled := false {
 #      - 4. Fix the failed checks
 #    severity: MEDIUM
 #    requiredScopes: [repo, read:repo_hook]
-#    prerequisites: [scorecard_enabled]
+#    prerequisites: [scorecard_enabled, not_archived]
 #    threat: A low Scorecard score can indicate that the repository is more vulnerable to attack than others, making it a prime attack target.
 default scorecard_score_too_low := true
 
@@ -642,6 +645,7 @@ scorecard_score_too_low := false {
 #     - 5. Select 'Read repository contents permission'
 #     - 6. Click 'Save'
 #   severity: MEDIUM
+#   prerequisites: [not_archived]
 #   requiredScopes: [admin:org]
 #   threat: In case of token compromise (due to a vulnerability or malicious third-party GitHub actions), an attacker can use this token to sabotage various assets in your CI/CD pipeline, such as packages, pull-requests, deployments, and more.
 default token_default_permissions_is_read_write := true
@@ -664,6 +668,7 @@ token_default_permissions_is_read_write := false {
 #     - 5. Uncheck 'Allow GitHub actions to create and approve pull requests.'
 #     - 6. Click 'Save'
 #   severity: HIGH
+#   prerequisites: [not_archived]
 #   requiredScopes: [admin:org]
 #   threat: Attackers can exploit this misconfiguration to bypass code-review restrictions by creating a workflow that approves their own pull request and then merging the pull request without anyone noticing, introducing malicious code that would go straight ahead to production.
 default actions_can_approve_pull_requests := true
@@ -684,6 +689,7 @@ actions_can_approve_pull_requests := false{
 #     - 4. Empty the 'Bypass list'
 #     - 5. Press 'Save Changes'
 #   severity: MEDIUM
+#   prerequisites: [not_archived]
 #   requiredScopes: [repo]
 #   threat: Attackers that gain access to a user that can bypass the ruleset rules can compromise the codebase without anyone noticing, introducing malicious code that would go straight ahead to production.
 default users_allowed_to_bypass_ruleset := true
@@ -743,3 +749,430 @@ default secret_scanning_not_enabled := true
 secret_scanning_not_enabled := false{
     input.security_and_analysis.secret_scanning.status == "enabled"
 }
+
+# METADATA
+# scope: rule
+# title: Workflows Triggered By pull_request_target Should Not Check Out The Pull Request Code
+# description: The pull_request_target trigger runs a workflow in the context of the base repository, with access to its secrets and a token with write permissions. A workflow that checks out the code of the pull request and builds or tests it lets anyone who opens a pull request from a fork run arbitrary code with these privileges.
+# custom:
+#   requiredEnrichers: [workflowsList]
+#   severity: CRITICAL
+#   prerequisites: [not_archived]
+#   remediationSteps:
+#     - 1. Open the workflow file in the repository
+#     - 2. Use the 'pull_request' trigger to build and test the code of pull requests, which runs without access to secrets
+#     - 3. If privileged access is required, move the privileged steps to a separate workflow triggered by 'workflow_run', and never run the pull request code in it
+#   requiredScopes: [repo]
+#   threat: An attacker can open a pull request from a fork with malicious code that runs with access to the repository secrets and a write token ("pwn request"), allowing them to push code, tamper with releases and steal secrets.
+workflow_pull_request_target_checks_out_pr_code[violated] := true {
+    some i, j, k
+    workflow := input.workflows[i]
+    workflow.triggers[_] == "pull_request_target"
+    job := workflow.jobs[j]
+    step := job.steps[k]
+    startswith(step.uses, "actions/checkout@")
+    is_pull_request_ref(step["with"].ref)
+    violated := {
+        "workflow": workflow.path,
+        "job": job.id,
+        "step": step_name(step),
+    }
+}
+
+is_pull_request_ref(ref) {
+    contains(ref, "github.event.pull_request.head")
+}
+
+is_pull_request_ref(ref) {
+    contains(ref, "github.head_ref")
+}
+
+is_pull_request_ref(ref) {
+    contains(ref, "refs/pull/")
+}
+
+step_name(step) := name {
+    name := object.get(step, "name", object.get(step, "id", object.get(step, "uses", "run")))
+}
+
+# untrusted_input_expressions returns the expressions of the script with inputs that can be set by anyone who opens an issue, a pull request or a comment
+untrusted_input_expressions(script) := regex.find_n(`\$\{\{\s*(github\.head_ref|github\.event\.(issue\.(title|body)|pull_request\.(title|body|head\.(ref|label|repo\.default_branch))|comment\.body|review\.body|review_comment\.body|discussion\.(title|body)|pages\.[^}]*\.page_name|head_commit\.(message|author\.(email|name))|commits\.[^}]*\.(message|author\.(email|name))|workflow_run\.(head_branch|head_commit\.(message|author\.(email|name)))))[^}]*\}\}`, script, 1)
+
+step_scripts(step) := scripts {
+    run := {script | script := step.run}
+    github_script := {script | startswith(step.uses, "actions/github-script@"); script := step["with"].script}
+    scripts := run | github_script
+}
+
+# METADATA
+# scope: rule
+# title: Workflows Should Not Use Untrusted Inputs In Scripts
+# description: Expressions such as '${{ github.event.issue.title }}' are evaluated before the script runs, so their content becomes part of the script. Issue titles, pull request descriptions, comments, branch names and commit messages are controlled by their authors, which allows them to inject commands into the workflow.
+# custom:
+#   requiredEnrichers: [workflowsList]
+#   severity: HIGH
+#   prerequisites: [not_archived]
+#   remediationSteps:
+#     - 1. Open the workflow file in the repository
+#     - 2. Pass the untrusted input to the script using an environment variable of the step (e.g. set TITLE to '${{ github.event.issue.title }}' under 'env')
+#     - 3. Use the environment variable in the script, quoted (e.g. "$TITLE")
+#   requiredScopes: [repo]
+#   threat: An attacker can open an issue, a pull request or a comment with crafted content that runs arbitrary commands in the workflow, with access to its secrets and token.
+workflow_script_injection[violated] := true {
+    some i, j, k
+    workflow := input.workflows[i]
+    job := workflow.jobs[j]
+    step := job.steps[k]
+    script := step_scripts(step)[_]
+    expressions := untrusted_input_expressions(script)
+    count(expressions) > 0
+    violated := {
+        "workflow": workflow.path,
+        "job": job.id,
+        "step": step_name(step),
+        "expression": expressions[0],
+    }
+}
+
+workflow_uses(job) := uses {
+    steps := {step.uses | step := job.steps[_]}
+    reusable := {job.uses | job.uses}
+    uses := steps | reusable
+}
+
+is_pinned(uses) {
+    startswith(uses, "./")
+}
+
+is_pinned(uses) {
+    startswith(uses, "docker://")
+    contains(uses, "@sha256:")
+}
+
+is_pinned(uses) {
+    regex.match(`@[0-9a-f]{40}$`, uses)
+}
+
+# METADATA
+# scope: rule
+# title: Workflows Should Use Actions Pinned To A Full Commit SHA
+# description: Actions and reusable workflows referenced by a tag or a branch (e.g. 'actions/checkout@v3') can be changed by their owners at any time. Pinning them to a full commit SHA makes sure the workflow runs the code that was reviewed.
+# custom:
+#   requiredEnrichers: [workflowsList]
+#   severity: MEDIUM
+#   prerequisites: [not_archived]
+#   remediationSteps:
+#     - 1. Open the workflow file in the repository
+#     - 2. Replace the tag or branch of each action with the full SHA of the commit it points to (e.g. 'actions/checkout@<sha> # v3')
+#     - 3. Use Dependabot or a similar tool to keep the pinned versions up to date
+#   requiredScopes: [repo]
+#   threat: If the repository of an action is compromised, the attacker can move its tags to malicious code that runs in your workflows, with access to their secrets and token.
+workflow_action_not_pinned_to_sha[violated] := true {
+    some i, j
+    workflow := input.workflows[i]
+    job := workflow.jobs[j]
+    uses := workflow_uses(job)[_]
+    not is_pinned(uses)
+    violated := {
+        "workflow": workflow.path,
+        "job": job.id,
+        "action": uses,
+    }
+}
+
+# METADATA
+# scope: rule
+# title: Workflows Should Declare Top-Level Permissions
+# description: A workflow without a top-level 'permissions' key gets the default permissions of the workflow token, which may allow writing to the repository. Declaring the permissions explicitly limits the token to what the workflow needs.
+# custom:
+#   requiredEnrichers: [workflowsList]
+#   severity: MEDIUM
+#   prerequisites: [not_archived]
+#   remediationSteps:
+#     - 1. Open the workflow file in the repository
+#     - 2. Add a top-level 'permissions' key with the minimal permissions required (e.g. read access to 'contents')
+#     - 3. Grant additional permissions only to the jobs that require them, using the job-level 'permissions' key
+#   requiredScopes: [repo]
+#   threat: A compromised step (e.g. a malicious dependency or action) can use an over-privileged token to push code, modify releases or approve pull requests.
+workflow_missing_top_level_permissions[violated] := true {
+    some i
+    workflow := input.workflows[i]
+    is_null(workflow.permissions)
+    violated := {
+        "workflow": workflow.path,
+    }
+}
+
+# METADATA
+# scope: rule
+# title: Deploy Keys With Write Access Should Be Rotated At Least Yearly
+# description: Some of the repository deploy keys have write access and were added over a year ago. Deploy keys are not bound to a user, so a key that leaks lets anyone push code to the repository until it is removed.
+# custom:
+#   requiredEnrichers: [deployKeysList]
+#   remediationSteps:
+#      - 1. Enter your repository's landing page
+#      - 2. Go to the settings tab
+#      - 3. Under the 'Security' title on the left, choose 'Deploy keys'
+#      - 4. Remove every key with write access that is older than one year, or that is no longer used
+#      - 5. Generate a new key and add it, granting write access only if it is required
+#   severity: HIGH
+#   requiredScopes: [repo]
+#   threat: An attacker who obtains an old deploy key, e.g. from a decommissioned server or a leaked backup, can push malicious code to the repository without being tied to any user.
+stale_write_deploy_key[violated] := true {
+    some index
+    key := input.deploy_keys[index]
+    not key.read_only
+    secretUtils.is_stale(key.created_at)
+    violated := {
+        "title": key.title,
+        "creation date": time.format(key.created_at),
+    }
+}
+
+# is_unused_deploy_key holds if the deploy key wasn't used in the last days (or since it was created, if it was never used)
+is_unused_deploy_key(key, days) {
+    key.last_used == 0
+    time.now_ns() - key.created_at > days * 24 * 60 * 60 * 1000000000
+}
+
+is_unused_deploy_key(key, days) {
+    key.last_used != 0
+    time.now_ns() - key.last_used > days * 24 * 60 * 60 * 1000000000
+}
+
+deploy_key_last_used(key) := "never" {
+    key.last_used == 0
+} else := time.format(key.last_used)
+
+# METADATA
+# scope: rule
+# title: Unused Deploy Keys With Write Access Should Be Removed
+# description: Some of the repository deploy keys have write access and were not used in the last 90 days. A deploy key that is no longer used still lets anyone who has it push code to the repository.
+# custom:
+#   requiredEnrichers: [deployKeysList]
+#   remediationSteps:
+#      - 1. Enter your repository's landing page
+#      - 2. Go to the settings tab
+#      - 3. Under the 'Security' title on the left, choose 'Deploy keys'
+#      - 4. Remove every key with write access that was not used in the last 90 days
+#   severity: MEDIUM
+#   requiredScopes: [repo]
+#   threat: An attacker who obtains a forgotten deploy key, e.g. from a decommissioned server, can push malicious code to the repository without being tied to any user, and its use is unlikely to be noticed.
+unused_write_deploy_key[violated] := true {
+    some index
+    key := input.deploy_keys[index]
+    not key.read_only
+    is_unused_deploy_key(key, 90)
+    violated := {
+        "title": key.title,
+        "last used": deploy_key_last_used(key),
+    }
+}
+
+# METADATA
+# scope: rule
+# title: Production Environments Should Require Reviewers
+# description: Deployments to production environments should be approved by required reviewers, so that a workflow can't access the environment and its secrets before someone reviews it.
+# custom:
+#   requiredEnrichers: [environmentsList]
+#   remediationSteps:
+#      - 1. Enter your repository's landing page
+#      - 2. Go to the settings tab
+#      - 3. Choose 'Environments' and select the production environment
+#      - 4. Check 'Required reviewers' and add the users or teams who approve deployments
+#      - 5. Click 'Save protection rules'
+#   severity: HIGH
+#   prerequisites: [not_archived]
+#   requiredScopes: [repo]
+#   threat: Any user who can run a workflow can deploy to production and read the production secrets without approval.
+production_environment_without_reviewers[violated] := true {
+    some index
+    environment := input.environments[index]
+    is_production_environment(environment.name)
+    count(environment.reviewers) == 0
+    violated := {
+        "environment": environment.name,
+    }
+}
+
+# METADATA
+# scope: rule
+# title: Production Environments Should Restrict Deployment Branches
+# description: Production environments should only be deployed from protected branches or from selected branches, so that a workflow that runs on any branch can't access the environment and its secrets.
+# custom:
+#   requiredEnrichers: [environmentsList]
+#   remediationSteps:
+#      - 1. Enter your repository's landing page
+#      - 2. Go to the settings tab
+#      - 3. Choose 'Environments' and select the production environment
+#      - 4. Under 'Deployment branches', choose 'Protected branches' or 'Selected branches'
+#   severity: HIGH
+#   prerequisites: [not_archived]
+#   requiredScopes: [repo]
+#   threat: A user who can push a branch can add a workflow to it that deploys unreviewed code to production or exfiltrates the production secrets.
+production_environment_without_branch_policy[violated] := true {
+    some index
+    environment := input.environments[index]
+    is_production_environment(environment.name)
+    is_null(environment.deployment_branch_policy)
+    violated := {
+        "environment": environment.name,
+    }
+}
+
+is_production_environment(name) {
+    regex.match(`(?i)(^|[-_/ ])(prod|production|live)($|[-_/ ])`, name)
+}
+
+# METADATA
+# scope: rule
+# title: Critical Dependabot Alerts Should Be Remediated Within 30 Days
+# description: The repository has critical Dependabot alerts that have been open for more than 30 days. Enabling Dependabot only helps if its alerts are acted upon; a critical vulnerability in a dependency is often easily exploitable and publicly known.
+# custom:
+#   remediationSteps:
+#      - 1. Enter your repository's landing page
+#      - 2. Go to the security tab
+#      - 3. Choose 'Dependabot' and filter the alerts by 'Severity: Critical'
+#      - 4. Upgrade the vulnerable dependencies, or dismiss the alerts that don't affect the repository
+#   severity: HIGH
+#   prerequisites: [not_archived]
+#   requiredScopes: [security_events]
+#   threat: An attacker can exploit a known critical vulnerability in one of the repository dependencies, for which a fix is usually already available.
+default dependabot_critical_alerts_not_remediated := false
+
+dependabot_critical_alerts_not_remediated {
+    open_for_more_than_days(input.security_alerts.dependabot.critical, 30)
+}
+
+# METADATA
+# scope: rule
+# title: Critical Code Scanning Alerts Should Be Remediated Within 30 Days
+# description: The repository has critical code scanning alerts that have been open for more than 30 days. Enabling code scanning only helps if its alerts are acted upon.
+# custom:
+#   remediationSteps:
+#      - 1. Enter your repository's landing page
+#      - 2. Go to the security tab
+#      - 3. Choose 'Code scanning' and filter the alerts by 'Severity: Critical'
+#      - 4. Fix the vulnerable code, or dismiss the alerts that are false positives
+#   severity: HIGH
+#   requiredScopes: [security_events]
+#   prerequisites: [advanced_security, not_archived]
+#   threat: An attacker can exploit a critical vulnerability in the repository code that was already detected but not fixed.
+default code_scanning_critical_alerts_not_remediated := false
+
+code_scanning_critical_alerts_not_remediated {
+    open_for_more_than_days(input.security_alerts.code_scanning.critical, 30)
+}
+
+# METADATA
+# scope: rule
+# title: Secret Scanning Alerts Should Be Resolved Within 7 Days
+# description: The repository has secret scanning alerts that have been open for more than 7 days. A secret that was committed to the repository should be considered leaked and revoked as soon as possible.
+# custom:
+#   remediationSteps:
+#      - 1. Enter your repository's landing page
+#      - 2. Go to the security tab
+#      - 3. Choose 'Secret scanning'
+#      - 4. Revoke and rotate every exposed secret, then close the alert as 'Revoked'
+#   severity: HIGH
+#   requiredScopes: [security_events]
+#   prerequisites: [advanced_security]
+#   threat: Anyone with read access to the repository, or to one of its forks or clones, can use the exposed secret to access the service it belongs to.
+default secret_scanning_alerts_not_resolved := false
+
+secret_scanning_alerts_not_resolved {
+    open_for_more_than_days(input.security_alerts.secret_scanning, 7)
+}
+
+open_for_more_than_days(alerts, days) {
+    alerts.count > 0
+    time.now_ns() - alerts.oldest_created_at > days * 24 * 60 * 60 * 1000000000
+}
+
+# METADATA
+# scope: rule
+# title: Repository Requiring Code Owner Review Should Have A CODEOWNERS File
+# description: The default branch requires a review from code owners, but the repository has no CODEOWNERS file in its root, '.github/' or 'docs/' directory. Without the file no change has code owners, so the requirement has no effect.
+# custom:
+#   remediationSteps:
+#      - 1. Add a CODEOWNERS file to the '.github/' directory of the default branch
+#      - 2. Assign an owner to every path in the repository, e.g. using a '*' pattern
+#   severity: MEDIUM
+#   requiredScopes: [repo]
+#   prerequisites: [has_branch_protection_permission, not_archived]
+#   threat: Any contributor with write access can approve a change, although the repository is expected to be reviewed by designated owners.
+default missing_codeowners_file := false
+
+missing_codeowners_file {
+    not code_review_not_limited_to_code_owners
+    input.codeowners.path == ""
+}
+
+# METADATA
+# scope: rule
+# title: CODEOWNERS File Should Not Have Errors
+# description: The CODEOWNERS file has lines that GitHub can't parse, e.g. unsupported patterns or invalid owners. GitHub ignores these lines, so the paths they refer to may have no owners, or different owners than intended.
+# custom:
+#   requiredEnrichers: [codeownersList]
+#   remediationSteps:
+#      - 1. Open the CODEOWNERS file in the repository; GitHub highlights the invalid lines
+#      - 2. Fix or remove every invalid line
+#   severity: MEDIUM
+#   prerequisites: [not_archived]
+#   requiredScopes: [repo]
+#   threat: Changes to paths with an invalid CODEOWNERS line can be approved by anyone with write access, without the review of their intended owners.
+codeowners_file_has_errors[violated] := true {
+    some index
+    codeowners_error := input.codeowners.errors[index]
+    codeowners_error.kind != "Unknown owner"
+    violated := {
+        "line": format_int(codeowners_error.line, 10),
+        "error": codeowners_error.message,
+    }
+}
+
+# METADATA
+# scope: rule
+# title: Code Owners Should Exist And Have Write Access
+# description: Some of the users or teams in the CODEOWNERS file no longer exist, or don't have write access to the repository. GitHub ignores such owners, so their paths may be left without anyone who can review them as a code owner.
+# custom:
+#   requiredEnrichers: [codeownersList]
+#   remediationSteps:
+#      - 1. Open the CODEOWNERS file in the repository
+#      - 2. Replace every owner that no longer exists with a current user or team
+#      - 3. Grant write access to the repository to the remaining owners, or replace them
+#   severity: MEDIUM
+#   prerequisites: [not_archived]
+#   requiredScopes: [read:org,repo]
+#   threat: Changes to paths whose owners are invalid can be approved by anyone with write access, without the review of their intended owners.
+codeowners_owner_cannot_review[violated] := true {
+    some index
+    owner := input.codeowners.owners[index]
+    {"not_found", "no_write_access"}[owner.status]
+    violated := {
+        "owner": owner.name,
+        "status": owner.status,
+    }
+}
+
+# METADATA
+# scope: rule
+# title: Sensitive Paths Should Be Covered By CODEOWNERS
+# description: The CODEOWNERS file doesn't assign owners to paths that control the CI/CD of the repository, such as '.github/workflows/', or to the CODEOWNERS file itself.
+# custom:
+#   requiredEnrichers: [codeownersList]
+#   remediationSteps:
+#      - 1. Open the CODEOWNERS file in the repository
+#      - 2. Add a line for every uncovered path, e.g. '/.github/workflows/ @org/devops'
+#   severity: MEDIUM
+#   prerequisites: [not_archived]
+#   requiredScopes: [repo]
+#   threat: A contributor can change the workflows of the repository, or remove themselves from the CODEOWNERS file, with the approval of anyone with write access, and gain access to the repository secrets and deployments.
+sensitive_paths_without_codeowners[violated] := true {
+    input.codeowners.path != ""
+    some index
+    path := input.codeowners.uncovered_paths[index]
+    violated := {
+        "path": path,
+    }
+}
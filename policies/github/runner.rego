@@ -0,0 +1,73 @@
+package runner
+
+# usable_by_public_repository holds if the runner is registered to a public repository, or its group allows public repositories
+usable_by_public_repository(entity) {
+    is_object(entity.repository)
+    entity.repository.private == false
+}
+
+usable_by_public_repository(entity) {
+    entity.runner_group.allows_public_repositories == true
+    entity.runner_group.visibility == "all"
+}
+
+usable_by_public_repository(entity) {
+    entity.runner_group.allows_public_repositories == true
+    some index
+    entity.allowed_repositories[index].private == false
+}
+
+# METADATA
+# scope: rule
+# title: Self-Hosted Runners Should Not Be Used By Public Repositories
+# description: The self-hosted runner is registered to a public repository, or belongs to a runner group that allows public repositories. Anyone can open a pull request from a fork of a public repository, and depending on the repository settings its workflows run on the runner.
+# custom:
+#   severity: HIGH
+#   requiredEnrichers: [organizationId]
+#   requiredScopes: [admin:org]
+#   remediationSteps:
+#     - 1. For an organization runner, go to the organization settings page, press Actions ➝ Runner groups, select the group of the runner and uncheck 'Allow public repositories'
+#     - 2. For a repository runner, remove the runner from the repository and use GitHub-hosted runners for public repositories
+#   threat: An attacker can fork the public repository and open a pull request with a workflow that runs arbitrary code on the runner, inside the network of the organization.
+default self_hosted_runner_used_by_public_repositories := false
+
+self_hosted_runner_used_by_public_repositories := true {
+    usable_by_public_repository(input)
+}
+
+# METADATA
+# scope: rule
+# title: Self-Hosted Runners Reachable By Fork Pull Requests Should Be Ephemeral
+# description: The self-hosted runner can be used by public repositories, which run workflows of pull requests from forks, and it is not ephemeral. A non-ephemeral runner runs many jobs on the same machine, so a job can leave behind processes or files that affect the jobs that run after it.
+# custom:
+#   severity: CRITICAL
+#   requiredEnrichers: [organizationId]
+#   requiredScopes: [admin:org]
+#   remediationSteps:
+#     - 1. Register the runner with the '--ephemeral' flag, so that it runs a single job and is removed afterwards
+#     - 2. Alternatively, prevent public repositories from using the runner
+#   threat: An attacker can open a pull request from a fork that plants a backdoor on the runner, and steal the secrets and tokens of the privileged jobs that run on it later, e.g. release workflows.
+default non_ephemeral_runner_reachable_by_fork_pull_requests := false
+
+non_ephemeral_runner_reachable_by_fork_pull_requests := true {
+    usable_by_public_repository(input)
+    not input.runner.ephemeral
+}
+
+# METADATA
+# scope: rule
+# title: Self-Hosted Runners Should Be Registered To The Organization
+# description: The self-hosted runner is registered to a repository, so it isn't assigned to a runner group and the controls of the runner groups, such as the repositories and workflows that can use it, don't apply to it. Repository administrators can register runners without the approval of the organization.
+# custom:
+#   severity: MEDIUM
+#   requiredEnrichers: [organizationId]
+#   requiredScopes: [repo]
+#   remediationSteps:
+#     - 1. Remove the runner from the repository settings, under Actions ➝ Runners
+#     - 2. Register it to the organization instead, and assign it to a runner group that is limited to the repositories that need it
+#   threat: Runners that are not managed by the organization are less likely to be hardened and monitored, and may expose the machine they run on to every workflow of the repository.
+default repository_runner_bypasses_runner_groups := false
+
+repository_runner_bypasses_runner_groups := true {
+    is_object(input.repository)
+}
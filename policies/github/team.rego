@@ -0,0 +1,65 @@
+package team
+
+# admin_repositories returns the repositories that are not archived on which the team has the admin permission
+admin_repositories(team) := {repository | repository := team.repositories[_]; repository.permission == "admin"; not repository.archived}
+
+# METADATA
+# scope: rule
+# title: Secret Teams Should Not Have Admin Permission On Many Repositories
+# description: The team is secret, which means only its members and the organization owners can see it, and it has admin permission on several repositories. The access that secret teams grant is less likely to be reviewed by the organization members.
+# custom:
+#   severity: MEDIUM
+#   requiredEnrichers: [organizationId]
+#   requiredScopes: [read:org]
+#   remediationSteps:
+#     - 1. Go to the team page
+#     - 2. Press 'Settings' and change the team visibility to 'Visible', or
+#     - 3. Press 'Repositories' and lower the permission of the team on the repositories it doesn't administer
+#   threat: Admin access that is hidden from the organization members may remain unnoticed, e.g. after a member leaves the team that needed it, and can be abused to change the settings and protection of the repositories.
+default secret_team_with_admin_on_many_repositories := false
+
+secret_team_with_admin_on_many_repositories := true {
+    input.team.privacy == "secret"
+    # secret teams are hidden from the organization members, so their access is less likely to be reviewed
+    count(admin_repositories(input)) >= 3
+}
+
+# METADATA
+# scope: rule
+# title: Teams Should Have Maintainers
+# description: The team has no maintainers, so its membership can only be managed by the organization owners. Team maintainers are responsible for reviewing who is a member of the team and what access it grants.
+# custom:
+#   severity: LOW
+#   requiredEnrichers: [organizationId]
+#   requiredScopes: [read:org]
+#   remediationSteps:
+#     - 1. Go to the team page
+#     - 2. Press 'Members'
+#     - 3. Select a member who is responsible for the team, and change their role to 'Maintainer'
+#   threat: Teams without an owner tend to accumulate members who no longer need their access, since nobody reviews their membership.
+default team_without_maintainers := false
+
+team_without_maintainers := true {
+    maintainers := [member | member := input.members[_]; member.role == "maintainer"]
+    count(maintainers) == 0
+}
+
+# METADATA
+# scope: rule
+# title: Teams Should Not Grant Admin Permission On Many Repositories
+# description: The team has admin permission on many repositories. Admin permission allows to change the settings of a repository, including its branch protection, and should be granted on a per-repository basis to the teams that administer it.
+# custom:
+#   severity: MEDIUM
+#   requiredEnrichers: [organizationId]
+#   requiredScopes: [read:org]
+#   remediationSteps:
+#     - 1. Go to the team page
+#     - 2. Press 'Repositories'
+#     - 3. Change the permission of the team to 'Write' or 'Maintain' on the repositories it doesn't administer
+#   threat: Every member of the team, and any attacker who compromises one of their accounts, can disable the branch protection of many repositories and push malicious code to them.
+default team_grants_admin_broadly := false
+
+team_grants_admin_broadly := true {
+    # the number of repositories from which a team is considered to have a broad access
+    count(admin_repositories(input)) >= 10
+}
@@ -0,0 +1,94 @@
+package audit
+
+event_time(event) := event.created_at {
+	is_string(event.created_at)
+} else := ""
+
+# METADATA
+# scope: rule
+# title: Branch Protection Should Not Be Removed
+# description: A protected branch of a project in the group was unprotected during the analyzed time window. Even if the protection was restored afterwards, the changes that were pushed while it was removed were not reviewed.
+# custom:
+#   severity: HIGH
+#   requiredEnrichers: [entityId, auditEventsList]
+#   remediationSteps:
+#     - 1. Make sure you have owner permissions
+#     - 2. Go to the group page
+#     - 3. Press 'Secure' ➝ 'Audit events'
+#     - 4. Review the violating events with their authors, and make sure the protection was restored
+#     - 5. Review the commits that were pushed to the branch while it was unprotected
+#   threat: An attacker with the Maintainer role can temporarily unprotect a branch, push malicious code without a review and protect it again, leaving the current configuration compliant.
+branch_protection_removed[violated] := true {
+	some index
+	event := input.events[index]
+	event.details.remove == "protected_branch"
+	violated := {
+		"author": event.details.author_name,
+		"entity": event.details.entity_path,
+		"branch": event.details.target_details,
+		"time": event_time(event),
+	}
+}
+
+# METADATA
+# scope: rule
+# title: Projects Should Not Be Made Public
+# description: The visibility of a project or of a group was changed to public during the analyzed time window. Making a project public exposes its code, history and pipeline logs to everyone, and cannot be undone once its content was copied.
+# custom:
+#   severity: HIGH
+#   requiredEnrichers: [entityId, auditEventsList]
+#   remediationSteps:
+#     - 1. Make sure you have owner permissions
+#     - 2. Go to the group page
+#     - 3. Press 'Secure' ➝ 'Audit events'
+#     - 4. Review the violating events, and make sure the projects were meant to be public
+#     - 5. Otherwise, change the visibility of the projects back to private and rotate the secrets that were exposed
+#   threat: Source code, credentials committed to the history and internal information become available to anyone, including attackers looking for vulnerabilities and secrets.
+repository_made_public[violated] := true {
+	some index
+	event := input.events[index]
+	{"visibility", "visibility_level"}[event.details.change]
+	lower(event.details.to) == "public"
+	violated := {
+		"author": event.details.author_name,
+		"entity": event.details.entity_path,
+		"previous visibility": event.details.from,
+		"time": event_time(event),
+	}
+}
+
+is_owner_granted(event) {
+	event.details.add == "user_access"
+	event.details["as"] == "Owner"
+}
+
+is_owner_granted(event) {
+	event.details.change == "access_level"
+	event.details.to == "Owner"
+}
+
+# METADATA
+# scope: rule
+# title: New Owners Should Be Reviewed
+# description: A user was granted the Owner role of the group or of one of its projects during the analyzed time window. Administrative access should be granted rarely, and every grant should be reviewed.
+# custom:
+#   severity: MEDIUM
+#   requiredEnrichers: [entityId, auditEventsList]
+#   remediationSteps:
+#     - 1. Make sure you have owner permissions
+#     - 2. Go to the group page
+#     - 3. Press 'Secure' ➝ 'Audit events'
+#     - 4. Review the violating events, and make sure the users should be owners
+#     - 5. Otherwise, press 'Manage' ➝ 'Members' and lower the role of the users
+#   threat: An attacker that compromised an owner account can grant the Owner role to another account they control, to keep their access after the compromised account is recovered.
+admin_added[violated] := true {
+	some index
+	event := input.events[index]
+	is_owner_granted(event)
+	violated := {
+		"author": event.details.author_name,
+		"entity": event.details.entity_path,
+		"user": event.details.target_details,
+		"time": event_time(event),
+	}
+}
@@ -0,0 +1,21 @@
+package common.membership
+
+maintainer_access_level := 40
+
+roles := {10: "Guest", 20: "Reporter", 30: "Developer", 40: "Maintainer", 50: "Owner"}
+
+# is_maintainer_through_sharing tells whether the member has Maintainer or Owner access only because a group it belongs to was shared
+is_maintainer_through_sharing(member) {
+	member.access_level >= maintainer_access_level
+	not is_maintainer_within_hierarchy(member)
+}
+
+is_maintainer_within_hierarchy(member) {
+	grant := member.grants[_]
+	grant.access_level >= maintainer_access_level
+	grant.source != "shared"
+}
+
+violation(member) := {"username": member.username, "role": roles[member.access_level], "shared_from": concat(", ", shared_from)} {
+	shared_from := {grant.path | grant := member.grants[_]; grant.source == "shared"; grant.access_level >= maintainer_access_level}
+}
@@ -0,0 +1,60 @@
+package common.tokens
+
+is_active(token) {
+	token.active
+	not token.revoked
+}
+
+# is_unused tells whether the token wasn't used in the last days, or since it was created if it was never used
+is_unused(token, days) {
+	not is_null(token.last_used_at)
+	older_than_days(token.last_used_at, days)
+}
+
+is_unused(token, days) {
+	is_null(token.last_used_at)
+	older_than_days(token.created_at, days)
+}
+
+older_than_days(date, days) {
+	time.now_ns() - time.parse_rfc3339_ns(date) > days * 24 * 60 * 60 * 1000000000
+}
+
+violation(token) := {"type": token.type, "name": token.name, "scopes": concat(", ", token.scopes)}
+
+# The rules below return the violations of the listed tokens, for the projects, groups and users to share
+
+deploy_tokens_without_expiration(tokens) := {violation |
+	some index
+	token := tokens[index]
+	not token.revoked
+	is_null(token.expires_at)
+	violation := {"name": token.name, "scopes": concat(", ", token.scopes)}
+}
+
+without_expiration(tokens) := {violation(token) |
+	some index
+	token := tokens[index]
+	is_active(token)
+	is_null(token.expires_at)
+}
+
+with_api_scope(tokens) := {violation(token) |
+	some index
+	token := tokens[index]
+	is_active(token)
+	token.scopes[_] == "api"
+}
+
+with_owner_api_scope(tokens) := with_api_scope([token |
+	some index
+	token := tokens[index]
+	token.access_level == 50
+])
+
+unused(tokens, days) := {violation(token) |
+	some index
+	token := tokens[index]
+	is_active(token)
+	is_unused(token, days)
+}
@@ -0,0 +1,6 @@
+package common.variables
+
+# is_secret tells whether the name of a CI/CD variable suggests it holds a secret
+is_secret(key) {
+	regex.match(`(?i)(token|secret|passw(or)?d|api[-_]?key|private[-_]?key|credential)`, key)
+}
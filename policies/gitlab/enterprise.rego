@@ -222,3 +222,240 @@ default unauthenticated_signup_enabled := true
 unauthenticated_signup_enabled := false {
     not input.signup_enabled
 }
+
+# METADATA
+# scope: rule
+# title: Sign-Up Should Require Admin Approval
+# description: The server allows anyone to sign up, and new users get access without an admin approving them first. Requiring admin approval makes sure that only legitimate users get access to the server.
+# custom:
+#   severity: HIGH
+#   remediationSteps:
+#     - 1. Go to the admin page: Menu -> Admin
+#     - 2. Press Settings -> General
+#     - 3. Expand 'Sign-up restrictions' section
+#     - 4. Toggle 'Require admin approval for new sign-ups'
+#     - 5. Press 'Save Changes'
+#   threat:
+#     - An attacker can sign up to the server and immediately access every internal project and group.
+default signup_does_not_require_admin_approval := false
+
+signup_does_not_require_admin_approval {
+	input.signup_enabled
+	not input.require_admin_approval_after_user_signup
+}
+
+# METADATA
+# scope: rule
+# title: Sign-Up Should Be Restricted To Allowed Domains
+# description: The server allows anyone to sign up with any email address. Restricting sign-up to the email domains of your organization prevents external users from creating accounts.
+# custom:
+#   severity: MEDIUM
+#   remediationSteps:
+#     - 1. Go to the admin page: Menu -> Admin
+#     - 2. Press Settings -> General
+#     - 3. Expand 'Sign-up restrictions' section
+#     - 4. Fill 'Allowed domains for sign-ups' with the domains of your organization
+#     - 5. Press 'Save Changes'
+#   threat:
+#     - Anyone with network access to the server can create an account with a personal email address and access internal projects.
+default signup_not_restricted_to_allowed_domains := false
+
+signup_not_restricted_to_allowed_domains {
+	input.signup_enabled
+	not has_domain_allowlist(input)
+}
+
+has_domain_allowlist(settings) {
+	settings.domain_allowlist[_]
+}
+
+# METADATA
+# scope: rule
+# title: Admin Mode Should Be Enabled
+# description: Admin mode requires administrators to re-authenticate before performing administrative tasks, and limits the administrative access of their tokens. Without it, every session and token of an administrator has full administrative access.
+# custom:
+#   severity: MEDIUM
+#   remediationSteps:
+#     - 1. Go to the admin page: Menu -> Admin
+#     - 2. Press Settings -> General
+#     - 3. Expand 'Sign-in restrictions' section
+#     - 4. Toggle 'Enable admin mode'
+#     - 5. Press 'Save Changes'
+#   threat:
+#     - An attacker who steals the session or a personal access token of an administrator gets full control of the server.
+default admin_mode_not_enabled := true
+
+admin_mode_not_enabled := false {
+	input.admin_mode
+}
+
+# METADATA
+# scope: rule
+# title: Two-Factor Authentication Grace Period Should Not Be Longer Than One Week
+# description: Two-factor authentication is enforced on the server, but users are allowed longer than a week to enable it. The time frame should be lowered to one week or less.
+# custom:
+#   severity: MEDIUM
+#   remediationSteps:
+#     - 1. Go to the admin page: Menu -> Admin
+#     - 2. Press Settings -> General
+#     - 3. Expand 'Sign-in restrictions' section
+#     - 4. Set 'Two-factor grace period (hours)' to a number under 168 (preferably 0)
+#     - 5. Press 'Save Changes'
+#   threat:
+#     - Any new user effectively acts as an attack surface until two-factor authentication is enabled. The risk is compounded as new users may be more vulnerable to phishing and identity theft attacks.
+default server_allows_excessive_mfa_grace_period := false
+
+server_allows_excessive_mfa_grace_period {
+	input.require_two_factor_authentication
+	input.two_factor_grace_period > 168
+}
+
+# METADATA
+# scope: rule
+# title: Minimum Password Length Should Be At Least 12 Characters
+# description: Password authentication is enabled on the server, but passwords may be shorter than 12 characters. Short passwords are easier to guess and to brute-force.
+# custom:
+#   severity: MEDIUM
+#   remediationSteps:
+#     - 1. Go to the admin page: Menu -> Admin
+#     - 2. Press Settings -> General
+#     - 3. Expand 'Sign-up restrictions' section
+#     - 4. Set 'Minimum password length (number of characters)' to 12 or more
+#     - 5. Press 'Save Changes'
+#   threat:
+#     - An attacker can guess or brute-force the password of a user and access the server on their behalf.
+default minimum_password_length_too_short := false
+
+minimum_password_length_too_short {
+	input.password_authentication_enabled_for_web
+	input.minimum_password_length < 12
+}
+
+# METADATA
+# scope: rule
+# title: Project Import Sources Should Be Restricted
+# description: >
+#     The server allows importing projects from a repository URL, from a manifest file or from a GitLab export file.
+#     Imports from URLs make the server fetch arbitrary addresses, and export files are complex archives that have been the source of several critical vulnerabilities.
+#     It is recommended to enable only the import sources that your organization uses.
+# custom:
+#   severity: MEDIUM
+#   remediationSteps:
+#     - 1. Go to the admin page: Menu -> Admin
+#     - 2. Press Settings -> General
+#     - 3. Expand 'Import and export settings' section
+#     - 4. Under 'Import sources' un toggle 'Repository by URL', 'Manifest file' and 'GitLab export'
+#     - 5. Press 'Save Changes'
+#   threat:
+#     - An attacker with access to the server can import a malicious project to reach internal services or exploit the import process.
+default unsafe_import_sources_enabled := false
+
+unsafe_import_sources_enabled {
+	{"git", "manifest", "gitlab_project"}[input.import_sources[_]]
+}
+
+# METADATA
+# scope: rule
+# title: System Hooks Should Not Be Allowed To Be Sent To The Local Network
+# description: >
+#     System hooks are sent by the server on events of the entire instance, and can cause potential damage if sent uncontrollably to internal services.
+#     Therefore, as a security best practice, system hooks should be limited to external URLs, or to an explicit allowlist of local addresses.
+# custom:
+#   severity: LOW
+#   remediationSteps:
+#     - 1. Go to the admin page: Menu -> Admin
+#     - 2. Press Settings -> Network
+#     - 3. Expand 'Outbound requests' section
+#     - 4. Un toggle 'Allow requests to the local network from system hooks'
+#     - 5. Press 'Save Changes'
+#   threat:
+#     - An attacker who controls a system hook can use the server to send requests to internal services that are not exposed to the network.
+default system_hooks_are_allowed_to_be_sent_to_local_network := true
+
+system_hooks_are_allowed_to_be_sent_to_local_network := false {
+	not input.allow_local_requests_from_system_hooks
+}
+
+# METADATA
+# scope: rule
+# title: Personal Access Token Lifetime Should Be Limited
+# description: The server allows personal access tokens that are valid for more than a year. Limiting the lifetime of tokens reduces the time window in which a leaked token can be used.
+# custom:
+#   severity: MEDIUM
+#   prerequisites: [premium]
+#   remediationSteps:
+#     - 1. Go to the admin page: Menu -> Admin
+#     - 2. Press Settings -> General
+#     - 3. Expand 'Account and limit' section
+#     - 4. Set 'Maximum allowable lifetime for access tokens (days)' to 365 or less
+#     - 5. Press 'Save Changes'
+#   threat:
+#     - A leaked personal access token can be used to access the server long after it was created and forgotten.
+default personal_access_token_lifetime_not_limited := true
+
+personal_access_token_lifetime_not_limited := false {
+	input.max_personal_access_token_lifetime > 0
+	input.max_personal_access_token_lifetime <= 365
+}
+
+# METADATA
+# scope: rule
+# title: Audit Events Should Be Streamed To An External Destination
+# description: The server doesn't stream its audit events to an external destination, such as a SIEM. Streaming audit events allows monitoring them in real time, and keeps them safe from an attacker who gains admin access to the server.
+# custom:
+#   severity: LOW
+#   prerequisites: [premium]
+#   remediationSteps:
+#     - 1. Go to the admin page: Menu -> Admin
+#     - 2. Press Monitoring -> Audit Events
+#     - 3. Select the 'Streams' tab
+#     - 4. Press 'Add streaming destination' and fill the details of your destination
+#   threat:
+#     - An attacker who gains access to the server can act unnoticed, and an attacker with admin access can erase the traces of their actions.
+default audit_event_streaming_not_configured := false
+
+audit_event_streaming_not_configured {
+	input.audit_event_streaming_destinations == []
+}
+
+# METADATA
+# scope: rule
+# title: Admins Should Have Two-Factor Authentication Enabled
+# description: Some of the active administrators of the server don't have two-factor authentication enabled. Administrators have full control of the server, so their accounts must be protected by more than a password.
+# custom:
+#   severity: HIGH
+#   requiredEnrichers: [adminsList]
+#   remediationSteps:
+#     - 1. Ask each of the listed administrators to enable two-factor authentication in their account settings
+#     - 2. Alternatively, enforce two-factor authentication globally on the server
+#   threat:
+#     - An attacker who obtains the password of an administrator, e.g. by phishing, gets full control of the server.
+admins_without_two_factor_authentication[violation] := true {
+	some index
+	admin := input.admins[index]
+	admin.state == "active"
+	not admin.two_factor_enabled
+	violation := {"username": admin.username, "name": admin.name}
+}
+
+# METADATA
+# scope: rule
+# title: Blocked And Deactivated Users Should Not Keep Admin Privileges
+# description: Some users that are not active (e.g. blocked or deactivated) still have administrator privileges. Once a user is not active, there is no reason to keep their privileges, which are restored as soon as the user is unblocked or reactivated.
+# custom:
+#   severity: MEDIUM
+#   requiredEnrichers: [adminsList]
+#   remediationSteps:
+#     - 1. Go to the admin page: Menu -> Admin
+#     - 2. Press Overview -> Users
+#     - 3. Edit each of the listed users
+#     - 4. Under 'Access level' select 'Regular'
+#     - 5. Press 'Save Changes'
+#   threat:
+#     - A user that is reactivated, by mistake or by another compromised admin, immediately regains full control of the server.
+inactive_users_with_admin_privileges[violation] := true {
+	some index
+	admin := input.admins[index]
+	admin.state != "active"
+	violation := {"username": admin.username, "state": admin.state}
+}
@@ -1,5 +1,7 @@
 package member
 
+import data.common.tokens as tokenUtils
+
 # METADATA
 # scope: rule
 # title: Two Factor Authentication Should Be Enabled for Collaborators
@@ -70,3 +72,60 @@ isStale(target_last_active, count_months) {
 	# diff[1] the months index
 	diff[1] >= count_months
 }
+
+# METADATA
+# scope: rule
+# title: Personal Access Tokens Should Have An Expiration Date
+# description: Some of the user's personal access tokens or impersonation tokens never expire. These tokens act with all the permissions of the user, in every group and project the user is a member of, so a leaked token keeps them until an administrator revokes it.
+# custom:
+#   severity: MEDIUM
+#   requiredEnrichers: [accessTokensList]
+#   remediationSteps:
+#     - 1. Go to the admin menu
+#     - 2. Select 'Overview -> Users' on the left navigation bar and choose the user
+#     - 3. Revoke each of the listed tokens in the 'Personal Access Tokens' and 'Impersonation Tokens' tabs
+#     - 4. Ask the user to create a new token with an expiration date, or to use a project or group access token for automation
+#   threat:
+#     - An attacker who obtains an old personal token of the user, e.g. from a script or a developer's machine, can act as the user indefinitely, even after the user's password was changed.
+personal_access_token_without_expiration[violation] := true {
+	violation := tokenUtils.without_expiration(input.access_tokens)[_]
+}
+
+# METADATA
+# scope: rule
+# title: Administrators Should Not Have Personal Access Tokens With The API Scope
+# description: An administrator has personal access tokens or impersonation tokens with the 'api' scope, which allows full read and write access to the whole GitLab instance through the API.
+# custom:
+#   severity: HIGH
+#   requiredEnrichers: [accessTokensList]
+#   remediationSteps:
+#     - 1. Go to the admin menu
+#     - 2. Select 'Overview -> Users' on the left navigation bar and choose the user
+#     - 3. Revoke each of the listed tokens in the 'Personal Access Tokens' and 'Impersonation Tokens' tabs
+#     - 4. Ask the user to create tokens with narrower scopes (e.g. 'read_api'), or to use a non-admin account for automation
+#   threat:
+#     - An attacker who obtains such a token can take over the whole GitLab instance, e.g. by creating admin users or reading the CI/CD secrets of every project.
+admin_personal_access_token_with_api_scope[violation] := true {
+	input.is_admin
+	violation := tokenUtils.with_api_scope(input.access_tokens)[_]
+}
+
+# METADATA
+# scope: rule
+# title: Unused Personal Access Tokens Should Be Revoked
+# description: Some of the user's personal access tokens weren't used in the last 90 days. A token that the user no longer needs still carries all of the user's permissions, and is easily overlooked when the user's access is reviewed.
+# custom:
+#   severity: LOW
+#   requiredEnrichers: [accessTokensList]
+#   remediationSteps:
+#     - 1. Go to the admin menu
+#     - 2. Select 'Overview -> Users' on the left navigation bar and choose the user
+#     - 3. Confirm with the user that the listed tokens are no longer used
+#     - 4. Revoke each of the listed tokens in the 'Personal Access Tokens' tab
+#   threat:
+#     - An attacker who finds a forgotten token of the user, e.g. in an old script, can act as the user without signing in, so neither the user nor the sign-in monitoring notices it.
+unused_personal_access_token[violation] := true {
+	# the last usage of impersonation tokens is not available
+	personal := [token | some index; token := input.access_tokens[index]; token.type == "personal"]
+	violation := tokenUtils.unused(personal, 90)[_]
+}
@@ -1,5 +1,9 @@
 package organization
 
+import data.common.variables as variableUtils
+import data.common.tokens as tokenUtils
+import data.common.membership as membershipUtils
+
 # METADATA
 # scope: rule
 # title: Two-Factor Authentication Should Be Enforced For The Group
@@ -20,6 +24,11 @@ two_factor_authentication_not_required_for_group := false {
 	input.require_two_factor_authentication
 }
 
+# subgroups inherit the requirement from their ancestors
+two_factor_authentication_not_required_for_group := false {
+	input.effective_settings.require_two_factor_authentication
+}
+
 # METADATA
 # scope: rule
 # title: Forking of Repositories to External Namespaces Should Be Disabled.
@@ -39,6 +48,11 @@ collaborators_can_fork_repositories_to_external_namespaces := false {
 	input.prevent_forking_outside_group
 }
 
+# the setting can only be set on the top-level group, and applies to all its subgroups
+collaborators_can_fork_repositories_to_external_namespaces := false {
+	input.effective_settings.prevent_forking_outside_group
+}
+
 # METADATA
 # scope: rule
 # title: Webhooks Should Be Configured To Use SSL
@@ -101,3 +115,133 @@ default group_allows_excessive_mfa_grace_period := true
 group_allows_excessive_mfa_grace_period := false{
 	input.two_factor_grace_period <= 168
 }
+
+# the shortest grace period in the hierarchy applies
+group_allows_excessive_mfa_grace_period := false {
+	input.effective_settings.require_two_factor_authentication
+	input.effective_settings.two_factor_grace_period <= 168
+}
+
+# METADATA
+# scope: rule
+# title: Secret Group CI/CD Variables Should Be Masked And Protected
+# description: Some of the group CI/CD variables seem to hold secrets, e.g. tokens or passwords, but are not masked or not protected. Group variables are available to all the projects of the group, so an unmasked variable is printed in clear text in the job logs of each of them, and an unprotected variable is available to pipelines of every branch.
+# custom:
+#   severity: HIGH
+#   requiredEnrichers: [variablesList]
+#   remediationSteps:
+#     - 1. Make sure you have owner permissions
+#     - 2. Go to the group's Settings -> CI/CD page
+#     - 3. Expand the 'Variables' section
+#     - 4. Edit each of the listed variables, and check 'Protect variable' and 'Mask variable'
+#     - 5. Press 'Update variable'
+#   threat:
+#     - Any developer of any project in the group can push a branch with a pipeline that prints an unprotected secret, and use it to access the service it belongs to.
+#     - Anyone with access to the job logs of a project in the group can read an unmasked secret.
+group_secret_variable_not_masked_or_protected[violation] := true {
+	some index
+	variable := input.variables[index]
+	variableUtils.is_secret(variable.key)
+	not secured(variable)
+	violation := {"variable": variable.key, "environment": variable.environment_scope}
+}
+
+secured(variable) {
+	variable.masked
+	variable.protected
+}
+
+# METADATA
+# scope: rule
+# title: Group Deploy Tokens Should Have An Expiration Date
+# description: Some of the group deploy tokens never expire. Group deploy tokens give access to all the projects of the group and are not bound to a user, so a token that leaks gives access to the group until someone notices and revokes it.
+# custom:
+#   severity: MEDIUM
+#   requiredEnrichers: [deployTokensList]
+#   remediationSteps:
+#     - 1. Make sure you have owner permissions
+#     - 2. Go to the group's Settings -> Repository page
+#     - 3. Expand the 'Deploy tokens' section
+#     - 4. Revoke each of the listed tokens, and create a new token with an expiration date instead
+#   threat:
+#     - An attacker who obtains an old group deploy token can read the code and packages of every project in the group, or publish malicious packages and images.
+group_deploy_token_without_expiration[violation] := true {
+	violation := tokenUtils.deploy_tokens_without_expiration(input.deploy_tokens)[_]
+}
+
+# METADATA
+# scope: rule
+# title: Group Access Tokens Should Have An Expiration Date
+# description: Some of the group access tokens never expire. A group access token gives access to every project of the group and of its subgroups, including the projects that are added after it was created, so a leaked token keeps that access until a group owner notices and revokes it.
+# custom:
+#   severity: MEDIUM
+#   requiredEnrichers: [accessTokensList]
+#   remediationSteps:
+#     - 1. Make sure you have owner permissions on the group
+#     - 2. Go to the group's Settings -> Access Tokens page
+#     - 3. Revoke each of the listed tokens
+#     - 4. Create a new token with an expiration date, preferably a project access token if only a single project uses it, and replace the revoked token where it is used
+#   threat:
+#     - An attacker who obtains an old group access token can use it indefinitely to access all the projects of the group, including sensitive projects that were created long after the token leaked.
+group_access_token_without_expiration[violation] := true {
+	violation := tokenUtils.without_expiration(input.access_tokens)[_]
+}
+
+# METADATA
+# scope: rule
+# title: Group Access Tokens With The Owner Role Should Not Have The API Scope
+# description: Some of the group access tokens have both the Owner role and the 'api' scope, which allows full read and write access to the group and all of its projects through the API, including their settings, members and CI/CD variables.
+# custom:
+#   severity: HIGH
+#   requiredEnrichers: [accessTokensList]
+#   remediationSteps:
+#     - 1. Make sure you have owner permissions
+#     - 2. Go to the group's Settings -> Access Tokens page
+#     - 3. Revoke each of the listed tokens
+#     - 4. Create a new token with the lowest role and the narrowest scopes (e.g. 'read_api' or 'read_repository') that its usage requires
+#   threat:
+#     - An attacker who obtains such a token can take over the group and all of its projects, e.g. by removing their protections, reading their CI/CD secrets or adding members.
+group_access_token_with_owner_api_scope[violation] := true {
+	violation := tokenUtils.with_owner_api_scope(input.access_tokens)[_]
+}
+
+# METADATA
+# scope: rule
+# title: Unused Group Access Tokens Should Be Revoked
+# description: Some of the group access tokens weren't used in the last 90 days. An unused group access token still gives access to all the projects of the group, whose maintainers usually don't know that it exists.
+# custom:
+#   severity: LOW
+#   requiredEnrichers: [accessTokensList]
+#   remediationSteps:
+#     - 1. Make sure you have owner permissions on the group
+#     - 2. Go to the group's Settings -> Access Tokens page
+#     - 3. Check the 'Last used' column to confirm that the listed tokens are no longer used
+#     - 4. Revoke each of the listed tokens
+#   threat:
+#     - Group access tokens are often created for a one-time automation and forgotten, so their use by an attacker goes unnoticed by the maintainers of the projects they access.
+unused_group_access_token[violation] := true {
+	violation := tokenUtils.unused(input.access_tokens, 90)[_]
+}
+
+# METADATA
+# scope: rule
+# title: Maintainer Access To The Group Should Not Be Granted Through Group Sharing
+# description: >
+#     Some users have Maintainer or Owner access to the group only because the group, or one of its ancestors, was shared with another group they belong to.
+#     Such access is managed by the owners of the other group, who can add members to it at any time, so it is easy to lose track of who can administer the group and its projects.
+# custom:
+#   severity: MEDIUM
+#   requiredEnrichers: [effectiveMembersList]
+#   remediationSteps:
+#     - 1. Make sure you have owner permissions
+#     - 2. Go to the group (or the listed ancestor group) and press 'Manage' -> 'Members'
+#     - 3. Choose the 'Groups' tab, and lower the role of the shared group to 'Developer'
+#     - 4. Add the users that need Maintainer access as direct members instead
+#   threat:
+#     - An attacker who compromises any member of the shared group, or who is added to it by its owners, can change the settings and protections of the group and all of its projects.
+group_maintainer_access_through_shared_groups[violation] := true {
+	some index
+	member := input.effective_members[index]
+	membershipUtils.is_maintainer_through_sharing(member)
+	violation := membershipUtils.violation(member)
+}
@@ -1,5 +1,9 @@
 package repository
 
+import data.common.variables as variableUtils
+import data.common.tokens as tokenUtils
+import data.common.membership as membershipUtils
+
 # METADATA
 # scope: rule
 # title: Project Should Be Updated At Least Quarterly
@@ -9,6 +13,7 @@ package repository
 #     - 1. Make sure you have admin permissions
 #     - 2. Either Delete or Archive the project
 #   severity: HIGH
+#   prerequisites: [not_archived]
 #   threat: As new vulnerabilities are found over time, unmaintained repositories are more likely to point to dependencies that have known vulnerabilities, exposing these repositories to 1-day attacks.
 default project_not_maintained := true
 
@@ -82,7 +87,7 @@ forking_allowed_for_repository := false {
 #     - 4. Select the default branch
 #     - 5. Set the allowed to merge to 'maintainers' and the allowed to push to 'No one'
 #   severity: MEDIUM
-#   prerequisites: [premium]
+#   prerequisites: [premium, not_archived]
 #   threat: Any contributor with write access may push potentially dangerous code to this repository, making it easier to compromise and difficult to audit.
 default missing_default_branch_protection := true
 
@@ -103,7 +108,7 @@ missing_default_branch_protection := false {
 #     - 4. Select the default branch
 #     - 5. Set the allowed to merge to 'maintainers' and the allowed to push to 'No one'
 #   severity: MEDIUM
-#   prerequisites: [premium]
+#   prerequisites: [premium, not_archived]
 #   threat: Rewriting project history can make it difficult to trace back when bugs or security issues were introduced, making them more difficult to remediate.
 default missing_default_branch_protection_force_push := true
 
@@ -127,7 +132,7 @@ missing_default_branch_protection_force_push := false {
 #     - 4. Select the default branch
 #     - 5. Check the 'Code owner approval'
 #   severity: LOW
-#   prerequisites: [premium]
+#   prerequisites: [premium, not_archived]
 #   threat: A pull request may be approved by any contributor with write access. Specifying specific code owners can ensure review is only done by individuals with the correct expertise required for the review of the changed files, potentially preventing bugs and security risks.
 default repository_require_code_owner_reviews_policy := true
 
@@ -165,6 +170,7 @@ project_webhook_doesnt_require_ssl := false{
 # description: Checks that validate the quality and security of the code are not required to pass before submitting new changes. It is advised to turn this flag on to ensure any existing or future check will be required to pass.
 # custom:
 #   severity: MEDIUM
+#   prerequisites: [not_archived]
 #   remediationSteps:
 #     - 1. Make sure you can manage project merge requests permissions
 #     - 2. Go to the project's settings page
@@ -184,6 +190,7 @@ requires_status_checks := false {
 # description: Require all merge request conversations to be resolved before merging. Check this to avoid bypassing/missing a Pull Request comment.
 # custom:
 #   severity: LOW
+#   prerequisites: [not_archived]
 #   remediationSteps:
 #     - 1. Make sure you can manage project merge requests permissions
 #     - 2. Go to the project's settings page
@@ -208,7 +215,7 @@ no_conversation_resolution := false {
 #     - 3. Enter 'Push Rules' tab
 #     - 4. Set the 'Reject unsigned commits' checkbox
 #   severity: LOW
-#   prerequisites: [premium]
+#   prerequisites: [premium, not_archived]
 #   threat: A commit containing malicious code may be crafted by a malicious actor that has acquired write access to the repository to initiate a supply chain attack. Commit signing provides another layer of defense that can prevent this type of compromise.
 default no_signed_commits := true
 
@@ -231,7 +238,7 @@ no_signed_commits := false {
 #     - 6. Select 'Add approvers' and select the desired members
 #     - 7. Click 'Add approval rule'
 #   severity: HIGH
-#   prerequisites: [premium]
+#   prerequisites: [premium, not_archived]
 #   threat:
 #     - Users can merge code without being reviewed which can lead to insecure code reaching the main branch and production.
 default code_review_not_required := true
@@ -254,7 +261,7 @@ code_review_not_required := false {
 #     - 6. Select 'Add approvers' and select the desired members
 #     - 7. Click 'Add approval rule'
 #   severity: MEDIUM
-#   prerequisites: [premium]
+#   prerequisites: [premium, not_archived]
 #   threat:
 #     - Users can merge code without being reviewed which can lead to insecure code reaching the main branch and production.
 default code_review_by_two_members_not_required := true
@@ -275,6 +282,7 @@ code_review_by_two_members_not_required := false {
 #     - 4. Under 'Approval settings', Check 'Prevent approval by author'
 #     - 5. Click 'Save Changes'
 #   severity: MEDIUM
+#   prerequisites: [not_archived]
 #   threat:
 #     - Users can merge code without being reviewed which can lead to insecure code reaching the main branch and production.
 default repository_allows_review_requester_to_approve_their_own_request := true
@@ -295,7 +303,7 @@ repository_allows_review_requester_to_approve_their_own_request := false {
 #     - 4. Under 'Approval settings', Check 'Prevent editing approval rules in merge requests'
 #     - 5. Click 'Save Changes'
 #   severity: MEDIUM
-#   prerequisites: [premium]
+#   prerequisites: [premium, not_archived]
 #   threat:
 #     - Users can merge code without being reviewed which can lead to insecure code reaching the main branch and production.
 default repository_allows_overriding_approvers := true
@@ -316,7 +324,7 @@ repository_allows_overriding_approvers := false {
 #     - 4. Under 'Approval settings', Check 'Prevent approvals by users who add commits'
 #     - 5. Click 'Save Changes'
 #   severity: LOW
-#   prerequisites: [premium]
+#   prerequisites: [premium, not_archived]
 #   threat:
 #     - Users can merge code without being reviewed which can lead to insecure code reaching the main branch and production.
 default repository_allows_committer_approvals_policy := true
@@ -337,7 +345,7 @@ repository_allows_committer_approvals_policy := false {
 #     - 4. Under 'Approval settings', Check 'Remove all approvals'
 #     - 5. Click 'Save Changes'
 #   severity: LOW
-#   prerequisites: [premium]
+#   prerequisites: [premium, not_archived]
 #   threat: Buggy or insecure code may be committed after approval and will reach the main branch without review. Alternatively, an attacker can attempt a just-in-time attack to introduce dangerous code just before merge.
 default repository_dismiss_stale_reviews := true
 
@@ -357,9 +365,280 @@ repository_dismiss_stale_reviews := false {
 #     - 4. When 'restrict_user_defined_variables' is enabled, you can specify which role can override variables. This is done by setting the 'ci_pipeline_variables_minimum_override_role' attribute to one of: owner, maintainer, developer or no_one_allowed.
 #     - 5. For more information, you can check out gitlab's API documentation: https://docs.gitlab.com/ee/api/projects.html
 #   severity: LOW
+#   prerequisites: [not_archived]
 #   threat: Allowing overrides of predefined variables can result in unintentional misconfigurations of the CI/CD pipeline or deliberate tampering.
 default overriding_defined_variables_isnt_restricted := true
 
 overriding_defined_variables_isnt_restricted := false {
 	input.restrict_user_defined_variables
-}
\ No newline at end of file
+}
+
+# METADATA
+# scope: rule
+# title: Secret CI/CD Variables Should Be Masked And Protected
+# description: Some of the project CI/CD variables seem to hold secrets, e.g. tokens or passwords, but are not masked or not protected. An unmasked variable is printed in clear text in the job logs, and an unprotected variable is available to pipelines of every branch, not only of protected branches.
+# custom:
+#   severity: HIGH
+#   requiredEnrichers: [variablesList]
+#   remediationSteps:
+#     - 1. Make sure you have owner or maintainer permissions
+#     - 2. Go to the project's Settings -> CI/CD page
+#     - 3. Expand the 'Variables' section
+#     - 4. Edit each of the listed variables, and check 'Protect variable' and 'Mask variable'
+#     - 5. Press 'Update variable'
+#   threat:
+#     - Any developer can push a branch with a pipeline that prints an unprotected secret, and use it to access the service it belongs to.
+#     - Anyone with access to the job logs can read an unmasked secret.
+secret_variable_not_masked_or_protected[violation] := true {
+	some index
+	variable := input.variables[index]
+	variableUtils.is_secret(variable.key)
+	not secured(variable)
+	violation := {"variable": variable.key, "environment": variable.environment_scope}
+}
+
+secured(variable) {
+	variable.masked
+	variable.protected
+}
+
+# METADATA
+# scope: rule
+# title: Projects With Protected Variables Should Not Use Shared Runners
+# description: The project has protected CI/CD variables, which are usually deployment secrets, and its jobs may run on shared runners. Shared runners also run the jobs of other projects, possibly of other tenants of the GitLab instance, so a job of another project that compromises a runner can read the secrets of the project's jobs.
+# custom:
+#   severity: MEDIUM
+#   prerequisites: [not_archived]
+#   remediationSteps:
+#     - 1. Make sure you have owner or maintainer permissions
+#     - 2. Go to the project's Settings -> CI/CD page
+#     - 3. Expand the 'Runners' section
+#     - 4. Disable 'Enable shared runners for this project'
+#     - 5. Register a project or group runner for the project instead
+#   threat:
+#     - A malicious job of another project that runs on the same shared runner can persist on it and steal the secrets of the following jobs, or tamper with their artifacts.
+default shared_runners_enabled_on_sensitive_project := false
+
+shared_runners_enabled_on_sensitive_project {
+	input.shared_runners_enabled
+	some index
+	input.variables[index].protected
+}
+
+# METADATA
+# scope: rule
+# title: Deploy Tokens Should Have An Expiration Date
+# description: Some of the project deploy tokens never expire. Deploy tokens are not bound to a user, so a token that leaks gives access to the project until someone notices and revokes it.
+# custom:
+#   severity: MEDIUM
+#   requiredEnrichers: [deployTokensList]
+#   remediationSteps:
+#     - 1. Make sure you have owner or maintainer permissions
+#     - 2. Go to the project's Settings -> Repository page
+#     - 3. Expand the 'Deploy tokens' section
+#     - 4. Revoke each of the listed tokens, and create a new token with an expiration date instead
+#   threat:
+#     - An attacker who obtains an old deploy token, e.g. from a decommissioned server or a leaked backup, can read the project code and packages, or publish malicious packages and images.
+deploy_token_without_expiration[violation] := true {
+	violation := tokenUtils.deploy_tokens_without_expiration(input.deploy_tokens)[_]
+}
+
+# METADATA
+# scope: rule
+# title: Project Access Tokens Should Have An Expiration Date
+# description: Some of the project access tokens never expire. Each project access token acts as a bot member of the project, which is not removed when the people who created the token leave, so a leaked token keeps its access to the project until a maintainer notices and revokes it.
+# custom:
+#   severity: MEDIUM
+#   requiredEnrichers: [accessTokensList]
+#   remediationSteps:
+#     - 1. Make sure you have owner permissions on the project
+#     - 2. Go to the project's Settings -> Access Tokens page
+#     - 3. Revoke each of the listed tokens
+#     - 4. Create a new project access token with an expiration date, and replace the revoked token in the pipelines and integrations of the project that use it
+#   threat:
+#     - An attacker who obtains an old project access token, e.g. from the CI/CD configuration of another project, can keep accessing the code, issues and pipelines of the project with the token's role long after the leak.
+project_access_token_without_expiration[violation] := true {
+	violation := tokenUtils.without_expiration(input.access_tokens)[_]
+}
+
+# METADATA
+# scope: rule
+# title: Project Access Tokens With The Owner Role Should Not Have The API Scope
+# description: Some of the project access tokens have both the Owner role and the 'api' scope, which allows full read and write access to the project through the API, including its settings, members and CI/CD variables.
+# custom:
+#   severity: HIGH
+#   requiredEnrichers: [accessTokensList]
+#   remediationSteps:
+#     - 1. Make sure you have owner permissions
+#     - 2. Go to the project's Settings -> Access Tokens page
+#     - 3. Revoke each of the listed tokens
+#     - 4. Create a new token with the lowest role and the narrowest scopes (e.g. 'read_api' or 'read_repository') that its usage requires
+#   threat:
+#     - An attacker who obtains such a token can take over the project, e.g. by removing its protections, reading its CI/CD secrets or adding members.
+project_access_token_with_owner_api_scope[violation] := true {
+	violation := tokenUtils.with_owner_api_scope(input.access_tokens)[_]
+}
+
+# METADATA
+# scope: rule
+# title: Unused Project Access Tokens Should Be Revoked
+# description: Some of the project access tokens weren't used in the last 90 days. The bot members of these tokens still hold their role in the project, although none of the pipelines or integrations of the project relies on them anymore.
+# custom:
+#   severity: LOW
+#   requiredEnrichers: [accessTokensList]
+#   remediationSteps:
+#     - 1. Make sure you have owner permissions on the project
+#     - 2. Go to the project's Settings -> Access Tokens page
+#     - 3. Check the 'Last used' column to confirm that the listed tokens are no longer used
+#     - 4. Revoke each of the listed tokens
+#   threat:
+#     - An attacker who finds an unused project access token, e.g. in an old pipeline configuration or a fork of the project, can use it without the project maintainers noticing, since nothing legitimate depends on the token.
+unused_project_access_token[violation] := true {
+	violation := tokenUtils.unused(input.access_tokens, 90)[_]
+}
+
+# METADATA
+# scope: rule
+# title: Release Tags Should Be Protected
+# description: The project has no protected tags. Tags usually trigger release pipelines and mark the versions that are deployed or published, so anyone who can push to the project can create, move or delete the tag of a release.
+# custom:
+#   severity: MEDIUM
+#   prerequisites: [not_archived]
+#   remediationSteps:
+#     - 1. Make sure you have owner or maintainer permissions
+#     - 2. Go to the project's Settings -> Repository page
+#     - 3. Expand the 'Protected tags' section
+#     - 4. Add a protected tag that matches the release tags (e.g. 'v*'), and allow only Maintainers to create it
+#     - 5. Press 'Protect'
+#   threat:
+#     - A developer, or an attacker who compromised a developer account, can create a release tag on unreviewed code, and have it built, published and deployed by the release pipeline.
+default tags_not_protected := false
+
+tags_not_protected {
+	input.protected_tags == []
+}
+
+# METADATA
+# scope: rule
+# title: Protected Tags Should Only Be Created By Maintainers
+# description: Some of the protected tags can be created by Developers. Protected tags are meant to restrict who can release the project, which is defeated if every developer can create them.
+# custom:
+#   severity: MEDIUM
+#   prerequisites: [not_archived]
+#   requiredEnrichers: [tagsList]
+#   remediationSteps:
+#     - 1. Make sure you have owner or maintainer permissions
+#     - 2. Go to the project's Settings -> Repository page
+#     - 3. Expand the 'Protected tags' section
+#     - 4. For each of the listed tags, set 'Allowed to create' to 'Maintainers'
+#   threat:
+#     - A developer, or an attacker who compromised a developer account, can create a release tag on unreviewed code, and have it built, published and deployed by the release pipeline.
+protected_tag_created_by_developers[violation] := true {
+	some index
+	tag := input.protected_tags[index]
+	level := tag.create_access_levels[_]
+	level.user_id == 0
+	level.group_id == 0
+	level.access_level > 0
+	level.access_level < 40
+	violation := {"tag": tag.name}
+}
+
+# METADATA
+# scope: rule
+# title: Production Environments Should Require Deployment Approval
+# description: Deployments to production environments should be approved, so that a pipeline can't deploy to production, and use its secrets, before someone reviews it. An environment requires approval only when it is protected with required approvals.
+# custom:
+#   severity: HIGH
+#   requiredEnrichers: [environmentsList]
+#   prerequisites: [premium, not_archived]
+#   remediationSteps:
+#     - 1. Make sure you have owner or maintainer permissions
+#     - 2. Go to the project's Settings -> CI/CD page
+#     - 3. Expand the 'Protected environments' section
+#     - 4. Protect the production environment, and add required approvals
+#   threat:
+#     - Any user who can run a pipeline can deploy unreviewed code to production, and read the production secrets, without approval.
+production_environment_without_approval[violation] := true {
+	some index
+	environment := input.environments[index]
+	is_production_environment(environment)
+	required_approvals(environment) == 0
+	violation := {"environment": environment.name}
+}
+
+# METADATA
+# scope: rule
+# title: Production Environments Should Only Be Deployed By Maintainers
+# description: Some of the production environments are not protected, or can be deployed by Developers. Protecting an environment restricts who can run the jobs that deploy to it.
+# custom:
+#   severity: MEDIUM
+#   requiredEnrichers: [environmentsList]
+#   prerequisites: [premium, not_archived]
+#   remediationSteps:
+#     - 1. Make sure you have owner or maintainer permissions
+#     - 2. Go to the project's Settings -> CI/CD page
+#     - 3. Expand the 'Protected environments' section
+#     - 4. Protect the production environment, and set 'Allowed to deploy' to 'Maintainers' or to specific users or groups
+#   threat:
+#     - Any developer can run a deployment job, and deploy unreviewed code to production or read the production secrets.
+production_environment_deployable_by_developers[violation] := true {
+	some index
+	environment := input.environments[index]
+	is_production_environment(environment)
+	deployable_by_developers(environment)
+	violation := {"environment": environment.name}
+}
+
+is_production_environment(environment) {
+	environment.tier == "production"
+}
+
+is_production_environment(environment) {
+	regex.match(`(?i)(^|[-_/ ])(prod|production|live)($|[-_/ ])`, environment.name)
+}
+
+required_approvals(environment) := 0 {
+	is_null(environment.protection)
+}
+
+required_approvals(environment) := approvals {
+	not is_null(environment.protection)
+	rules := [rule.required_approvals | rule := environment.protection.approval_rules[_]]
+	approvals := environment.protection.required_approval_count + sum(rules)
+}
+
+deployable_by_developers(environment) {
+	is_null(environment.protection)
+}
+
+deployable_by_developers(environment) {
+	level := environment.protection.deploy_access_levels[_]
+	level.user_id == 0
+	level.group_id == 0
+	level.access_level > 0
+	level.access_level < 40
+}
+
+# METADATA
+# scope: rule
+# title: Maintainer Access To The Project Should Not Be Granted Through Group Sharing
+# description: >
+#     Some users have Maintainer or Owner access to the project only because the project, or one of its ancestor groups, was shared with another group they belong to.
+#     Such access is managed by the owners of the other group, who can add members to it at any time, so it is easy to lose track of who can change the protections of the project.
+# custom:
+#   severity: MEDIUM
+#   requiredEnrichers: [effectiveMembersList]
+#   remediationSteps:
+#     - 1. Make sure you have owner permissions
+#     - 2. Go to the project (or the listed ancestor group) and press 'Manage' -> 'Members'
+#     - 3. Choose the 'Groups' tab, and lower the role of the shared group to 'Developer'
+#     - 4. Add the users that need Maintainer access as direct members instead
+#   threat:
+#     - An attacker who compromises any member of the shared group, or who is added to it by its owners, can remove the protections of the project and push malicious code to it.
+project_maintainer_access_through_shared_groups[violation] := true {
+	some index
+	member := input.effective_members[index]
+	membershipUtils.is_maintainer_through_sharing(member)
+	violation := membershipUtils.violation(member)
+}
@@ -0,0 +1,42 @@
+package team
+
+# METADATA
+# scope: rule
+# title: Groups Should Have Owners
+# description: The group has no members with the Owner role, including the members it inherits from its parent groups, so nobody is responsible for reviewing its membership and settings.
+# custom:
+#   severity: LOW
+#   remediationSteps:
+#     - 1. Make sure you have admin permissions
+#     - 2. Go to the group page
+#     - 3. Press 'Manage' ➝ 'Members'
+#     - 4. Change the role of a member who is responsible for the group to 'Owner'
+#   threat: Groups without an owner tend to accumulate members who no longer need their access, since nobody reviews their membership.
+default team_without_maintainers := false
+
+team_without_maintainers := true {
+    # 50 is the Owner access level
+    owners := [member | member := input.members[_]; member.access_level >= 50]
+    count(owners) == 0
+}
+
+# METADATA
+# scope: rule
+# title: Groups Should Not Be Shared With Maintainer Access On Many Projects And Groups
+# description: The group was shared with Maintainer or Owner access on many projects and groups, which grants its members administrative access to all of them. Groups should only be shared with the access that their members need.
+# custom:
+#   severity: MEDIUM
+#   remediationSteps:
+#     - 1. Go to the project or group that was shared with the group
+#     - 2. Press 'Manage' ➝ 'Members' and choose the 'Groups' tab
+#     - 3. Lower the role of the group to 'Developer', or remove it if its access is not required
+#   threat: Every member of the group, and any attacker who compromises one of their accounts, can change the protection and settings of many projects and push malicious code to them.
+default team_grants_admin_broadly := false
+
+team_grants_admin_broadly := true {
+    # 40 is the Maintainer access level
+    projects := [project | project := input.shared_projects[_]; project.access_level >= 40]
+    groups := [group | group := input.shared_groups[_]; group.access_level >= 40]
+    # the number of projects and groups from which a group is considered to have a broad access
+    count(projects) + count(groups) >= 10
+}
@@ -0,0 +1,95 @@
+package test
+
+import (
+	"testing"
+
+	githubcollected "github.com/Legit-Labs/legitify/internal/collected/github"
+	"github.com/Legit-Labs/legitify/internal/common/namespace"
+	"github.com/Legit-Labs/legitify/internal/common/scm_type"
+	"github.com/google/go-github/v53/github"
+)
+
+func newAppsMock(restrictions *bool, installations ...*githubcollected.AppInstallation) githubcollected.OrganizationApps {
+	return githubcollected.OrganizationApps{
+		Organization:         defaultOrg,
+		Installations:        installations,
+		OAuthAppRestrictions: restrictions,
+	}
+}
+
+func newInstallationMock(selection string, permissions map[string]string) *githubcollected.AppInstallation {
+	return &githubcollected.AppInstallation{
+		AppSlug:             "app",
+		RepositorySelection: selection,
+		Permissions:         permissions,
+	}
+}
+
+func TestApps(t *testing.T) {
+	tests := []struct {
+		name             string
+		policyName       string
+		shouldBeViolated bool
+		args             githubcollected.OrganizationApps
+	}{
+		{
+			name:             "app can write to all repositories",
+			policyName:       "app_has_write_access_to_all_repositories",
+			shouldBeViolated: true,
+			args:             newAppsMock(nil, newInstallationMock("all", map[string]string{"metadata": "read", "contents": "write"})),
+		},
+		{
+			name:             "app can write to selected repositories",
+			policyName:       "app_has_write_access_to_all_repositories",
+			shouldBeViolated: false,
+			args:             newAppsMock(nil, newInstallationMock("selected", map[string]string{"metadata": "read", "contents": "write"})),
+		},
+		{
+			name:             "app can read all repositories",
+			policyName:       "app_has_write_access_to_all_repositories",
+			shouldBeViolated: false,
+			args:             newAppsMock(nil, newInstallationMock("all", map[string]string{"metadata": "read", "contents": "read"})),
+		},
+		{
+			name:             "app can administer repositories",
+			policyName:       "app_has_admin_permissions",
+			shouldBeViolated: true,
+			args:             newAppsMock(nil, newInstallationMock("selected", map[string]string{"administration": "write"})),
+		},
+		{
+			name:             "app can manage organization members",
+			policyName:       "app_has_admin_permissions",
+			shouldBeViolated: true,
+			args:             newAppsMock(nil, newInstallationMock("all", map[string]string{"members": "write"})),
+		},
+		{
+			name:             "app has no administrative permissions",
+			policyName:       "app_has_admin_permissions",
+			shouldBeViolated: false,
+			args:             newAppsMock(nil, newInstallationMock("all", map[string]string{"administration": "read", "issues": "write"})),
+		},
+		{
+			name:             "oauth app restrictions are disabled",
+			policyName:       "oauth_app_restrictions_disabled",
+			shouldBeViolated: true,
+			args:             newAppsMock(github.Bool(false)),
+		},
+		{
+			name:             "oauth app restrictions are enabled",
+			policyName:       "oauth_app_restrictions_disabled",
+			shouldBeViolated: false,
+			args:             newAppsMock(github.Bool(true)),
+		},
+		{
+			name:             "oauth app restrictions are unknown",
+			policyName:       "oauth_app_restrictions_disabled",
+			shouldBeViolated: false,
+			args:             newAppsMock(nil),
+		},
+	}
+
+	for _, test := range tests {
+		PolicyTestTemplate(t, test.name, test.args,
+			namespace.Apps, test.policyName, test.shouldBeViolated, scm_type.GitHub)
+	}
+}
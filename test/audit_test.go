@@ -0,0 +1,192 @@
+package test
+
+import (
+	"testing"
+	"time"
+
+	githubcollected "github.com/Legit-Labs/legitify/internal/collected/github"
+	"github.com/Legit-Labs/legitify/internal/collected/gitlab_collected"
+	"github.com/Legit-Labs/legitify/internal/common/namespace"
+	"github.com/Legit-Labs/legitify/internal/common/scm_type"
+	"github.com/google/go-github/v53/github"
+	"github.com/xanzy/go-gitlab"
+)
+
+func newAuditLogMock(events ...*github.AuditEntry) githubcollected.AuditLog {
+	for _, event := range events {
+		event.Actor = github.String("actor")
+		event.Timestamp = &github.Timestamp{Time: time.Date(2023, 6, 1, 12, 0, 0, 0, time.UTC)}
+	}
+
+	return githubcollected.AuditLog{
+		Organization: &defaultOrg,
+		Since:        "2023-05-25T12:00:00Z",
+		Events:       append([]*github.AuditEntry{}, events...),
+	}
+}
+
+func TestAudit(t *testing.T) {
+	tests := []struct {
+		name             string
+		policyName       string
+		shouldBeViolated bool
+		args             githubcollected.AuditLog
+	}{
+		{
+			name:             "branch protection rule was deleted",
+			policyName:       "branch_protection_disabled",
+			shouldBeViolated: true,
+			args:             newAuditLogMock(&github.AuditEntry{Action: github.String("protected_branch.destroy"), Repo: github.String("org/repo"), Name: github.String("main")}),
+		},
+		{
+			name:             "branch protection rule was created",
+			policyName:       "branch_protection_disabled",
+			shouldBeViolated: false,
+			args:             newAuditLogMock(&github.AuditEntry{Action: github.String("protected_branch.create"), Repo: github.String("org/repo"), Name: github.String("main")}),
+		},
+		{
+			name:             "repository was made public",
+			policyName:       "repository_made_public",
+			shouldBeViolated: true,
+			args:             newAuditLogMock(&github.AuditEntry{Action: github.String("repo.access"), Repo: github.String("org/repo"), Visibility: github.String("public"), PreviousVisibility: github.String("private")}),
+		},
+		{
+			name:             "repository was made private",
+			policyName:       "repository_made_public",
+			shouldBeViolated: false,
+			args:             newAuditLogMock(&github.AuditEntry{Action: github.String("repo.access"), Repo: github.String("org/repo"), Visibility: github.String("private"), PreviousVisibility: github.String("public")}),
+		},
+		{
+			name:             "member was promoted to owner",
+			policyName:       "admin_added",
+			shouldBeViolated: true,
+			args:             newAuditLogMock(&github.AuditEntry{Action: github.String("org.update_member"), User: github.String("user"), Permission: github.String("admin"), OldPermission: github.String("read")}),
+		},
+		{
+			name:             "enterprise owner was added",
+			policyName:       "admin_added",
+			shouldBeViolated: true,
+			args:             newAuditLogMock(&github.AuditEntry{Action: github.String("business.add_admin"), User: github.String("user")}),
+		},
+		{
+			name:             "member was added",
+			policyName:       "admin_added",
+			shouldBeViolated: false,
+			args:             newAuditLogMock(&github.AuditEntry{Action: github.String("org.add_member"), User: github.String("user"), Permission: github.String("read")}),
+		},
+		{
+			name:             "deploy key with write access was added",
+			policyName:       "deploy_key_with_write_access_added",
+			shouldBeViolated: true,
+			args:             newAuditLogMock(&github.AuditEntry{Action: github.String("public_key.create"), Repo: github.String("org/repo"), ReadOnly: github.String("false")}),
+		},
+		{
+			name:             "read only deploy key was added",
+			policyName:       "deploy_key_with_write_access_added",
+			shouldBeViolated: false,
+			args:             newAuditLogMock(&github.AuditEntry{Action: github.String("public_key.create"), Repo: github.String("org/repo"), ReadOnly: github.String("true")}),
+		},
+		{
+			name:             "secret scanning push protection was disabled",
+			policyName:       "secret_scanning_disabled",
+			shouldBeViolated: true,
+			args:             newAuditLogMock(&github.AuditEntry{Action: github.String("repository_secret_scanning_push_protection.disable"), Repo: github.String("org/repo")}),
+		},
+		{
+			name:             "secret scanning was enabled",
+			policyName:       "secret_scanning_disabled",
+			shouldBeViolated: false,
+			args:             newAuditLogMock(&github.AuditEntry{Action: github.String("repository_secret_scanning.enable"), Repo: github.String("org/repo")}),
+		},
+		{
+			name:             "ip allow list entry was added",
+			policyName:       "ip_allow_list_changed",
+			shouldBeViolated: true,
+			args:             newAuditLogMock(&github.AuditEntry{Action: github.String("ip_allow_list_entry.create")}),
+		},
+		{
+			name:             "no risky events",
+			policyName:       "ip_allow_list_changed",
+			shouldBeViolated: false,
+			args:             newAuditLogMock(),
+		},
+	}
+
+	for _, test := range tests {
+		PolicyTestTemplate(t, test.name, test.args,
+			namespace.Audit, test.policyName, test.shouldBeViolated, scm_type.GitHub)
+	}
+}
+
+func newGitlabAuditLogMock(details ...gitlab.AuditEventDetails) gitlab_collected.AuditLog {
+	createdAt := time.Date(2023, 6, 1, 12, 0, 0, 0, time.UTC)
+	auditLog := gitlab_collected.AuditLog{
+		Group:  &gitlab.Group{FullPath: "group"},
+		Since:  "2023-05-25T12:00:00Z",
+		Events: []*gitlab.AuditEvent{},
+	}
+	for _, detail := range details {
+		detail.AuthorName = "author"
+		detail.EntityPath = "group/project"
+		auditLog.Events = append(auditLog.Events, &gitlab.AuditEvent{Details: detail, CreatedAt: &createdAt})
+	}
+
+	return auditLog
+}
+
+func TestGitlabAudit(t *testing.T) {
+	tests := []struct {
+		name             string
+		policyName       string
+		shouldBeViolated bool
+		args             gitlab_collected.AuditLog
+	}{
+		{
+			name:             "protected branch was removed",
+			policyName:       "branch_protection_removed",
+			shouldBeViolated: true,
+			args:             newGitlabAuditLogMock(gitlab.AuditEventDetails{Remove: "protected_branch", TargetDetails: "main"}),
+		},
+		{
+			name:             "protected branch was added",
+			policyName:       "branch_protection_removed",
+			shouldBeViolated: false,
+			args:             newGitlabAuditLogMock(gitlab.AuditEventDetails{Add: "protected_branch", TargetDetails: "main"}),
+		},
+		{
+			name:             "project was made public",
+			policyName:       "repository_made_public",
+			shouldBeViolated: true,
+			args:             newGitlabAuditLogMock(gitlab.AuditEventDetails{Change: "visibility", From: "Private", To: "Public"}),
+		},
+		{
+			name:             "project was made internal",
+			policyName:       "repository_made_public",
+			shouldBeViolated: false,
+			args:             newGitlabAuditLogMock(gitlab.AuditEventDetails{Change: "visibility", From: "Private", To: "Internal"}),
+		},
+		{
+			name:             "owner was added",
+			policyName:       "admin_added",
+			shouldBeViolated: true,
+			args:             newGitlabAuditLogMock(gitlab.AuditEventDetails{Add: "user_access", As: "Owner", TargetDetails: "user"}),
+		},
+		{
+			name:             "member was promoted to owner",
+			policyName:       "admin_added",
+			shouldBeViolated: true,
+			args:             newGitlabAuditLogMock(gitlab.AuditEventDetails{Change: "access_level", From: "Developer", To: "Owner", TargetDetails: "user"}),
+		},
+		{
+			name:             "developer was added",
+			policyName:       "admin_added",
+			shouldBeViolated: false,
+			args:             newGitlabAuditLogMock(gitlab.AuditEventDetails{Add: "user_access", As: "Developer", TargetDetails: "user"}),
+		},
+	}
+
+	for _, test := range tests {
+		PolicyTestTemplate(t, test.name, test.args,
+			namespace.Audit, test.policyName, test.shouldBeViolated, scm_type.GitLab)
+	}
+}
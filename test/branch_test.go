@@ -0,0 +1,90 @@
+package test
+
+import (
+	"testing"
+
+	"github.com/Legit-Labs/legitify/internal/clients/github/types"
+	githubcollected "github.com/Legit-Labs/legitify/internal/collected/github"
+	"github.com/Legit-Labs/legitify/internal/common/namespace"
+	"github.com/Legit-Labs/legitify/internal/common/scm_type"
+	"github.com/google/go-github/v53/github"
+)
+
+func newBranchMock(protection *githubcollected.GitHubQLBranchProtectionRule, rules ...*types.RepositoryRule) githubcollected.Branch {
+	return githubcollected.Branch{
+		Owner: "org",
+		Repository: &githubcollected.GitHubQLRepository{
+			Name: "REPO",
+			Url:  "https://github.com/org/REPO",
+		},
+		Branch: &githubcollected.GitHubQLBranch{
+			Name:                 github.String("release/1.0"),
+			BranchProtectionRule: protection,
+		},
+		MatchedPatterns: []string{"release/*"},
+		RulesSet:        rules,
+	}
+}
+
+func TestBranch(t *testing.T) {
+	tests := []struct {
+		name             string
+		policyName       string
+		shouldBeViolated bool
+		args             githubcollected.Branch
+	}{
+		{
+			name:             "release branch is not protected",
+			policyName:       "missing_branch_protection",
+			shouldBeViolated: true,
+			args:             newBranchMock(nil),
+		},
+		{
+			name:             "release branch is protected by a branch protection rule",
+			policyName:       "missing_branch_protection",
+			shouldBeViolated: false,
+			args:             newBranchMock(&githubcollected.GitHubQLBranchProtectionRule{}),
+		},
+		{
+			name:             "release branch is protected by a ruleset",
+			policyName:       "missing_branch_protection",
+			shouldBeViolated: false,
+			args:             newBranchMock(nil, &types.RepositoryRule{Type: "pull_request"}),
+		},
+		{
+			name:             "release branch is not protected against deletion",
+			policyName:       "missing_branch_protection_deletion",
+			shouldBeViolated: true,
+			args:             newBranchMock(nil),
+		},
+		{
+			name:             "release branch protection allows force pushes",
+			policyName:       "missing_branch_protection_force_push",
+			shouldBeViolated: true,
+			args:             newBranchMock(&githubcollected.GitHubQLBranchProtectionRule{AllowsForcePushes: github.Bool(true)}),
+		},
+		{
+			name:             "release branch protection doesn't allow force pushes",
+			policyName:       "missing_branch_protection_force_push",
+			shouldBeViolated: false,
+			args:             newBranchMock(&githubcollected.GitHubQLBranchProtectionRule{AllowsForcePushes: github.Bool(false)}),
+		},
+		{
+			name:             "release branch doesn't require code review",
+			policyName:       "code_review_not_required",
+			shouldBeViolated: true,
+			args:             newBranchMock(&githubcollected.GitHubQLBranchProtectionRule{RequiredApprovingReviewCount: github.Int(0)}),
+		},
+		{
+			name:             "release branch requires code review",
+			policyName:       "code_review_not_required",
+			shouldBeViolated: false,
+			args:             newBranchMock(&githubcollected.GitHubQLBranchProtectionRule{RequiredApprovingReviewCount: github.Int(1)}),
+		},
+	}
+
+	for _, test := range tests {
+		PolicyTestTemplate(t, test.name, test.args,
+			namespace.Branch, test.policyName, test.shouldBeViolated, scm_type.GitHub)
+	}
+}
@@ -2,8 +2,10 @@ package test
 
 import (
 	githubcollected "github.com/Legit-Labs/legitify/internal/collected/github"
+	gitlabcollected "github.com/Legit-Labs/legitify/internal/collected/gitlab_collected"
 	"github.com/Legit-Labs/legitify/internal/common/namespace"
 	"github.com/Legit-Labs/legitify/internal/common/scm_type"
+	gitlab2 "github.com/xanzy/go-gitlab"
 	"testing"
 )
 
@@ -46,7 +48,103 @@ func TestEnterpriseNotificationRestrictionPolicy(t *testing.T) {
 	}
 }
 
-func enterpriseTestTemplate(t *testing.T, name string, mockData githubcollected.Enterprise, testedPolicyName string, expectFailure bool, scmType scm_type.ScmType) {
+func TestGitlabServerSettings(t *testing.T) {
+	makeMockData := func(modify func(settings *gitlab2.Settings)) *gitlabcollected.Server {
+		settings := &gitlab2.Settings{
+			SignupEnabled:                       true,
+			RequireAdminApprovalAfterUserSignup: true,
+			DomainAllowlist:                     []string{"example.com"},
+			AdminMode:                           true,
+			RequireTwoFactorAuthentication:      true,
+			TwoFactorGracePeriod:                48,
+			PasswordAuthenticationEnabledForWeb: true,
+			MinimumPasswordLength:               12,
+			ImportSources:                       []string{"github"},
+			MaxPersonalAccessTokenLifetime:      365,
+		}
+		modify(settings)
+		return gitlabcollected.NewServer("https://gitlab.example.com", settings, nil, nil)
+	}
+	unmodified := func(*gitlab2.Settings) {}
+
+	tests := []struct {
+		name             string
+		policyName       string
+		shouldBeViolated bool
+		args             *gitlabcollected.Server
+	}{
+		{"sign-up doesn't require admin approval", "signup_does_not_require_admin_approval", true,
+			makeMockData(func(s *gitlab2.Settings) { s.RequireAdminApprovalAfterUserSignup = false })},
+		{"sign-up requires admin approval", "signup_does_not_require_admin_approval", false, makeMockData(unmodified)},
+		{"sign-up is disabled", "signup_does_not_require_admin_approval", false,
+			makeMockData(func(s *gitlab2.Settings) { s.SignupEnabled = false; s.RequireAdminApprovalAfterUserSignup = false })},
+		{"sign-up is not restricted to domains", "signup_not_restricted_to_allowed_domains", true,
+			makeMockData(func(s *gitlab2.Settings) { s.DomainAllowlist = nil })},
+		{"sign-up is restricted to domains", "signup_not_restricted_to_allowed_domains", false, makeMockData(unmodified)},
+		{"admin mode is disabled", "admin_mode_not_enabled", true,
+			makeMockData(func(s *gitlab2.Settings) { s.AdminMode = false })},
+		{"admin mode is enabled", "admin_mode_not_enabled", false, makeMockData(unmodified)},
+		{"2FA grace period is two weeks", "server_allows_excessive_mfa_grace_period", true,
+			makeMockData(func(s *gitlab2.Settings) { s.TwoFactorGracePeriod = 336 })},
+		{"2FA grace period is two days", "server_allows_excessive_mfa_grace_period", false, makeMockData(unmodified)},
+		{"password may be 8 characters long", "minimum_password_length_too_short", true,
+			makeMockData(func(s *gitlab2.Settings) { s.MinimumPasswordLength = 8 })},
+		{"password authentication is disabled", "minimum_password_length_too_short", false,
+			makeMockData(func(s *gitlab2.Settings) { s.PasswordAuthenticationEnabledForWeb = false; s.MinimumPasswordLength = 8 })},
+		{"import from repository URL is enabled", "unsafe_import_sources_enabled", true,
+			makeMockData(func(s *gitlab2.Settings) { s.ImportSources = []string{"github", "git"} })},
+		{"only import from GitHub is enabled", "unsafe_import_sources_enabled", false, makeMockData(unmodified)},
+		{"system hooks are allowed to local network", "system_hooks_are_allowed_to_be_sent_to_local_network", true,
+			makeMockData(func(s *gitlab2.Settings) { s.AllowLocalRequestsFromSystemHooks = true })},
+		{"system hooks are not allowed to local network", "system_hooks_are_allowed_to_be_sent_to_local_network", false, makeMockData(unmodified)},
+		{"personal access token lifetime is not limited", "personal_access_token_lifetime_not_limited", true,
+			makeMockData(func(s *gitlab2.Settings) { s.MaxPersonalAccessTokenLifetime = 0 })},
+		{"personal access token lifetime is a year", "personal_access_token_lifetime_not_limited", false, makeMockData(unmodified)},
+	}
+
+	for _, test := range tests {
+		enterpriseTestTemplate(t, test.name, test.args, test.policyName, test.shouldBeViolated, scm_type.GitLab)
+	}
+}
+
+func TestGitlabServerAdminsAndAuditEvents(t *testing.T) {
+	makeMockData := func(admin *gitlab2.User, destinations []*gitlabcollected.AuditEventStreamingDestination) *gitlabcollected.Server {
+		admin.Username = "root"
+		admin.IsAdmin = true
+		return gitlabcollected.NewServer("https://gitlab.example.com", &gitlab2.Settings{}, []*gitlab2.User{admin}, destinations)
+	}
+	destination := &gitlabcollected.AuditEventStreamingDestination{ID: "1", Name: "siem", DestinationURL: "https://siem.example.com"}
+
+	tests := []struct {
+		name             string
+		policyName       string
+		shouldBeViolated bool
+		args             *gitlabcollected.Server
+	}{
+		{"active admin without 2FA", "admins_without_two_factor_authentication", true,
+			makeMockData(&gitlab2.User{State: "active"}, nil)},
+		{"active admin with 2FA", "admins_without_two_factor_authentication", false,
+			makeMockData(&gitlab2.User{State: "active", TwoFactorEnabled: true}, nil)},
+		{"blocked admin without 2FA", "admins_without_two_factor_authentication", false,
+			makeMockData(&gitlab2.User{State: "blocked"}, nil)},
+		{"blocked admin", "inactive_users_with_admin_privileges", true,
+			makeMockData(&gitlab2.User{State: "blocked", TwoFactorEnabled: true}, nil)},
+		{"active admin", "inactive_users_with_admin_privileges", false,
+			makeMockData(&gitlab2.User{State: "active", TwoFactorEnabled: true}, nil)},
+		{"no audit event streaming destinations", "audit_event_streaming_not_configured", true,
+			makeMockData(&gitlab2.User{State: "active"}, []*gitlabcollected.AuditEventStreamingDestination{})},
+		{"audit events are streamed", "audit_event_streaming_not_configured", false,
+			makeMockData(&gitlab2.User{State: "active"}, []*gitlabcollected.AuditEventStreamingDestination{destination})},
+		{"audit event streaming destinations weren't collected", "audit_event_streaming_not_configured", false,
+			makeMockData(&gitlab2.User{State: "active"}, nil)},
+	}
+
+	for _, test := range tests {
+		enterpriseTestTemplate(t, test.name, test.args, test.policyName, test.shouldBeViolated, scm_type.GitLab)
+	}
+}
+
+func enterpriseTestTemplate(t *testing.T, name string, mockData interface{}, testedPolicyName string, expectFailure bool, scmType scm_type.ScmType) {
 	ns := namespace.Enterprise
 	PolicyTestTemplate(t, name, mockData, ns, testedPolicyName, expectFailure, scmType)
 }
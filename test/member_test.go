@@ -7,7 +7,11 @@ import (
 	"github.com/Legit-Labs/legitify/internal/common/scm_type"
 
 	githubcollected "github.com/Legit-Labs/legitify/internal/collected/github"
+	gitlabcollected "github.com/Legit-Labs/legitify/internal/collected/gitlab_collected"
 	"github.com/Legit-Labs/legitify/internal/common/namespace"
+	"github.com/Legit-Labs/legitify/internal/common/permissions"
+	"github.com/google/go-github/v53/github"
+	gitlab2 "github.com/xanzy/go-gitlab"
 )
 
 type memberMockConfiguration struct {
@@ -122,3 +126,257 @@ func TestMember(t *testing.T) {
 			namespace.Member, test.policyName, test.shouldBeViolated, scm_type.GitHub)
 	}
 }
+
+func newOutsiderMock(lastActive time.Time, permission string) githubcollected.OutsideCollaborator {
+	return githubcollected.OutsideCollaborator{
+		User:       &github.User{Login: github.String("outsider")},
+		LastActive: int(lastActive.UnixNano()),
+		Repositories: []githubcollected.CollaboratorRepository{
+			{Name: "REPO", Permission: permission},
+		},
+	}
+}
+
+func TestMemberOutsiders(t *testing.T) {
+	tests := []struct {
+		name             string
+		policyName       string
+		shouldBeViolated bool
+		args             githubcollected.OrganizationMembers
+	}{
+		{
+			name:             "outside collaborator has the admin role",
+			policyName:       "outside_collaborator_with_admin_permissions",
+			shouldBeViolated: true,
+			args: githubcollected.OrganizationMembers{
+				OutsideCollaborators: []githubcollected.OutsideCollaborator{newOutsiderMock(time.Now(), "admin")},
+			},
+		},
+		{
+			name:             "outside collaborator has the maintain role",
+			policyName:       "outside_collaborator_with_admin_permissions",
+			shouldBeViolated: true,
+			args: githubcollected.OrganizationMembers{
+				OutsideCollaborators: []githubcollected.OutsideCollaborator{newOutsiderMock(time.Now(), "maintain")},
+			},
+		},
+		{
+			name:             "outside collaborator has the write role",
+			policyName:       "outside_collaborator_with_admin_permissions",
+			shouldBeViolated: false,
+			args: githubcollected.OrganizationMembers{
+				OutsideCollaborators: []githubcollected.OutsideCollaborator{newOutsiderMock(time.Now(), "write")},
+			},
+		},
+		{
+			name:             "outside collaborator is stale",
+			policyName:       "stale_outside_collaborator",
+			shouldBeViolated: true,
+			args: githubcollected.OrganizationMembers{
+				OutsideCollaborators: []githubcollected.OutsideCollaborator{newOutsiderMock(time.Now().AddDate(0, -9, 0), "read")},
+			},
+		},
+		{
+			name:             "outside collaborator is active",
+			policyName:       "stale_outside_collaborator",
+			shouldBeViolated: false,
+			args: githubcollected.OrganizationMembers{
+				OutsideCollaborators: []githubcollected.OutsideCollaborator{newOutsiderMock(time.Now().AddDate(0, -1, 0), "read")},
+			},
+		},
+		{
+			name:             "invitation has expired",
+			policyName:       "expired_pending_invitation",
+			shouldBeViolated: true,
+			args: githubcollected.OrganizationMembers{
+				PendingInvitations: []githubcollected.PendingInvitation{
+					{Email: "user@example.com", Role: "direct_member", CreatedAt: int(time.Now().AddDate(0, 0, -8).UnixNano()), Expired: true},
+				},
+			},
+		},
+		{
+			name:             "repository invitation has expired",
+			policyName:       "expired_pending_invitation",
+			shouldBeViolated: true,
+			args: githubcollected.OrganizationMembers{
+				PendingInvitations: []githubcollected.PendingInvitation{
+					{Login: "user", Role: "write", Repository: "REPO", CreatedAt: int(time.Now().AddDate(0, 0, -8).UnixNano()), Expired: true},
+				},
+			},
+		},
+		{
+			name:             "invitation is pending",
+			policyName:       "expired_pending_invitation",
+			shouldBeViolated: false,
+			args: githubcollected.OrganizationMembers{
+				PendingInvitations: []githubcollected.PendingInvitation{
+					{Login: "user", Role: "write", Repository: "REPO", CreatedAt: int(time.Now().AddDate(0, 0, -1).UnixNano())},
+				},
+			},
+		},
+	}
+
+	for _, test := range tests {
+		test.args.Organization = defaultOrg
+		PolicyTestTemplate(t, test.name, test.args,
+			namespace.Member, test.policyName, test.shouldBeViolated, scm_type.GitHub)
+	}
+}
+
+func TestGitlabMemberAccessTokens(t *testing.T) {
+	now := time.Now()
+	old := now.AddDate(0, -4, 0)
+	expiresAt := gitlab2.ISOTime(now.AddDate(0, 3, 0))
+	makeMockData := func(isAdmin bool, token *gitlabcollected.AccessToken) gitlabcollected.Member {
+		token.Name = "automation"
+		token.Active = true
+		return gitlabcollected.Member{
+			User:         &gitlab2.User{IsAdmin: isAdmin},
+			AccessTokens: []*gitlabcollected.AccessToken{token},
+		}
+	}
+
+	tests := []struct {
+		name             string
+		policyName       string
+		shouldBeViolated bool
+		args             gitlabcollected.Member
+	}{
+		{"personal access token never expires", "personal_access_token_without_expiration", true,
+			makeMockData(false, &gitlabcollected.AccessToken{Type: gitlabcollected.PersonalAccessToken, Scopes: []string{"read_api"}, CreatedAt: &now})},
+		{"impersonation token never expires", "personal_access_token_without_expiration", true,
+			makeMockData(false, &gitlabcollected.AccessToken{Type: gitlabcollected.ImpersonationAccessToken, Scopes: []string{"read_api"}, CreatedAt: &now})},
+		{"personal access token expires", "personal_access_token_without_expiration", false,
+			makeMockData(false, &gitlabcollected.AccessToken{Type: gitlabcollected.PersonalAccessToken, Scopes: []string{"read_api"}, CreatedAt: &now, ExpiresAt: &expiresAt})},
+		{"admin personal access token has api scope", "admin_personal_access_token_with_api_scope", true,
+			makeMockData(true, &gitlabcollected.AccessToken{Type: gitlabcollected.PersonalAccessToken, Scopes: []string{"api"}, CreatedAt: &now, ExpiresAt: &expiresAt})},
+		{"non admin personal access token has api scope", "admin_personal_access_token_with_api_scope", false,
+			makeMockData(false, &gitlabcollected.AccessToken{Type: gitlabcollected.PersonalAccessToken, Scopes: []string{"api"}, CreatedAt: &now, ExpiresAt: &expiresAt})},
+		{"personal access token was never used", "unused_personal_access_token", true,
+			makeMockData(false, &gitlabcollected.AccessToken{Type: gitlabcollected.PersonalAccessToken, Scopes: []string{"read_api"}, CreatedAt: &old, ExpiresAt: &expiresAt})},
+		{"personal access token was used recently", "unused_personal_access_token", false,
+			makeMockData(false, &gitlabcollected.AccessToken{Type: gitlabcollected.PersonalAccessToken, Scopes: []string{"read_api"}, CreatedAt: &old, LastUsedAt: &now, ExpiresAt: &expiresAt})},
+		{"impersonation token usage is unknown", "unused_personal_access_token", false,
+			makeMockData(false, &gitlabcollected.AccessToken{Type: gitlabcollected.ImpersonationAccessToken, Scopes: []string{"read_api"}, CreatedAt: &old, ExpiresAt: &expiresAt})},
+	}
+
+	for _, test := range tests {
+		PolicyTestTemplate(t, test.name, test.args,
+			namespace.Member, test.policyName, test.shouldBeViolated, scm_type.GitLab)
+	}
+}
+
+func newIdentityMember(twoFactorDisabled *bool, identity *githubcollected.ExternalIdentity) githubcollected.OrganizationMember {
+	return githubcollected.OrganizationMember{
+		User:              &github.User{Login: github.String("member")},
+		LastActive:        -1,
+		TwoFactorDisabled: twoFactorDisabled,
+		ExternalIdentity:  identity,
+	}
+}
+
+func TestMemberIdentities(t *testing.T) {
+	enforcing := githubcollected.NewExtendedOrg(&github.Organization{TwoFactorRequirementEnabled: github.Bool(true)}, permissions.OrgRoleOwner)
+	linked := &githubcollected.ExternalIdentity{Guid: "guid", SamlNameID: "member@example.com"}
+	deprovisioned := &githubcollected.ExternalIdentity{Guid: "guid", ScimUsername: "member@example.com", ScimActive: github.Bool(false)}
+	provisioned := &githubcollected.ExternalIdentity{Guid: "guid", ScimUsername: "member@example.com", ScimActive: github.Bool(true)}
+
+	tests := []struct {
+		name             string
+		policyName       string
+		shouldBeViolated bool
+		args             githubcollected.OrganizationMembers
+	}{
+		{
+			name:             "member without two-factor authentication",
+			policyName:       "member_without_two_factor_authentication",
+			shouldBeViolated: true,
+			args: githubcollected.OrganizationMembers{
+				Organization: defaultOrg,
+				Members:      []githubcollected.OrganizationMember{newIdentityMember(github.Bool(true), nil)},
+			},
+		},
+		{
+			name:             "member without two-factor authentication when the organization enforces it",
+			policyName:       "member_without_two_factor_authentication",
+			shouldBeViolated: false,
+			args: githubcollected.OrganizationMembers{
+				Organization: enforcing,
+				Members:      []githubcollected.OrganizationMember{newIdentityMember(github.Bool(true), nil)},
+			},
+		},
+		{
+			name:             "member with two-factor authentication",
+			policyName:       "member_without_two_factor_authentication",
+			shouldBeViolated: false,
+			args: githubcollected.OrganizationMembers{
+				Organization: defaultOrg,
+				Members:      []githubcollected.OrganizationMember{newIdentityMember(github.Bool(false), nil)},
+			},
+		},
+		{
+			name:             "member two-factor authentication status is unknown",
+			policyName:       "member_without_two_factor_authentication",
+			shouldBeViolated: false,
+			args: githubcollected.OrganizationMembers{
+				Organization: defaultOrg,
+				Members:      []githubcollected.OrganizationMember{newIdentityMember(nil, nil)},
+			},
+		},
+		{
+			name:             "member without a linked sso identity",
+			policyName:       "member_without_linked_sso_identity",
+			shouldBeViolated: true,
+			args: githubcollected.OrganizationMembers{
+				Organization: defaultOrg,
+				HasSamlSso:   github.Bool(true),
+				Members:      []githubcollected.OrganizationMember{newIdentityMember(nil, nil)},
+			},
+		},
+		{
+			name:             "member with a linked sso identity",
+			policyName:       "member_without_linked_sso_identity",
+			shouldBeViolated: false,
+			args: githubcollected.OrganizationMembers{
+				Organization: defaultOrg,
+				HasSamlSso:   github.Bool(true),
+				Members:      []githubcollected.OrganizationMember{newIdentityMember(nil, linked)},
+			},
+		},
+		{
+			name:             "organization without saml sso",
+			policyName:       "member_without_linked_sso_identity",
+			shouldBeViolated: false,
+			args: githubcollected.OrganizationMembers{
+				Organization: defaultOrg,
+				HasSamlSso:   github.Bool(false),
+				Members:      []githubcollected.OrganizationMember{newIdentityMember(nil, nil)},
+			},
+		},
+		{
+			name:             "member was deprovisioned by scim",
+			policyName:       "deprovisioned_member_still_present",
+			shouldBeViolated: true,
+			args: githubcollected.OrganizationMembers{
+				Organization: defaultOrg,
+				HasSamlSso:   github.Bool(true),
+				Members:      []githubcollected.OrganizationMember{newIdentityMember(nil, deprovisioned)},
+			},
+		},
+		{
+			name:             "member is provisioned by scim",
+			policyName:       "deprovisioned_member_still_present",
+			shouldBeViolated: false,
+			args: githubcollected.OrganizationMembers{
+				Organization: defaultOrg,
+				HasSamlSso:   github.Bool(true),
+				Members:      []githubcollected.OrganizationMember{newIdentityMember(nil, provisioned), newIdentityMember(nil, linked)},
+			},
+		},
+	}
+
+	for _, test := range tests {
+		PolicyTestTemplate(t, test.name, test.args,
+			namespace.Member, test.policyName, test.shouldBeViolated, scm_type.GitHub)
+	}
+}
@@ -7,7 +7,9 @@ import (
 	"time"
 
 	githubcollected "github.com/Legit-Labs/legitify/internal/collected/github"
+	gitlabcollected "github.com/Legit-Labs/legitify/internal/collected/gitlab_collected"
 	"github.com/Legit-Labs/legitify/internal/common/namespace"
+	gitlab2 "github.com/xanzy/go-gitlab"
 )
 
 type organizationMockConfiguration struct {
@@ -177,3 +179,150 @@ func TestOrganization(t *testing.T) {
 			namespace.Organization, test.policyName, test.shouldBeViolated, scm_type.GitHub)
 	}
 }
+
+func TestGitlabGroupCI(t *testing.T) {
+	expiresAt := time.Now().AddDate(0, 6, 0)
+	tests := []struct {
+		name             string
+		policyName       string
+		shouldBeViolated bool
+		args             gitlabcollected.Organization
+	}{
+		{
+			name:             "group secret variable is not protected",
+			policyName:       "group_secret_variable_not_masked_or_protected",
+			shouldBeViolated: true,
+			args: gitlabcollected.Organization{
+				Variables: []*gitlabcollected.CIVariable{{Key: "DEPLOY_TOKEN", Masked: true}},
+			},
+		},
+		{
+			name:             "group secret variable is masked and protected",
+			policyName:       "group_secret_variable_not_masked_or_protected",
+			shouldBeViolated: false,
+			args: gitlabcollected.Organization{
+				Variables: []*gitlabcollected.CIVariable{{Key: "DEPLOY_TOKEN", Masked: true, Protected: true}},
+			},
+		},
+		{
+			name:             "group non secret variable is not protected",
+			policyName:       "group_secret_variable_not_masked_or_protected",
+			shouldBeViolated: false,
+			args: gitlabcollected.Organization{
+				Variables: []*gitlabcollected.CIVariable{{Key: "GO_VERSION"}},
+			},
+		},
+		{
+			name:             "group deploy token never expires",
+			policyName:       "group_deploy_token_without_expiration",
+			shouldBeViolated: true,
+			args: gitlabcollected.Organization{
+				DeployTokens: []*gitlab2.DeployToken{{Name: "registry", Scopes: []string{"read_registry"}}},
+			},
+		},
+		{
+			name:             "group deploy token expires",
+			policyName:       "group_deploy_token_without_expiration",
+			shouldBeViolated: false,
+			args: gitlabcollected.Organization{
+				DeployTokens: []*gitlab2.DeployToken{{Name: "registry", Scopes: []string{"read_registry"}, ExpiresAt: &expiresAt}},
+			},
+		},
+		{
+			name:             "revoked group deploy token never expires",
+			policyName:       "group_deploy_token_without_expiration",
+			shouldBeViolated: false,
+			args: gitlabcollected.Organization{
+				DeployTokens: []*gitlab2.DeployToken{{Name: "registry", Scopes: []string{"read_registry"}, Revoked: true}},
+			},
+		},
+	}
+
+	for _, test := range tests {
+		PolicyTestTemplate(t, test.name, test.args,
+			namespace.Organization, test.policyName, test.shouldBeViolated, scm_type.GitLab)
+	}
+}
+
+func TestGitlabGroupAccessTokens(t *testing.T) {
+	now := time.Now()
+	old := now.AddDate(-1, 0, 0)
+	expiresAt := gitlab2.ISOTime(now.AddDate(0, 3, 0))
+	makeMockData := func(token *gitlabcollected.AccessToken) gitlabcollected.Organization {
+		token.Name = "release"
+		token.Type = gitlabcollected.GroupAccessToken
+		token.Active = true
+		return gitlabcollected.Organization{
+			Group:        &gitlab2.Group{},
+			AccessTokens: []*gitlabcollected.AccessToken{token},
+		}
+	}
+
+	tests := []struct {
+		name             string
+		policyName       string
+		shouldBeViolated bool
+		token            *gitlabcollected.AccessToken
+	}{
+		{"group access token never expires", "group_access_token_without_expiration", true,
+			&gitlabcollected.AccessToken{Scopes: []string{"read_api"}, CreatedAt: &now}},
+		{"revoked group access token never expires", "group_access_token_without_expiration", false,
+			&gitlabcollected.AccessToken{Scopes: []string{"read_api"}, CreatedAt: &now, Revoked: true}},
+		{"owner group access token has api scope", "group_access_token_with_owner_api_scope", true,
+			&gitlabcollected.AccessToken{Scopes: []string{"read_repository", "api"}, AccessLevel: gitlab2.OwnerPermissions, CreatedAt: &now, ExpiresAt: &expiresAt}},
+		{"owner group access token has read_api scope", "group_access_token_with_owner_api_scope", false,
+			&gitlabcollected.AccessToken{Scopes: []string{"read_api"}, AccessLevel: gitlab2.OwnerPermissions, CreatedAt: &now, ExpiresAt: &expiresAt}},
+		{"group access token was not used recently", "unused_group_access_token", true,
+			&gitlabcollected.AccessToken{Scopes: []string{"read_api"}, CreatedAt: &old, LastUsedAt: &old, ExpiresAt: &expiresAt}},
+		{"group access token was used recently", "unused_group_access_token", false,
+			&gitlabcollected.AccessToken{Scopes: []string{"read_api"}, CreatedAt: &old, LastUsedAt: &now, ExpiresAt: &expiresAt}},
+	}
+
+	for _, test := range tests {
+		PolicyTestTemplate(t, test.name, makeMockData(test.token),
+			namespace.Organization, test.policyName, test.shouldBeViolated, scm_type.GitLab)
+	}
+}
+
+func TestGitlabGroupHierarchy(t *testing.T) {
+	makeMember := func(grants ...*gitlabcollected.MembershipGrant) *gitlabcollected.EffectiveMember {
+		member := &gitlabcollected.EffectiveMember{ID: 1, Username: "user", Grants: grants}
+		for _, grant := range grants {
+			if grant.AccessLevel > member.AccessLevel {
+				member.AccessLevel = grant.AccessLevel
+			}
+		}
+		return member
+	}
+	shared := &gitlabcollected.MembershipGrant{Source: gitlabcollected.MembershipShared, Path: "other", AccessLevel: gitlab2.MaintainerPermissions}
+	inherited := &gitlabcollected.MembershipGrant{Source: gitlabcollected.MembershipInherited, Path: "parent", AccessLevel: gitlab2.MaintainerPermissions}
+	sharedAsDeveloper := &gitlabcollected.MembershipGrant{Source: gitlabcollected.MembershipShared, Path: "other", AccessLevel: gitlab2.DeveloperPermissions}
+
+	tests := []struct {
+		name             string
+		policyName       string
+		shouldBeViolated bool
+		args             gitlabcollected.Organization
+	}{
+		{"subgroup inherits the 2FA requirement", "two_factor_authentication_not_required_for_group", false,
+			gitlabcollected.Organization{Group: &gitlab2.Group{}, EffectiveSettings: &gitlabcollected.EffectiveSettings{RequireTwoFactorAuthentication: true}}},
+		{"no group in the hierarchy requires 2FA", "two_factor_authentication_not_required_for_group", true,
+			gitlabcollected.Organization{Group: &gitlab2.Group{}, EffectiveSettings: &gitlabcollected.EffectiveSettings{}}},
+		{"subgroup inherits a short 2FA grace period", "group_allows_excessive_mfa_grace_period", false,
+			gitlabcollected.Organization{Group: &gitlab2.Group{TwoFactorGracePeriod: 500},
+				EffectiveSettings: &gitlabcollected.EffectiveSettings{RequireTwoFactorAuthentication: true, TwoFactorGracePeriod: 48}}},
+		{"subgroup inherits forking prevention from the top-level group", "collaborators_can_fork_repositories_to_external_namespaces", false,
+			gitlabcollected.Organization{Group: &gitlab2.Group{}, EffectiveSettings: &gitlabcollected.EffectiveSettings{PreventForkingOutsideGroup: true}}},
+		{"maintainer only through a shared group", "group_maintainer_access_through_shared_groups", true,
+			gitlabcollected.Organization{Group: &gitlab2.Group{}, EffectiveMembers: []*gitlabcollected.EffectiveMember{makeMember(shared)}}},
+		{"maintainer through a parent group", "group_maintainer_access_through_shared_groups", false,
+			gitlabcollected.Organization{Group: &gitlab2.Group{}, EffectiveMembers: []*gitlabcollected.EffectiveMember{makeMember(shared, inherited)}}},
+		{"developer through a shared group", "group_maintainer_access_through_shared_groups", false,
+			gitlabcollected.Organization{Group: &gitlab2.Group{}, EffectiveMembers: []*gitlabcollected.EffectiveMember{makeMember(sharedAsDeveloper)}}},
+	}
+
+	for _, test := range tests {
+		PolicyTestTemplate(t, test.name, test.args,
+			namespace.Organization, test.policyName, test.shouldBeViolated, scm_type.GitLab)
+	}
+}
@@ -12,6 +12,7 @@ import (
 	gitlabcollected "github.com/Legit-Labs/legitify/internal/collected/gitlab_collected"
 	"github.com/Legit-Labs/legitify/internal/common/namespace"
 	"github.com/google/go-github/v53/github"
+	"github.com/stretchr/testify/require"
 )
 
 func repositoryTestTemplate(t *testing.T, name string, mockData interface{}, testedPolicyName string, expectFailure bool, scmType scm_type.ScmType) {
@@ -64,6 +65,30 @@ func TestRepositoryBranchProtection(t *testing.T) {
 	}
 }
 
+func TestRepositoryProtectedByOrganizationRuleset(t *testing.T) {
+	name := "repository protected only by an organization ruleset"
+	testedPolicyName := "missing_default_branch_protection"
+	makeMockData := func(enforcement string) githubcollected.Repository {
+		repo := makeRepoForBranch(githubcollected.GitHubQLBranch{Name: github.String("main")})
+		ruleset := &types.Ruleset{
+			Ruleset: github.Ruleset{ID: 1, Name: "protect default branches", Enforcement: enforcement},
+			Conditions: &types.RulesetConditions{
+				RefName:        &github.RulesetRefConditionParameters{Include: []string{"~DEFAULT_BRANCH"}},
+				RepositoryName: &github.RulesetRepositoryConditionParameters{Include: []string{"~ALL"}},
+			},
+			Rules: []*types.RulesetRule{{Type: "pull_request"}},
+		}
+		target := githubcollected.RulesetTarget{Organization: "org", Repository: repo.Name(), Branch: "main", IsDefaultBranch: true}
+		repo.RulesSet = githubcollected.EffectiveRules(nil, target, []*types.Ruleset{ruleset})
+		return repo
+	}
+
+	enforcements := []string{"evaluate", "active"}
+	for i, flag := range bools {
+		repositoryTestTemplate(t, name, makeMockData(enforcements[i]), testedPolicyName, flag, scm_type.GitHub)
+	}
+}
+
 func TestRepositoryForcePush(t *testing.T) {
 	name := "repository should have branch protection: force push"
 	testedPolicyName := "missing_default_branch_protection_force_push"
@@ -768,3 +793,552 @@ func TestGitlabRepositoryRestrictsOverrideVariables(t *testing.T) {
 		repositoryTestTemplate(t, name, makeMockData(flag), testedPolicyName, expectFailure, scm_type.GitLab)
 	}
 }
+
+func TestGitlabRepositorySecretVariables(t *testing.T) {
+	name := "Secret CI/CD Variables Should Be Masked And Protected"
+	testedPolicyName := "secret_variable_not_masked_or_protected"
+
+	makeMockData := func(variable *gitlabcollected.CIVariable) gitlabcollected.Repository {
+		return gitlabcollected.Repository{
+			Project:   &gitlab2.Project{},
+			Variables: []*gitlabcollected.CIVariable{variable},
+		}
+	}
+
+	repositoryTestTemplate(t, name, makeMockData(&gitlabcollected.CIVariable{Key: "AWS_SECRET_ACCESS_KEY", Protected: true}), testedPolicyName, true, scm_type.GitLab)
+	repositoryTestTemplate(t, name, makeMockData(&gitlabcollected.CIVariable{Key: "npm_password", Masked: true}), testedPolicyName, true, scm_type.GitLab)
+	repositoryTestTemplate(t, name, makeMockData(&gitlabcollected.CIVariable{Key: "AWS_SECRET_ACCESS_KEY", Protected: true, Masked: true}), testedPolicyName, false, scm_type.GitLab)
+	repositoryTestTemplate(t, name, makeMockData(&gitlabcollected.CIVariable{Key: "NODE_ENV"}), testedPolicyName, false, scm_type.GitLab)
+}
+
+func TestGitlabRepositorySharedRunners(t *testing.T) {
+	name := "Projects With Protected Variables Should Not Use Shared Runners"
+	testedPolicyName := "shared_runners_enabled_on_sensitive_project"
+
+	makeMockData := func(sharedRunners bool, protected bool) gitlabcollected.Repository {
+		return gitlabcollected.Repository{
+			Project:   &gitlab2.Project{SharedRunnersEnabled: sharedRunners},
+			Variables: []*gitlabcollected.CIVariable{{Key: "DEPLOY_KEY", Protected: protected}},
+		}
+	}
+
+	repositoryTestTemplate(t, name, makeMockData(true, true), testedPolicyName, true, scm_type.GitLab)
+	repositoryTestTemplate(t, name, makeMockData(false, true), testedPolicyName, false, scm_type.GitLab)
+	repositoryTestTemplate(t, name, makeMockData(true, false), testedPolicyName, false, scm_type.GitLab)
+}
+
+func TestGitlabRepositoryDeployTokenExpiration(t *testing.T) {
+	name := "Deploy Tokens Should Have An Expiration Date"
+	testedPolicyName := "deploy_token_without_expiration"
+	expiresAt := time.Now().AddDate(1, 0, 0)
+
+	for _, expectFailure := range bools {
+		token := &gitlab2.DeployToken{Name: "ci", Scopes: []string{"read_repository", "read_registry"}}
+		if !expectFailure {
+			token.ExpiresAt = &expiresAt
+		}
+		repositoryTestTemplate(t, name, gitlabcollected.Repository{
+			Project:      &gitlab2.Project{},
+			DeployTokens: []*gitlab2.DeployToken{token},
+		}, testedPolicyName, expectFailure, scm_type.GitLab)
+	}
+}
+
+func TestGitlabRepositoryAccessTokens(t *testing.T) {
+	now := time.Now()
+	old := now.AddDate(0, -6, 0)
+	expiresAt := gitlab2.ISOTime(now.AddDate(0, 3, 0))
+	makeMockData := func(token *gitlabcollected.AccessToken) gitlabcollected.Repository {
+		token.Name = "ci"
+		token.Type = gitlabcollected.ProjectAccessToken
+		token.Active = true
+		return gitlabcollected.Repository{
+			Project:      &gitlab2.Project{},
+			AccessTokens: []*gitlabcollected.AccessToken{token},
+		}
+	}
+
+	tests := []struct {
+		name             string
+		policyName       string
+		shouldBeViolated bool
+		token            *gitlabcollected.AccessToken
+	}{
+		{"project access token never expires", "project_access_token_without_expiration", true,
+			&gitlabcollected.AccessToken{Scopes: []string{"read_api"}, CreatedAt: &now, LastUsedAt: &now}},
+		{"project access token expires", "project_access_token_without_expiration", false,
+			&gitlabcollected.AccessToken{Scopes: []string{"read_api"}, CreatedAt: &now, LastUsedAt: &now, ExpiresAt: &expiresAt}},
+		{"owner project access token has api scope", "project_access_token_with_owner_api_scope", true,
+			&gitlabcollected.AccessToken{Scopes: []string{"api"}, AccessLevel: gitlab2.OwnerPermissions, CreatedAt: &now, ExpiresAt: &expiresAt}},
+		{"maintainer project access token has api scope", "project_access_token_with_owner_api_scope", false,
+			&gitlabcollected.AccessToken{Scopes: []string{"api"}, AccessLevel: gitlab2.MaintainerPermissions, CreatedAt: &now, ExpiresAt: &expiresAt}},
+		{"project access token was never used", "unused_project_access_token", true,
+			&gitlabcollected.AccessToken{Scopes: []string{"read_api"}, CreatedAt: &old, ExpiresAt: &expiresAt}},
+		{"project access token was not used recently", "unused_project_access_token", true,
+			&gitlabcollected.AccessToken{Scopes: []string{"read_api"}, CreatedAt: &old, LastUsedAt: &old, ExpiresAt: &expiresAt}},
+		{"project access token was used recently", "unused_project_access_token", false,
+			&gitlabcollected.AccessToken{Scopes: []string{"read_api"}, CreatedAt: &old, LastUsedAt: &now, ExpiresAt: &expiresAt}},
+		{"new project access token was never used", "unused_project_access_token", false,
+			&gitlabcollected.AccessToken{Scopes: []string{"read_api"}, CreatedAt: &now, ExpiresAt: &expiresAt}},
+	}
+
+	for _, test := range tests {
+		repositoryTestTemplate(t, test.name, makeMockData(test.token), test.policyName, test.shouldBeViolated, scm_type.GitLab)
+	}
+}
+
+func TestGitlabRepositoryProtectedTags(t *testing.T) {
+	makeMockData := func(tags ...*gitlab2.ProtectedTag) gitlabcollected.Repository {
+		return gitlabcollected.Repository{
+			Project:       &gitlab2.Project{},
+			ProtectedTags: append([]*gitlab2.ProtectedTag{}, tags...),
+		}
+	}
+	makeTag := func(level gitlab2.AccessLevelValue) *gitlab2.ProtectedTag {
+		return &gitlab2.ProtectedTag{
+			Name:               "v*",
+			CreateAccessLevels: []*gitlab2.TagAccessDescription{{AccessLevel: level}},
+		}
+	}
+
+	repositoryTestTemplate(t, "Release Tags Should Be Protected", makeMockData(), "tags_not_protected", true, scm_type.GitLab)
+	repositoryTestTemplate(t, "Release Tags Should Be Protected", makeMockData(makeTag(gitlab2.MaintainerPermissions)), "tags_not_protected", false, scm_type.GitLab)
+	repositoryTestTemplate(t, "Release Tags Should Be Protected", gitlabcollected.Repository{Project: &gitlab2.Project{}}, "tags_not_protected", false, scm_type.GitLab)
+
+	name := "Protected Tags Should Only Be Created By Maintainers"
+	testedPolicyName := "protected_tag_created_by_developers"
+	repositoryTestTemplate(t, name, makeMockData(makeTag(gitlab2.DeveloperPermissions)), testedPolicyName, true, scm_type.GitLab)
+	repositoryTestTemplate(t, name, makeMockData(makeTag(gitlab2.MaintainerPermissions)), testedPolicyName, false, scm_type.GitLab)
+	repositoryTestTemplate(t, name, makeMockData(makeTag(gitlab2.NoPermissions)), testedPolicyName, false, scm_type.GitLab)
+}
+
+func TestGitlabRepositoryProductionEnvironments(t *testing.T) {
+	makeMockData := func(environment *gitlabcollected.Environment) gitlabcollected.Repository {
+		return gitlabcollected.Repository{
+			Project:      &gitlab2.Project{},
+			Environments: []*gitlabcollected.Environment{environment},
+		}
+	}
+	makeProtection := func(deployLevel gitlab2.AccessLevelValue, requiredApprovals int, ruleApprovals int) *gitlabcollected.ProtectedEnvironment {
+		protection := &gitlabcollected.ProtectedEnvironment{
+			ProtectedEnvironment: gitlab2.ProtectedEnvironment{
+				Name:                  "production",
+				DeployAccessLevels:    []*gitlab2.EnvironmentAccessDescription{{AccessLevel: deployLevel}},
+				RequiredApprovalCount: requiredApprovals,
+			},
+		}
+		if ruleApprovals > 0 {
+			protection.ApprovalRules = []*gitlabcollected.EnvironmentApprovalRule{{GroupID: 1, RequiredApprovals: ruleApprovals}}
+		}
+		return protection
+	}
+
+	tests := []struct {
+		name             string
+		policyName       string
+		shouldBeViolated bool
+		environment      *gitlabcollected.Environment
+	}{
+		{"unprotected production environment", "production_environment_without_approval", true,
+			&gitlabcollected.Environment{Name: "production", Tier: "production"}},
+		{"production environment without approvals", "production_environment_without_approval", true,
+			&gitlabcollected.Environment{Name: "prod-eu", Tier: "other", Protection: makeProtection(gitlab2.MaintainerPermissions, 0, 0)}},
+		{"production environment with required approvals", "production_environment_without_approval", false,
+			&gitlabcollected.Environment{Name: "production", Tier: "production", Protection: makeProtection(gitlab2.MaintainerPermissions, 1, 0)}},
+		{"production environment with approval rules", "production_environment_without_approval", false,
+			&gitlabcollected.Environment{Name: "production", Tier: "production", Protection: makeProtection(gitlab2.MaintainerPermissions, 0, 2)}},
+		{"unprotected staging environment", "production_environment_without_approval", false,
+			&gitlabcollected.Environment{Name: "staging", Tier: "staging"}},
+		{"unprotected production environment is deployable by developers", "production_environment_deployable_by_developers", true,
+			&gitlabcollected.Environment{Name: "production", Tier: "production"}},
+		{"production environment is deployable by developers", "production_environment_deployable_by_developers", true,
+			&gitlabcollected.Environment{Name: "production", Tier: "production", Protection: makeProtection(gitlab2.DeveloperPermissions, 1, 0)}},
+		{"production environment is deployable by maintainers", "production_environment_deployable_by_developers", false,
+			&gitlabcollected.Environment{Name: "production", Tier: "production", Protection: makeProtection(gitlab2.MaintainerPermissions, 1, 0)}},
+	}
+
+	for _, test := range tests {
+		repositoryTestTemplate(t, test.name, makeMockData(test.environment), test.policyName, test.shouldBeViolated, scm_type.GitLab)
+	}
+}
+
+func makeRepoWithWorkflow(t *testing.T, content string) githubcollected.Repository {
+	workflow, err := githubcollected.ParseWorkflow(".github/workflows/ci.yml", []byte(content))
+	require.Nil(t, err)
+
+	repo := makeRepo(githubcollected.GitHubQLRepository{Name: "REPO"})
+	repo.Workflows = []*githubcollected.Workflow{workflow}
+	return repo
+}
+
+func TestRepositoryWorkflowPullRequestTargetCheckout(t *testing.T) {
+	name := "pull_request_target workflows should not check out the pull request code"
+	testedPolicyName := "workflow_pull_request_target_checks_out_pr_code"
+
+	workflows := map[bool]string{
+		true: `
+on: pull_request_target
+jobs:
+  build:
+    steps:
+      - uses: actions/checkout@v3
+        with:
+          ref: ${{ github.event.pull_request.head.sha }}
+      - run: make test
+`,
+		false: `
+on: [pull_request_target]
+jobs:
+  label:
+    steps:
+      - uses: actions/checkout@v3
+      - uses: actions/labeler@v4
+`,
+	}
+
+	for _, expectFailure := range bools {
+		repositoryTestTemplate(t, name, makeRepoWithWorkflow(t, workflows[expectFailure]), testedPolicyName, expectFailure, scm_type.GitHub)
+	}
+}
+
+func TestRepositoryWorkflowScriptInjection(t *testing.T) {
+	name := "workflows should not use untrusted inputs in scripts"
+	testedPolicyName := "workflow_script_injection"
+
+	workflows := map[bool]string{
+		true: `
+on:
+  issues:
+    types: [opened]
+jobs:
+  triage:
+    steps:
+      - run: echo "${{ github.event.issue.title }}"
+`,
+		false: `
+on:
+  issues:
+    types: [opened]
+jobs:
+  triage:
+    steps:
+      - run: echo "$TITLE" ${{ github.event.issue.number }}
+        env:
+          TITLE: ${{ github.event.issue.title }}
+`,
+	}
+
+	for _, expectFailure := range bools {
+		repositoryTestTemplate(t, name, makeRepoWithWorkflow(t, workflows[expectFailure]), testedPolicyName, expectFailure, scm_type.GitHub)
+	}
+
+	githubScript := `
+on: issue_comment
+jobs:
+  reply:
+    steps:
+      - uses: actions/github-script@v6
+        with:
+          script: console.log("${{ github.event.comment.body }}")
+`
+	repositoryTestTemplate(t, "github-script "+name, makeRepoWithWorkflow(t, githubScript), testedPolicyName, true, scm_type.GitHub)
+}
+
+func TestRepositoryWorkflowActionNotPinned(t *testing.T) {
+	name := "workflows should use actions pinned to a full commit sha"
+	testedPolicyName := "workflow_action_not_pinned_to_sha"
+
+	workflows := map[bool]string{
+		true: `
+on: push
+jobs:
+  build:
+    steps:
+      - uses: actions/checkout@8e5e7e5ab8b370d6c329ec480221332ada57f0ab
+      - uses: some/action@v1
+`,
+		false: `
+on: push
+jobs:
+  build:
+    steps:
+      - uses: actions/checkout@8e5e7e5ab8b370d6c329ec480221332ada57f0ab
+      - uses: ./.github/actions/local
+      - run: make
+  release:
+    uses: org/workflows/.github/workflows/release.yml@8e5e7e5ab8b370d6c329ec480221332ada57f0ab
+`,
+	}
+
+	for _, expectFailure := range bools {
+		repositoryTestTemplate(t, name, makeRepoWithWorkflow(t, workflows[expectFailure]), testedPolicyName, expectFailure, scm_type.GitHub)
+	}
+}
+
+func TestRepositoryWorkflowMissingPermissions(t *testing.T) {
+	name := "workflows should declare top-level permissions"
+	testedPolicyName := "workflow_missing_top_level_permissions"
+
+	workflows := map[bool]string{
+		true: `
+on: push
+jobs:
+  build:
+    permissions:
+      contents: read
+    steps:
+      - run: make
+`,
+		false: `
+on: push
+permissions: {}
+jobs:
+  build:
+    steps:
+      - run: make
+`,
+	}
+
+	for _, expectFailure := range bools {
+		repositoryTestTemplate(t, name, makeRepoWithWorkflow(t, workflows[expectFailure]), testedPolicyName, expectFailure, scm_type.GitHub)
+	}
+}
+
+func TestRepositoryStaleWriteDeployKey(t *testing.T) {
+	name := "repository should not have write deploy keys older than a year"
+	testedPolicyName := "stale_write_deploy_key"
+	makeMockData := func(readOnly bool, createdAt int) githubcollected.Repository {
+		return githubcollected.Repository{
+			DeployKeys: []*githubcollected.RepositoryDeployKey{
+				{
+					ID:        1,
+					Title:     "deploy",
+					ReadOnly:  readOnly,
+					CreatedAt: createdAt,
+				},
+			},
+		}
+	}
+
+	oldKey := 957796546000000000 //08.05.2000
+	newKey := int(time.Now().UnixNano())
+	repositoryTestTemplate(t, name, makeMockData(false, oldKey), testedPolicyName, true, scm_type.GitHub)
+	repositoryTestTemplate(t, name, makeMockData(true, oldKey), testedPolicyName, false, scm_type.GitHub)
+	repositoryTestTemplate(t, name, makeMockData(false, newKey), testedPolicyName, false, scm_type.GitHub)
+}
+
+func TestRepositoryUnusedWriteDeployKey(t *testing.T) {
+	name := "repository should not have write deploy keys that were not used recently"
+	testedPolicyName := "unused_write_deploy_key"
+	makeMockData := func(readOnly bool, lastUsed int) githubcollected.Repository {
+		return githubcollected.Repository{
+			DeployKeys: []*githubcollected.RepositoryDeployKey{
+				{
+					ID:        1,
+					Title:     "deploy",
+					ReadOnly:  readOnly,
+					CreatedAt: 957796546000000000, //08.05.2000
+					LastUsed:  lastUsed,
+				},
+			},
+		}
+	}
+
+	oldUse := int(time.Now().AddDate(0, -6, 0).UnixNano())
+	recentUse := int(time.Now().AddDate(0, 0, -1).UnixNano())
+	repositoryTestTemplate(t, name, makeMockData(false, 0), testedPolicyName, true, scm_type.GitHub)
+	repositoryTestTemplate(t, name, makeMockData(false, oldUse), testedPolicyName, true, scm_type.GitHub)
+	repositoryTestTemplate(t, name, makeMockData(true, oldUse), testedPolicyName, false, scm_type.GitHub)
+	repositoryTestTemplate(t, name, makeMockData(false, recentUse), testedPolicyName, false, scm_type.GitHub)
+}
+
+func makeRepoWithEnvironment(environment *githubcollected.RepositoryEnvironment) githubcollected.Repository {
+	return githubcollected.Repository{
+		Environments: []*githubcollected.RepositoryEnvironment{environment},
+	}
+}
+
+func TestRepositoryProductionEnvironmentReviewers(t *testing.T) {
+	name := "production environments should require reviewers"
+	testedPolicyName := "production_environment_without_reviewers"
+	reviewers := []*githubcollected.EnvironmentReviewer{{Type: "Team", Name: "release-managers"}}
+
+	repositoryTestTemplate(t, name, makeRepoWithEnvironment(&githubcollected.RepositoryEnvironment{
+		Name:      "Production",
+		Reviewers: []*githubcollected.EnvironmentReviewer{},
+	}), testedPolicyName, true, scm_type.GitHub)
+	repositoryTestTemplate(t, name, makeRepoWithEnvironment(&githubcollected.RepositoryEnvironment{
+		Name:      "production",
+		Reviewers: reviewers,
+	}), testedPolicyName, false, scm_type.GitHub)
+	repositoryTestTemplate(t, name, makeRepoWithEnvironment(&githubcollected.RepositoryEnvironment{
+		Name:      "staging",
+		Reviewers: []*githubcollected.EnvironmentReviewer{},
+	}), testedPolicyName, false, scm_type.GitHub)
+}
+
+func TestRepositoryProductionEnvironmentBranchPolicy(t *testing.T) {
+	name := "production environments should restrict deployment branches"
+	testedPolicyName := "production_environment_without_branch_policy"
+
+	for _, expectFailure := range bools {
+		environment := &githubcollected.RepositoryEnvironment{
+			Name:      "prod-us",
+			Reviewers: []*githubcollected.EnvironmentReviewer{},
+		}
+		if !expectFailure {
+			environment.DeploymentBranchPolicy = &githubcollected.EnvironmentBranchPolicy{ProtectedBranches: true}
+		}
+		repositoryTestTemplate(t, name, makeRepoWithEnvironment(environment), testedPolicyName, expectFailure, scm_type.GitHub)
+	}
+}
+
+func makeRepoWithSecurityAlerts(alerts *githubcollected.RepositorySecurityAlerts) githubcollected.Repository {
+	return githubcollected.Repository{
+		SecurityAlerts: alerts,
+	}
+}
+
+func TestRepositoryDependabotCriticalAlerts(t *testing.T) {
+	name := "critical dependabot alerts should be remediated within 30 days"
+	testedPolicyName := "dependabot_critical_alerts_not_remediated"
+	oldAlert := 957796546000000000 //08.05.2000
+	newAlert := int(time.Now().UnixNano())
+
+	repositoryTestTemplate(t, name, makeRepoWithSecurityAlerts(&githubcollected.RepositorySecurityAlerts{
+		Dependabot: map[string]*githubcollected.OpenAlerts{"critical": {Count: 2, OldestCreatedAt: oldAlert}},
+	}), testedPolicyName, true, scm_type.GitHub)
+	repositoryTestTemplate(t, name, makeRepoWithSecurityAlerts(&githubcollected.RepositorySecurityAlerts{
+		Dependabot: map[string]*githubcollected.OpenAlerts{"critical": {Count: 1, OldestCreatedAt: newAlert}},
+	}), testedPolicyName, false, scm_type.GitHub)
+	repositoryTestTemplate(t, name, makeRepoWithSecurityAlerts(&githubcollected.RepositorySecurityAlerts{
+		Dependabot: map[string]*githubcollected.OpenAlerts{"low": {Count: 1, OldestCreatedAt: oldAlert}},
+	}), testedPolicyName, false, scm_type.GitHub)
+	repositoryTestTemplate(t, name, makeRepoWithSecurityAlerts(nil), testedPolicyName, false, scm_type.GitHub)
+}
+
+func TestRepositoryCodeScanningCriticalAlerts(t *testing.T) {
+	name := "critical code scanning alerts should be remediated within 30 days"
+	testedPolicyName := "code_scanning_critical_alerts_not_remediated"
+	oldAlert := 957796546000000000 //08.05.2000
+	newAlert := int(time.Now().UnixNano())
+
+	repositoryTestTemplate(t, name, makeRepoWithSecurityAlerts(&githubcollected.RepositorySecurityAlerts{
+		CodeScanning: map[string]*githubcollected.OpenAlerts{"critical": {Count: 1, OldestCreatedAt: oldAlert}},
+	}), testedPolicyName, true, scm_type.GitHub)
+	repositoryTestTemplate(t, name, makeRepoWithSecurityAlerts(&githubcollected.RepositorySecurityAlerts{
+		CodeScanning: map[string]*githubcollected.OpenAlerts{"critical": {Count: 1, OldestCreatedAt: newAlert}},
+	}), testedPolicyName, false, scm_type.GitHub)
+}
+
+func TestRepositorySecretScanningAlerts(t *testing.T) {
+	name := "secret scanning alerts should be resolved within 7 days"
+	testedPolicyName := "secret_scanning_alerts_not_resolved"
+	oldAlert := int(time.Now().AddDate(0, 0, -8).UnixNano())
+	newAlert := int(time.Now().AddDate(0, 0, -1).UnixNano())
+
+	repositoryTestTemplate(t, name, makeRepoWithSecurityAlerts(&githubcollected.RepositorySecurityAlerts{
+		SecretScanning: &githubcollected.OpenAlerts{Count: 1, OldestCreatedAt: oldAlert},
+	}), testedPolicyName, true, scm_type.GitHub)
+	repositoryTestTemplate(t, name, makeRepoWithSecurityAlerts(&githubcollected.RepositorySecurityAlerts{
+		SecretScanning: &githubcollected.OpenAlerts{Count: 1, OldestCreatedAt: newAlert},
+	}), testedPolicyName, false, scm_type.GitHub)
+	repositoryTestTemplate(t, name, makeRepoWithSecurityAlerts(&githubcollected.RepositorySecurityAlerts{
+		SecretScanning: &githubcollected.OpenAlerts{},
+	}), testedPolicyName, false, scm_type.GitHub)
+}
+
+func makeRepoWithCodeowners(codeowners *githubcollected.Codeowners) githubcollected.Repository {
+	return githubcollected.Repository{
+		Repository: &githubcollected.GitHubQLRepository{
+			DefaultBranchRef: &githubcollected.GitHubQLBranch{
+				BranchProtectionRule: &githubcollected.GitHubQLBranchProtectionRule{
+					RequiresCodeOwnerReviews: github.Bool(true),
+				},
+			},
+		},
+		Codeowners: codeowners,
+	}
+}
+
+func TestRepositoryMissingCodeowners(t *testing.T) {
+	name := "repository requiring code owner review should have a CODEOWNERS file"
+	testedPolicyName := "missing_codeowners_file"
+
+	repositoryTestTemplate(t, name, makeRepoWithCodeowners(&githubcollected.Codeowners{}), testedPolicyName, true, scm_type.GitHub)
+	repositoryTestTemplate(t, name, makeRepoWithCodeowners(&githubcollected.Codeowners{Path: "CODEOWNERS"}), testedPolicyName, false, scm_type.GitHub)
+	repositoryTestTemplate(t, name, makeRepoWithCodeowners(nil), testedPolicyName, false, scm_type.GitHub)
+}
+
+func TestRepositoryCodeownersErrors(t *testing.T) {
+	name := "CODEOWNERS file should not have errors"
+	testedPolicyName := "codeowners_file_has_errors"
+	makeMockData := func(kind string) githubcollected.Repository {
+		return makeRepoWithCodeowners(&githubcollected.Codeowners{
+			Path:   ".github/CODEOWNERS",
+			Errors: []*githubcollected.CodeownersError{{Line: 3, Kind: kind, Message: "error"}},
+		})
+	}
+
+	repositoryTestTemplate(t, name, makeMockData("Invalid pattern"), testedPolicyName, true, scm_type.GitHub)
+	repositoryTestTemplate(t, name, makeMockData("Unknown owner"), testedPolicyName, false, scm_type.GitHub)
+}
+
+func TestRepositoryCodeownersOwners(t *testing.T) {
+	name := "code owners should exist and have write access"
+	testedPolicyName := "codeowners_owner_cannot_review"
+	makeMockData := func(status string) githubcollected.Repository {
+		return makeRepoWithCodeowners(&githubcollected.Codeowners{
+			Path: ".github/CODEOWNERS",
+			Owners: []*githubcollected.CodeownersOwner{
+				{Name: "@org/devops", Type: githubcollected.CodeownerTypeTeam, Status: status},
+			},
+		})
+	}
+
+	repositoryTestTemplate(t, name, makeMockData(githubcollected.CodeownerNotFound), testedPolicyName, true, scm_type.GitHub)
+	repositoryTestTemplate(t, name, makeMockData(githubcollected.CodeownerNoWriteAccess), testedPolicyName, true, scm_type.GitHub)
+	repositoryTestTemplate(t, name, makeMockData(githubcollected.CodeownerValid), testedPolicyName, false, scm_type.GitHub)
+	repositoryTestTemplate(t, name, makeMockData(githubcollected.CodeownerUnresolved), testedPolicyName, false, scm_type.GitHub)
+}
+
+func TestRepositorySensitivePathsCodeowners(t *testing.T) {
+	name := "sensitive paths should be covered by CODEOWNERS"
+	testedPolicyName := "sensitive_paths_without_codeowners"
+
+	for _, expectFailure := range bools {
+		codeowners := &githubcollected.Codeowners{
+			Path:           "CODEOWNERS",
+			UncoveredPaths: []string{},
+		}
+		if expectFailure {
+			codeowners.UncoveredPaths = []string{".github/workflows/"}
+		}
+		repositoryTestTemplate(t, name, makeRepoWithCodeowners(codeowners), testedPolicyName, expectFailure, scm_type.GitHub)
+	}
+}
+
+func TestGitlabRepositoryEffectiveMembers(t *testing.T) {
+	makeMockData := func(grants ...*gitlabcollected.MembershipGrant) gitlabcollected.Repository {
+		member := &gitlabcollected.EffectiveMember{ID: 1, Username: "user", Grants: grants}
+		for _, grant := range grants {
+			if grant.AccessLevel > member.AccessLevel {
+				member.AccessLevel = grant.AccessLevel
+			}
+		}
+		return gitlabcollected.Repository{
+			Project:          &gitlab2.Project{},
+			EffectiveMembers: []*gitlabcollected.EffectiveMember{member},
+		}
+	}
+	shared := &gitlabcollected.MembershipGrant{Source: gitlabcollected.MembershipShared, Path: "other", AccessLevel: gitlab2.OwnerPermissions}
+	direct := &gitlabcollected.MembershipGrant{Source: gitlabcollected.MembershipDirect, Path: "group/project", AccessLevel: gitlab2.MaintainerPermissions}
+	directAsDeveloper := &gitlabcollected.MembershipGrant{Source: gitlabcollected.MembershipDirect, Path: "group/project", AccessLevel: gitlab2.DeveloperPermissions}
+
+	name := "Maintainer Access To The Project Should Not Be Granted Through Group Sharing"
+	testedPolicyName := "project_maintainer_access_through_shared_groups"
+	repositoryTestTemplate(t, name, makeMockData(shared), testedPolicyName, true, scm_type.GitLab)
+	repositoryTestTemplate(t, name, makeMockData(shared, directAsDeveloper), testedPolicyName, true, scm_type.GitLab)
+	repositoryTestTemplate(t, name, makeMockData(shared, direct), testedPolicyName, false, scm_type.GitLab)
+	repositoryTestTemplate(t, name, makeMockData(directAsDeveloper), testedPolicyName, false, scm_type.GitLab)
+}
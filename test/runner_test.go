@@ -0,0 +1,116 @@
+package test
+
+import (
+	"testing"
+
+	"github.com/Legit-Labs/legitify/internal/clients/github/types"
+	githubcollected "github.com/Legit-Labs/legitify/internal/collected/github"
+	"github.com/Legit-Labs/legitify/internal/common/namespace"
+	"github.com/Legit-Labs/legitify/internal/common/scm_type"
+	"github.com/google/go-github/v53/github"
+)
+
+func newRunnerMock(ephemeral bool) githubcollected.Runner {
+	return githubcollected.Runner{
+		Organization: defaultOrg,
+		Runner: &types.Runner{
+			Runner:    github.Runner{ID: github.Int64(1), Name: github.String("runner")},
+			Ephemeral: github.Bool(ephemeral),
+		},
+		AllowedRepositories: []*githubcollected.RunnerRepository{},
+	}
+}
+
+func newOrganizationRunnerMock(ephemeral bool, visibility string, allowsPublic bool, allowed ...*githubcollected.RunnerRepository) githubcollected.Runner {
+	runner := newRunnerMock(ephemeral)
+	runner.RunnerGroup = &github.RunnerGroup{
+		Visibility:               github.String(visibility),
+		AllowsPublicRepositories: github.Bool(allowsPublic),
+	}
+	runner.AllowedRepositories = append(runner.AllowedRepositories, allowed...)
+	return runner
+}
+
+func newRepositoryRunnerMock(ephemeral bool, private bool) githubcollected.Runner {
+	runner := newRunnerMock(ephemeral)
+	runner.Repository = &githubcollected.RunnerRepository{Name: "REPO", Private: private}
+	return runner
+}
+
+func TestRunner(t *testing.T) {
+	privateRepo := &githubcollected.RunnerRepository{Name: "private", Private: true}
+	publicRepo := &githubcollected.RunnerRepository{Name: "public", Private: false}
+
+	tests := []struct {
+		name             string
+		policyName       string
+		shouldBeViolated bool
+		args             githubcollected.Runner
+	}{
+		{
+			name:             "runner is registered to a public repository",
+			policyName:       "self_hosted_runner_used_by_public_repositories",
+			shouldBeViolated: true,
+			args:             newRepositoryRunnerMock(true, false),
+		},
+		{
+			name:             "runner is registered to a private repository",
+			policyName:       "self_hosted_runner_used_by_public_repositories",
+			shouldBeViolated: false,
+			args:             newRepositoryRunnerMock(true, true),
+		},
+		{
+			name:             "runner group allows all repositories including public ones",
+			policyName:       "self_hosted_runner_used_by_public_repositories",
+			shouldBeViolated: true,
+			args:             newOrganizationRunnerMock(true, "all", true),
+		},
+		{
+			name:             "runner group allows a selected public repository",
+			policyName:       "self_hosted_runner_used_by_public_repositories",
+			shouldBeViolated: true,
+			args:             newOrganizationRunnerMock(true, "selected", true, privateRepo, publicRepo),
+		},
+		{
+			name:             "runner group doesn't allow public repositories",
+			policyName:       "self_hosted_runner_used_by_public_repositories",
+			shouldBeViolated: false,
+			args:             newOrganizationRunnerMock(true, "all", false),
+		},
+		{
+			name:             "non ephemeral runner can be used by public repositories",
+			policyName:       "non_ephemeral_runner_reachable_by_fork_pull_requests",
+			shouldBeViolated: true,
+			args:             newOrganizationRunnerMock(false, "all", true),
+		},
+		{
+			name:             "ephemeral runner can be used by public repositories",
+			policyName:       "non_ephemeral_runner_reachable_by_fork_pull_requests",
+			shouldBeViolated: false,
+			args:             newOrganizationRunnerMock(true, "all", true),
+		},
+		{
+			name:             "non ephemeral runner can only be used by private repositories",
+			policyName:       "non_ephemeral_runner_reachable_by_fork_pull_requests",
+			shouldBeViolated: false,
+			args:             newOrganizationRunnerMock(false, "selected", true, privateRepo),
+		},
+		{
+			name:             "runner is registered to a repository",
+			policyName:       "repository_runner_bypasses_runner_groups",
+			shouldBeViolated: true,
+			args:             newRepositoryRunnerMock(true, true),
+		},
+		{
+			name:             "runner is registered to the organization",
+			policyName:       "repository_runner_bypasses_runner_groups",
+			shouldBeViolated: false,
+			args:             newOrganizationRunnerMock(true, "selected", false, privateRepo),
+		},
+	}
+
+	for _, test := range tests {
+		PolicyTestTemplate(t, test.name, test.args,
+			namespace.Runner, test.policyName, test.shouldBeViolated, scm_type.GitHub)
+	}
+}
@@ -0,0 +1,154 @@
+package test
+
+import (
+	"fmt"
+	"testing"
+
+	githubcollected "github.com/Legit-Labs/legitify/internal/collected/github"
+	"github.com/Legit-Labs/legitify/internal/collected/gitlab_collected"
+	"github.com/Legit-Labs/legitify/internal/common/namespace"
+	"github.com/Legit-Labs/legitify/internal/common/scm_type"
+	"github.com/google/go-github/v53/github"
+	"github.com/xanzy/go-gitlab"
+)
+
+func newTeamMock(privacy string, adminRepositories int, maintainers ...string) githubcollected.Team {
+	team := githubcollected.Team{
+		Organization: defaultOrg,
+		Team: &github.Team{
+			Slug:    github.String("team"),
+			Privacy: github.String(privacy),
+		},
+		Members: []*githubcollected.TeamMember{{Login: "member", Role: githubcollected.TeamRoleMember}},
+	}
+	for _, maintainer := range maintainers {
+		team.Members = append(team.Members, &githubcollected.TeamMember{Login: maintainer, Role: githubcollected.TeamRoleMaintainer})
+	}
+	for i := 0; i < adminRepositories; i++ {
+		team.Repositories = append(team.Repositories, &githubcollected.TeamRepository{
+			Name:       fmt.Sprintf("repo%d", i),
+			Permission: "admin",
+		})
+	}
+	team.Repositories = append(team.Repositories, &githubcollected.TeamRepository{Name: "other", Permission: "push"})
+
+	return team
+}
+
+func TestTeam(t *testing.T) {
+	tests := []struct {
+		name             string
+		policyName       string
+		shouldBeViolated bool
+		args             githubcollected.Team
+	}{
+		{
+			name:             "secret team has admin on many repositories",
+			policyName:       "secret_team_with_admin_on_many_repositories",
+			shouldBeViolated: true,
+			args:             newTeamMock("secret", 3, "maintainer"),
+		},
+		{
+			name:             "visible team has admin on many repositories",
+			policyName:       "secret_team_with_admin_on_many_repositories",
+			shouldBeViolated: false,
+			args:             newTeamMock("closed", 3, "maintainer"),
+		},
+		{
+			name:             "secret team has admin on a single repository",
+			policyName:       "secret_team_with_admin_on_many_repositories",
+			shouldBeViolated: false,
+			args:             newTeamMock("secret", 1, "maintainer"),
+		},
+		{
+			name:             "team has no maintainers",
+			policyName:       "team_without_maintainers",
+			shouldBeViolated: true,
+			args:             newTeamMock("closed", 0),
+		},
+		{
+			name:             "team has a maintainer",
+			policyName:       "team_without_maintainers",
+			shouldBeViolated: false,
+			args:             newTeamMock("closed", 0, "maintainer"),
+		},
+		{
+			name:             "team has admin on many repositories",
+			policyName:       "team_grants_admin_broadly",
+			shouldBeViolated: true,
+			args:             newTeamMock("closed", 10, "maintainer"),
+		},
+		{
+			name:             "team has admin on a few repositories",
+			policyName:       "team_grants_admin_broadly",
+			shouldBeViolated: false,
+			args:             newTeamMock("closed", 2, "maintainer"),
+		},
+	}
+
+	for _, test := range tests {
+		PolicyTestTemplate(t, test.name, test.args,
+			namespace.Team, test.policyName, test.shouldBeViolated, scm_type.GitHub)
+	}
+}
+
+func newGitlabTeamMock(accessLevel gitlab.AccessLevelValue, shares int, memberAccessLevels ...gitlab.AccessLevelValue) gitlab_collected.Team {
+	team := gitlab_collected.Team{
+		Group:          &gitlab.Group{FullPath: "group/team"},
+		Members:        []*gitlab.GroupMember{},
+		SharedProjects: []*gitlab_collected.SharedWith{},
+		SharedGroups:   []*gitlab_collected.SharedWith{},
+	}
+	for _, level := range memberAccessLevels {
+		team.Members = append(team.Members, &gitlab.GroupMember{AccessLevel: level})
+	}
+	for i := 0; i < shares; i++ {
+		shared := &gitlab_collected.SharedWith{ID: i, FullPath: fmt.Sprintf("other/project%d", i), AccessLevel: accessLevel}
+		if i%2 == 0 {
+			team.SharedProjects = append(team.SharedProjects, shared)
+		} else {
+			team.SharedGroups = append(team.SharedGroups, shared)
+		}
+	}
+
+	return team
+}
+
+func TestGitlabTeam(t *testing.T) {
+	tests := []struct {
+		name             string
+		policyName       string
+		shouldBeViolated bool
+		args             gitlab_collected.Team
+	}{
+		{
+			name:             "group has no owners",
+			policyName:       "team_without_maintainers",
+			shouldBeViolated: true,
+			args:             newGitlabTeamMock(gitlab.DeveloperPermissions, 0, gitlab.MaintainerPermissions, gitlab.DeveloperPermissions),
+		},
+		{
+			name:             "group has an owner",
+			policyName:       "team_without_maintainers",
+			shouldBeViolated: false,
+			args:             newGitlabTeamMock(gitlab.DeveloperPermissions, 0, gitlab.OwnerPermissions),
+		},
+		{
+			name:             "group is shared with maintainer access on many projects and groups",
+			policyName:       "team_grants_admin_broadly",
+			shouldBeViolated: true,
+			args:             newGitlabTeamMock(gitlab.MaintainerPermissions, 10, gitlab.OwnerPermissions),
+		},
+		{
+			name:             "group is shared with developer access on many projects and groups",
+			policyName:       "team_grants_admin_broadly",
+			shouldBeViolated: false,
+			args:             newGitlabTeamMock(gitlab.DeveloperPermissions, 10, gitlab.OwnerPermissions),
+		},
+	}
+
+	for _, test := range tests {
+		PolicyTestTemplate(t, test.name, test.args,
+			namespace.Team, test.policyName, test.shouldBeViolated, scm_type.GitLab)
+	}
+}